@@ -10,10 +10,13 @@ import (
 
 var PlainTextIsEmptyErr = errors.New("plaintext is empty")
 var CipherTextIsEmptyErr = errors.New("ciphertext is empty")
+var CipherTextIsTooShortErr = errors.New("ciphertext is too short")
 var InvalidAESKeyErr = errors.New("aes key is invalid")
 
 type AES struct {
 	Key []byte
+	// Rand is the source of IVs, crypto/rand is used if it is nil
+	Rand io.Reader
 }
 
 func (aesObj *AES) Encrypt(plaintext []byte) ([]byte, error) {
@@ -28,8 +31,12 @@ func (aesObj *AES) Encrypt(plaintext []byte) ([]byte, error) {
 
 	ciphertext := make([]byte, aes.BlockSize+len(plaintext))
 
+	randReader := aesObj.Rand
+	if randReader == nil {
+		randReader = rand.Reader
+	}
 	iv := ciphertext[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+	if _, err := io.ReadFull(randReader, iv); err != nil {
 		return nil, err
 	}
 
@@ -42,6 +49,9 @@ func (aesObj *AES) Decrypt(ciphertext []byte) ([]byte, error) {
 	if len(ciphertext) == 0 {
 		return []byte{}, CipherTextIsEmptyErr
 	}
+	if len(ciphertext) < aes.BlockSize {
+		return nil, CipherTextIsTooShortErr
+	}
 
 	plaintext := make([]byte, len(ciphertext[aes.BlockSize:]))
 
@@ -56,3 +66,66 @@ func (aesObj *AES) Decrypt(ciphertext []byte) ([]byte, error) {
 
 	return plaintext, nil
 }
+
+var AuthenticationFailedErr = errors.New("ciphertext authentication failed")
+
+// AESGCM is AES in GCM mode, unlike AES its ciphertexts are authenticated,
+// so a tampered ciphertext or a wrong key is detected when decrypting
+type AESGCM struct {
+	Key []byte
+	// Rand is the source of nonces, crypto/rand is used if it is nil
+	Rand io.Reader
+}
+
+func (aesObj *AESGCM) newGCM() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(aesObj.Key)
+	if err != nil {
+		return nil, InvalidAESKeyErr
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt returns nonce || ciphertext || tag, additionalData is authenticated but not encrypted
+func (aesObj *AESGCM) Encrypt(plaintext []byte, additionalData []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return []byte{}, PlainTextIsEmptyErr
+	}
+
+	gcm, err := aesObj.newGCM()
+	if err != nil {
+		return nil, err
+	}
+
+	randReader := aesObj.Rand
+	if randReader == nil {
+		randReader = rand.Reader
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(randReader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt returns AuthenticationFailedErr if ciphertext or additionalData was changed or the key is wrong
+func (aesObj *AESGCM) Decrypt(ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) == 0 {
+		return []byte{}, CipherTextIsEmptyErr
+	}
+
+	gcm, err := aesObj.newGCM()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, AuthenticationFailedErr
+	}
+
+	nonce := ciphertext[:gcm.NonceSize()]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext[gcm.NonceSize():], additionalData)
+	if err != nil {
+		return nil, AuthenticationFailedErr
+	}
+	return plaintext, nil
+}
@@ -68,6 +68,29 @@ func (self Base58Check) Decode(input string) (result []byte, version byte, err e
 	return
 }
 
+// DecodeBytes is the same as Decode, but input is bytes
+// it does not convert input to a string and zeroes the intermediate decoded data
+func (self Base58Check) DecodeBytes(input []byte) (result []byte, version byte, err error) {
+	decoded, err := DecodeBytes(input)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		for i := range decoded {
+			decoded[i] = 0
+		}
+	}()
+	if len(decoded) < 5 {
+		return nil, 0, ErrInvalidFormat
+	}
+	version = decoded[0]
+	if !bytes.Equal(ChecksumFirst4Bytes(decoded[:len(decoded)-common.CheckSumLen]), decoded[len(decoded)-common.CheckSumLen:]) {
+		return nil, 0, ErrChecksum
+	}
+	result = append(result, decoded[1:len(decoded)-common.CheckSumLen]...)
+	return
+}
+
 var b58Check = Base58Check{}
 
 func DecodeCheck(input string) (result []byte, version byte, err error) {
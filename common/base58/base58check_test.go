@@ -71,3 +71,20 @@ func TestBase58CheckDecode(t *testing.T) {
 		assert.Equal(t, nil, err)
 	}
 }
+
+func TestBase58CheckDecodeBytes(t *testing.T) {
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	encoded := Base58Check{}.Encode(data, 0x00)
+
+	decoded, version, err := Base58Check{}.DecodeBytes([]byte(encoded))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, byte(0x00), version)
+	assert.Equal(t, data, decoded)
+
+	_, _, err = Base58Check{}.DecodeBytes([]byte(encoded[:len(encoded)-1] + "1"))
+	assert.NotEqual(t, nil, err)
+	_, _, err = Base58Check{}.DecodeBytes([]byte("0OIl"))
+	assert.NotEqual(t, nil, err)
+	_, _, err = Base58Check{}.DecodeBytes(nil)
+	assert.NotEqual(t, nil, err)
+}
@@ -89,13 +89,34 @@ func FastBase58DecodingAlphabet(str string, alphabet *Alphabet) ([]byte, error)
 	if len(str) == 0 {
 		return nil, fmt.Errorf("zero length string")
 	}
+	return fastBase58DecodingAlphabetRunes([]rune(str), alphabet)
+}
+
+// DecodeBytes decodes base58 encoded bytes without converting them to a string,
+// so secrets (eg: private keys) can be zeroed after decoding
+// intermediate buffers are zeroed before returning
+func DecodeBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("zero length string")
+	}
+	b58u := make([]rune, len(b))
+	for i, c := range b {
+		b58u[i] = rune(c)
+	}
+	defer func() {
+		for i := range b58u {
+			b58u[i] = 0
+		}
+	}()
+	return fastBase58DecodingAlphabetRunes(b58u, BTCAlphabet)
+}
 
+func fastBase58DecodingAlphabetRunes(b58u []rune, alphabet *Alphabet) ([]byte, error) {
 	var (
 		t        uint64
 		zmask, c uint32
 		zcount   int
 
-		b58u  = []rune(str)
 		b58sz = len(b58u)
 
 		outisz    = (b58sz + 3) / 4 // check to see if we need to change this buffer size to optimize
@@ -112,6 +133,11 @@ func FastBase58DecodingAlphabet(str string, alphabet *Alphabet) ([]byte, error)
 	}
 
 	var outi = make([]uint32, outisz)
+	defer func() {
+		for i := range outi {
+			outi[i] = 0
+		}
+	}()
 
 	for i := 0; i < b58sz && b58u[i] == zero; i++ {
 		zcount++
@@ -58,6 +58,14 @@ const (
 	MaxShardNumber = 8
 )
 
+// status of bridge requests (issuing requests)
+const (
+	BridgeRequestNotFoundStatus   = 0
+	BridgeRequestProcessingStatus = 1
+	BridgeRequestAcceptedStatus   = 2
+	BridgeRequestRejectedStatus   = 3
+)
+
 const PortalBTCIDStr = "b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"
 const PortalBNBIDStr = "b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"
 const PRVIDStr = "0000000000000000000000000000000000000000000000000000000000000004"
@@ -15,4 +15,12 @@ func HashB(b []byte) []byte {
 // and returns the result in Hash.
 func HashH(b []byte) Hash {
 	return Hash(sha3.Sum256(b))
-}
\ No newline at end of file
+}
+
+// Hash4Bls calculates Keccak-256 hashing of data, it is used to generate BLS keys
+// this is different from hash function for calculate transaction hash
+func Hash4Bls(data []byte) []byte {
+	hashMachine := sha3.NewLegacyKeccak256()
+	hashMachine.Write(data)
+	return hashMachine.Sum(nil)
+}
@@ -3,6 +3,8 @@ package crypto
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
 	"strconv"
 
@@ -244,7 +246,7 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	}
 
 	// Parse CoinCommitment
-	if offset > len(coinBytes) {
+	if offset >= len(coinBytes) {
 		// out of range
 		return errors.New("out of range Parse CoinCommitment")
 	}
@@ -264,7 +266,7 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	}
 
 	// Parse SNDerivator
-	if offset > len(coinBytes) {
+	if offset >= len(coinBytes) {
 		// out of range
 		return errors.New("out of range Parse SNDerivator")
 	}
@@ -282,7 +284,7 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	}
 
 	//Parse sn
-	if offset > len(coinBytes) {
+	if offset >= len(coinBytes) {
 		// out of range
 		return errors.New("out of range Parse sn")
 	}
@@ -302,7 +304,7 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	}
 
 	// Parse Randomness
-	if offset > len(coinBytes) {
+	if offset >= len(coinBytes) {
 		// out of range
 		return errors.New("out of range Parse Randomness")
 	}
@@ -319,7 +321,7 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	}
 
 	// Parse Value
-	if offset > len(coinBytes) {
+	if offset >= len(coinBytes) {
 		// out of range
 		return errors.New("out of range Parse PublicKey")
 	}
@@ -335,7 +337,7 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	}
 
 	// Parse Info
-	if offset > len(coinBytes) {
+	if offset >= len(coinBytes) {
 		// out of range
 		return errors.New("out of range Parse Info")
 	}
@@ -543,7 +545,7 @@ func (outputCoin *OutputCoin) SetBytes(bytes []byte) error {
 	}
 
 	// try get 1-byte for len
-	if offset > len(bytes) {
+	if offset >= len(bytes) {
 		// out of range
 		return errors.New("out of range Parse CoinDetails")
 	}
@@ -569,6 +571,8 @@ func (outputCoin *OutputCoin) SetBytes(bytes []byte) error {
 				// out of range
 				return errors.New("out of range Parse output coin details")
 			}
+			// drop fields set by the failed 1-byte attempt
+			outputCoin.CoinDetails = new(Coin)
 			err1 := outputCoin.CoinDetails.SetBytes(bytes[offset : offset+lenOutputCoin])
 			return err1
 		}
@@ -596,6 +600,11 @@ func (outputCoin *OutputCoin) SetBytes(bytes []byte) error {
 // in which AES encryption scheme is used as a data encapsulation scheme,
 // and ElGamal cryptosystem is used as a key encapsulation scheme.
 func (outputCoin *OutputCoin) Encrypt(recipientTK TransmissionKey) *PrivacyError {
+	return outputCoin.EncryptWithRandomness(recipientTK, nil)
+}
+
+// EncryptWithRandomness is the same as Encrypt, but randomness of hybrid encryption is read from rand
+func (outputCoin *OutputCoin) EncryptWithRandomness(recipientTK TransmissionKey, rand io.Reader) *PrivacyError {
 	// 32-byte first: Randomness, the rest of msg is value of coin
 	msg := append(outputCoin.CoinDetails.randomness.ToBytesS(), new(big.Int).SetUint64(outputCoin.CoinDetails.value).Bytes()...)
 
@@ -604,7 +613,7 @@ func (outputCoin *OutputCoin) Encrypt(recipientTK TransmissionKey) *PrivacyError
 		return NewPrivacyErr(EncryptOutputCoinErr, err)
 	}
 
-	outputCoin.CoinDetailsEncrypted, err = HybridEncrypt(msg, pubKeyPoint)
+	outputCoin.CoinDetailsEncrypted, err = HybridEncryptWithRandomness(msg, pubKeyPoint, rand)
 	if err != nil {
 		return NewPrivacyErr(EncryptOutputCoinErr, err)
 	}
@@ -625,3 +634,36 @@ func (outputCoin *OutputCoin) Decrypt(viewingKey ViewingKey) *PrivacyError {
 
 	return nil
 }
+
+// EncryptMemo encrypts memo to the owner of recipientTK by HybridEncryptV2,
+// the result is set as Message of PaymentInfo, so info of the output coin can only be read by the recipient
+// and changes of it are detected by DecryptMemo
+func EncryptMemo(memo []byte, recipientTK TransmissionKey) ([]byte, error) {
+	pubKeyPoint, err := new(Point).FromBytesS(recipientTK)
+	if err != nil {
+		return nil, NewPrivacyErr(EncryptOutputCoinErr, err)
+	}
+	ciphertext, err := HybridEncryptV2(memo, pubKeyPoint)
+	if err != nil {
+		return nil, NewPrivacyErr(EncryptOutputCoinErr, err)
+	}
+	info := ciphertext.Bytes()
+	if len(info) > MaxSizeInfoCoin {
+		return nil, fmt.Errorf("encrypted memo size %v is exceed MaxSizeInfoCoin %v", len(info), MaxSizeInfoCoin)
+	}
+	return info, nil
+}
+
+// DecryptMemo decrypts memo of info of a coin that is encrypted by EncryptMemo with recipient's receiving key
+// info that is not an encrypted memo (e.g. a plain message) is an error
+func DecryptMemo(info []byte, viewingKey ViewingKey) ([]byte, error) {
+	ciphertext := new(HybridCipherText)
+	err := ciphertext.SetBytes(info)
+	if err != nil {
+		return nil, NewPrivacyErr(DecryptOutputCoinErr, err)
+	}
+	if ciphertext.GetVersion() != HybridCipherTextV2 {
+		return nil, NewPrivacyErr(DecryptOutputCoinErr, errors.New("info is not an encrypted memo"))
+	}
+	return HybridDecrypt(ciphertext, new(Scalar).FromBytesS(viewingKey.Rk))
+}
@@ -67,7 +67,7 @@ func TestCoinMarshalJSON(t *testing.T) {
 		coin.snDerivator = RandomScalar()
 		coin.randomness = RandomScalar()
 		coin.value = uint64(100)
-		coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+		coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 		coin.CommitAll()
 		coin.info = []byte("Incognito chain")
 
@@ -100,7 +100,7 @@ func TestCoinBytesSetBytes(t *testing.T) {
 		coin.snDerivator = RandomScalar()
 		coin.randomness = RandomScalar()
 		coin.value = uint64(100)
-		coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+		coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 		coin.CommitAll()
 		coin.info = []byte("Incognito chain")
 
@@ -131,7 +131,7 @@ func TestCoinBytesSetBytesWithMissingFields(t *testing.T) {
 		coin.snDerivator = RandomScalar()
 		coin.randomness = RandomScalar()
 		coin.value = uint64(100)
-		coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+		coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 		//coin.CommitAll()
 		coin.info = []byte("Incognito chain")
 
@@ -162,7 +162,7 @@ func TestCoinBytesSetBytesWithInvalidBytes(t *testing.T) {
 	coin.snDerivator = RandomScalar()
 	coin.randomness = RandomScalar()
 	coin.value = uint64(100)
-	coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+	coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 	coin.CommitAll()
 	coin.info = []byte("Incognito chain")
 
@@ -206,7 +206,7 @@ func TestInputCoinBytesSetBytes(t *testing.T) {
 		coin.CoinDetails.snDerivator = RandomScalar()
 		coin.CoinDetails.randomness = RandomScalar()
 		coin.CoinDetails.value = uint64(100)
-		coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+		coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 		coin.CoinDetails.CommitAll()
 		coin.CoinDetails.info = []byte("Incognito chain")
 
@@ -235,7 +235,7 @@ func TestInputCoinBytesSetBytesWithMissingFields(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 
@@ -262,7 +262,7 @@ func TestInputCoinBytesSetBytesWithInvalidBytes(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 
@@ -306,7 +306,7 @@ func TestOutputCoinBytesSetBytes(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 	coin.Encrypt(paymentAddr.Tk)
@@ -336,7 +336,7 @@ func TestOutputCoinBytesSetBytesWithMissingFields(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	//coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
+	//coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 	coin.Encrypt(paymentAddr.Tk)
@@ -366,7 +366,7 @@ func TestOutputCoinBytesSetBytesWithInvalidBytes(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	//coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
+	//coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 	coin.Encrypt(paymentAddr.Tk)
@@ -463,3 +463,70 @@ func TestOutputCoinEncryptDecryptWithUnmatchedKey(t *testing.T) {
 	assert.NotEqual(t, coin.CoinDetails.randomness, coin2.CoinDetails.randomness)
 	assert.NotEqual(t, coin.CoinDetails.value, coin2.CoinDetails.value)
 }
+
+func FuzzOutputCoinSetBytes(f *testing.F) {
+	seedKey := RandomScalar().ToBytesS()
+	privateKey := GeneratePrivateKey(seedKey)
+	paymentAddr := GeneratePaymentAddress(privateKey)
+
+	for _, info := range [][]byte{nil, []byte("Incognito chain"), RandBytes(255)} {
+		coin := new(OutputCoin).Init()
+		coin.CoinDetails.publicKey.FromBytesS(paymentAddr.Pk)
+		coin.CoinDetails.snDerivator = RandomScalar()
+		coin.CoinDetails.randomness = RandomScalar()
+		coin.CoinDetails.value = uint64(100)
+		coin.CoinDetails.info = info
+		coin.CoinDetails.CommitAll()
+		f.Add(coin.Bytes())
+
+		coin.Encrypt(paymentAddr.Tk)
+		f.Add(coin.Bytes())
+	}
+	f.Add([]byte{0, 0, 1, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		coin := new(OutputCoin)
+		if coin.SetBytes(data) != nil {
+			return
+		}
+
+		// bytes of a parsed coin are parsed to the same coin
+		coinBytes := coin.Bytes()
+		coin2 := new(OutputCoin)
+		assert.Equal(t, nil, coin2.SetBytes(coinBytes))
+		assert.Equal(t, coinBytes, coin2.Bytes())
+	})
+}
+
+func TestEncryptDecryptMemo(t *testing.T) {
+	rk := RandomScalar()
+	tk := new(Point).ScalarMultBase(rk).ToBytesS()
+	viewingKey := ViewingKey{Rk: rk.ToBytesS()}
+
+	memo := []byte("invoice 42")
+	info, err := EncryptMemo(memo, tk)
+	assert.Equal(t, nil, err)
+	assert.LessOrEqual(t, len(info), MaxSizeInfoCoin)
+	memo2, err := DecryptMemo(info, viewingKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, memo, memo2)
+
+	// a change of info is detected
+	info[len(info)-1] ^= 1
+	_, err = DecryptMemo(info, viewingKey)
+	assert.Equal(t, ErrCodeMessage[AuthenticateCipherTextFailedErr].Code, err.(*PrivacyError).GetCode())
+
+	// other receiver
+	info, _ = EncryptMemo(memo, new(Point).ScalarMultBase(RandomScalar()).ToBytesS())
+	_, err = DecryptMemo(info, viewingKey)
+	assert.NotEqual(t, nil, err)
+
+	// plain message is not an encrypted memo
+	ciphertextV1, _ := HybridEncrypt(memo, new(Point).ScalarMultBase(rk))
+	_, err = DecryptMemo(ciphertextV1.Bytes(), viewingKey)
+	assert.NotEqual(t, nil, err)
+
+	// encrypted memo must fit in info of coin
+	_, err = EncryptMemo(make([]byte, MaxSizeInfoCoin), tk)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,251 @@
+package crypto
+
+import (
+	"flag"
+	"math"
+	"math/big"
+	mrand "math/rand"
+	"runtime/debug"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Timing tests of secret-dependent operations, in the style of dudect (https://eprint.iacr.org/2016/1123):
+	each operation is timed with a fixed input (class 0) and random inputs (class 1) in random order,
+	Welch's t-test tells whether the timings of the classes differ.
+	Run the audit with more samples to find smaller leaks, e.g. go test ./crypto -run ConstantTime -ct.samples=200000
+*/
+
+var ctSamples = flag.Int("ct.samples", 3000, "number of measurements of each constant-time test")
+
+// timings with |t| above ctThreshold depend on the inputs
+const ctThreshold = 10
+
+// number of measurements of an operation before it is reported as not constant-time
+const ctAttempts = 3
+
+// welchT returns Welch's t-statistic of two lists of measurements
+func welchT(m0, m1 []float64) float64 {
+	if len(m0) < 2 || len(m1) < 2 {
+		return 0
+	}
+	mean := func(m []float64) float64 {
+		sum := 0.0
+		for _, x := range m {
+			sum += x
+		}
+		return sum / float64(len(m))
+	}
+	variance := func(m []float64, mean float64) float64 {
+		sum := 0.0
+		for _, x := range m {
+			sum += (x - mean) * (x - mean)
+		}
+		return sum / float64(len(m)-1)
+	}
+	mean0, mean1 := mean(m0), mean(m1)
+	s := math.Sqrt(variance(m0, mean0)/float64(len(m0)) + variance(m1, mean1)/float64(len(m1)))
+	if s == 0 {
+		return 0
+	}
+	return (mean0 - mean1) / s
+}
+
+// ctLeakage times op returned by prepare(class) samples times and returns the largest |t|
+// of all measurements and measurements cropped at some percentiles to remove outliers
+// prepare(0) must return op with a fixed input, prepare(1) with a random input
+func ctLeakage(samples int, prepare func(class int) func()) float64 {
+	// garbage collection during the measurements adds noise
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	// all inputs are prepared before the measurements, so that preparing them does not change the timings
+	classes := make([]int, samples)
+	ops := make([]func(), samples)
+	for i := range classes {
+		classes[i] = mrand.Intn(2)
+		ops[i] = prepare(classes[i])
+	}
+	timings := make([]float64, samples)
+	for i, op := range ops {
+		start := time.Now()
+		op()
+		timings[i] = float64(time.Since(start))
+	}
+
+	sorted := append([]float64{}, timings...)
+	sort.Float64s(sorted)
+	maxT := 0.0
+	for _, percentile := range []float64{1, 0.99, 0.95, 0.9, 0.75, 0.5} {
+		threshold := sorted[int(percentile*float64(samples-1))]
+		var m0, m1 []float64
+		for i, timing := range timings {
+			if timing > threshold {
+				continue
+			}
+			if classes[i] == 0 {
+				m0 = append(m0, timing)
+			} else {
+				m1 = append(m1, timing)
+			}
+		}
+		maxT = math.Max(maxT, math.Abs(welchT(m0, m1)))
+	}
+	return maxT
+}
+
+func TestWelchT(t *testing.T) {
+	assert.Equal(t, 0.0, welchT([]float64{1, 2, 3}, []float64{1, 2, 3}))
+	assert.InDelta(t, -3.674, welchT([]float64{1, 2, 3}, []float64{4, 5, 6}), 0.001)
+	assert.Equal(t, 0.0, welchT([]float64{1}, []float64{4, 5, 6}))
+}
+
+// the harness must find the leaks of operations that are known to run in variable time
+func TestConstantTimeHarness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	one := new(Scalar).FromUint64(1)
+	leakT := ctLeakage(*ctSamples, func(class int) func() {
+		a := one
+		if class == 1 {
+			a = RandomScalar()
+		}
+		return func() { new(Scalar).Invert(a) }
+	})
+	assert.Greater(t, leakT, float64(ctThreshold), "Invert")
+
+	points := []*Point{RandomPoint(), RandomPoint()}
+	zeros := []*Scalar{new(Scalar).FromUint64(0), new(Scalar).FromUint64(0)}
+	leakT = ctLeakage(*ctSamples, func(class int) func() {
+		scalars := zeros
+		if class == 1 {
+			scalars = []*Scalar{RandomScalar(), RandomScalar()}
+		}
+		return func() { new(Point).MultiScalarMultVartime(scalars, points) }
+	})
+	assert.Greater(t, leakT, float64(ctThreshold), "MultiScalarMultVartime")
+
+	// big.Int drops leading zero bytes, so its operations depend on the size of the value
+	leakT = ctLeakage(*ctSamples, func(class int) func() {
+		a := one
+		if class == 1 {
+			a = RandomScalar()
+		}
+		return func() {
+			for i := 0; i < 100; i++ {
+				new(big.Int).Exp(ScalarToBigInt(a), big.NewInt(65537), LInt)
+			}
+		}
+	})
+	assert.Greater(t, leakT, float64(ctThreshold), "big.Int")
+}
+
+func TestConstantTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	// the fixed input is not 0 or 1, because the identity point and field elements of zeros
+	// are faster to compute with on some CPUs whatever the code does
+	// nor are the fixed inputs of an operation all equal
+	drbg := NewDRBG([]byte("constant time"))
+	fixedScalars := make([]*Scalar, len(PedCom.G))
+	for i := range fixedScalars {
+		fixedScalars[i] = RandomScalarFromReader(drbg)
+	}
+	point := RandomPoint()
+	// random scalars are read from a DRBG too, reading from the OS between measurements adds noise
+	random := NewDRBG(RandBytes(32))
+	// n fixed or random scalars for class 0 or 1,
+	// scalars of both classes are copied to a new array, so that they are laid out the same in memory
+	scalarsOf := func(class int, n int) []*Scalar {
+		values := make([]Scalar, n)
+		scalars := make([]*Scalar, n)
+		for i := range values {
+			if class == 0 {
+				values[i].Set(fixedScalars[i])
+			} else {
+				values[i].Set(RandomScalarFromReader(random))
+			}
+			scalars[i] = &values[i]
+		}
+		return scalars
+	}
+
+	testcases := []struct {
+		name    string
+		prepare func(class int) func()
+	}{
+		{"InvertConstantTime", func(class int) func() {
+			a := scalarsOf(class, 1)[0]
+			return func() { new(Scalar).InvertConstantTime(a) }
+		}},
+		{"Compare", func(class int) func() {
+			scalars := scalarsOf(class, 2)
+			a, b := scalars[0], scalars[1]
+			return func() {
+				for i := 0; i < 100; i++ {
+					Compare(a, b)
+				}
+			}
+		}},
+		{"FromUint64", func(class int) func() {
+			// a small value had a shorter loop than a large one
+			v := uint64(1)
+			if class == 1 {
+				v = mrand.Uint64()
+			}
+			return func() {
+				for i := 0; i < 100; i++ {
+					new(Scalar).FromUint64(v)
+				}
+			}
+		}},
+		{"ScalarMult", func(class int) func() {
+			a := scalarsOf(class, 1)[0]
+			return func() { new(Point).ScalarMult(point, a) }
+		}},
+		{"MultiScalarMult", func(class int) func() {
+			scalars := scalarsOf(class, 2)
+			points := []*Point{point, PedCom.G[0]}
+			return func() { new(Point).MultiScalarMult(scalars, points) }
+		}},
+		{"commitAll", func(class int) func() {
+			scalars := scalarsOf(class, len(PedCom.G))
+			return func() { PedCom.commitAll(scalars) }
+		}},
+		{"CommitAtIndex", func(class int) func() {
+			scalars := scalarsOf(class, 2)
+			return func() { PedCom.CommitAtIndex(scalars[0], scalars[1], PedersenValueIndex) }
+		}},
+		{"Derive", func(class int) func() {
+			scalars := scalarsOf(class, 2)
+			return func() { new(Point).Derive(PedCom.G[PedersenPrivateKeyIndex], scalars[0], scalars[1]) }
+		}},
+		{"SchnorrSign", func(class int) func() {
+			privKey := new(SchnorrPrivateKey)
+			scalars := scalarsOf(class, 2)
+			privKey.Set(scalars[0], scalars[1])
+			data := make([]byte, 32)
+			// nonces are the same for both classes, so only the private key may change the time
+			return func() { privKey.SignWithRandomness(data, NewDRBG([]byte("nonce"))) }
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// a leak is found by every measurement, noise of the machine is not, so the test is retried
+			leakT := 0.0
+			for attempt := 0; attempt < ctAttempts; attempt++ {
+				leakT = ctLeakage(*ctSamples, tc.prepare)
+				t.Logf("|t| = %.2f", leakT)
+				if leakT < ctThreshold {
+					break
+				}
+			}
+			assert.Less(t, leakT, float64(ctThreshold))
+		})
+	}
+}
@@ -534,6 +534,39 @@ func selectPoint(t *PreComputedGroupElement, pos int32, b int32) {
 	PreComputedGroupElementCMove(t, &minusT, bNegative)
 }
 
+// selectCachedPoint sets t = b*A in constant time, where Ai is A,2A,3A,4A,5A,6A,7A,8A and -8 <= b <= 8
+func selectCachedPoint(t *CachedGroupElement, Ai *[8]CachedGroupElement, b int32) {
+	var minusT CachedGroupElement
+	bNegative := negative(b)
+	bAbs := b - (((-bNegative) & b) << 1)
+
+	t.Zero()
+	for i := int32(0); i < 8; i++ {
+		CachedGroupElementCMove(t, &Ai[i], equal(bAbs, i+1))
+	}
+	FeCopy(&minusT.yPlusX, &t.yMinusX)
+	FeCopy(&minusT.yMinusX, &t.yPlusX)
+	FeCopy(&minusT.Z, &t.Z)
+	FeNeg(&minusT.T2d, &t.T2d)
+	CachedGroupElementCMove(t, &minusT, bNegative)
+}
+
+// selectCachedPointVartime is selectCachedPoint in variable time, b must not be secret
+func selectCachedPointVartime(t *CachedGroupElement, Ai *[8]CachedGroupElement, b int32) {
+	if b == 0 {
+		t.Zero()
+		return
+	}
+	if b > 0 {
+		*t = Ai[b-1]
+		return
+	}
+	FeCopy(&t.yPlusX, &Ai[-b-1].yMinusX)
+	FeCopy(&t.yMinusX, &Ai[-b-1].yPlusX)
+	FeCopy(&t.Z, &Ai[-b-1].Z)
+	FeNeg(&t.T2d, &Ai[-b-1].T2d)
+}
+
 // GeScalarMultBase computes h = a*B, where
 //   a = a[0]+256*a[1]+...+256^31 a[31]
 //   B is the Ed25519 base point (x,4/5) with x positive.
@@ -659,9 +692,6 @@ func GeDoubleScalarMultPrecompVartime(r *ProjectiveGroupElement, a *Key, A *Exte
 }
 
 func CachedGroupElementCMove(t, u *CachedGroupElement, b int32) {
-	if b == 0 {
-		return
-	}
 	FeCMove(&t.yPlusX, &u.yPlusX, b)
 	FeCMove(&t.yMinusX, &u.yMinusX, b)
 	FeCMove(&t.Z, &u.Z, b)
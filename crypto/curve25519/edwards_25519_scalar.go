@@ -990,11 +990,7 @@ func GeScalarMult(r *ProjectiveGroupElement, a *Key, A *ExtendedGroupElement) {
 	}
 	r.Zero()
 	cur := new(CachedGroupElement)
-	minusCur := new(CachedGroupElement)
 	for i := 63; i >= 0; i-- {
-		b := e[i]
-		bNegative := int8(negative(int32(b)))
-		bAbs := b - (((-bNegative) & b) << 1)
 		r.Double(t)
 		t.ToProjective(r)
 		r.Double(t)
@@ -1004,19 +1000,7 @@ func GeScalarMult(r *ProjectiveGroupElement, a *Key, A *ExtendedGroupElement) {
 		r.Double(t)
 		t.ToExtended(u)
 
-		cur.Zero()
-		for j := int32(0); j < 8; j++ {
-			if equal(int32(bAbs), j+1) == 1 { // optimisation
-				CachedGroupElementCMove(cur, &Ai[j], equal(int32(bAbs), j+1))
-			}
-		}
-
-		FeCopy(&minusCur.yPlusX, &cur.yMinusX)
-		FeCopy(&minusCur.yMinusX, &cur.yPlusX)
-		FeCopy(&minusCur.Z, &cur.Z)
-		FeNeg(&minusCur.T2d, &cur.T2d)
-
-		CachedGroupElementCMove(cur, minusCur, int32(bNegative))
+		selectCachedPoint(cur, &Ai, int32(e[i]))
 
 		geAdd(t, u, cur)
 		t.ToProjective(r)
@@ -481,95 +481,45 @@ func PreComputeForMultiScalar (p *Key) [8]CachedGroupElement {
 }
 
 
+// MultiScalarMultKeyCached returns sum of scalars[i]*A_i where AiLs[i] is A_i,2A_i,...,8A_i
+// it runs in constant time for any values of scalars
 func MultiScalarMultKeyCached(AiLs [][8]CachedGroupElement, scalars []*Key, ) (result *Key) {
-	r := new(ProjectiveGroupElement)
-
-	digitsLs := make([][64]int8, len(scalars))
-	for i:= range digitsLs {
-		digitsLs[i] = scalars[i].SignedRadix16()
-	}
-
-	t := new(CompletedGroupElement)
-	u := new(ExtendedGroupElement)
+	return multiScalarMultPrecomputed(AiLs, scalars, false)
+}
 
-	r.Zero()
-	cachedBase := new(ExtendedGroupElement)
-	cur := new(CachedGroupElement)
-	minusCur := new(CachedGroupElement)
-	for i := 63; i >= 0; i-- {
-		r.Double(t)
-		t.ToProjective(r)
-		r.Double(t)
-		t.ToProjective(r)
-		r.Double(t)
-		t.ToProjective(r)
-		r.Double(t)
-		t.ToExtended(u)
+// MultiScalarMultKey returns sum of scalars[i]*points[i] in constant time
+func MultiScalarMultKey(points []*Key, scalars []*Key) (result *Key) {
+	return multiScalarMultPrecomputed(preComputeMultiScalarLs(points), scalars, false)
+}
 
-		cachedBase.Zero()
-		tmpt := new(CompletedGroupElement)
-		for j:= 0; j < len(scalars); j++ {
-			cur.Zero()
-			b := digitsLs[j][i]
-			bNegative := int8(negative(int32(b)))
-			bAbs := b - (((-bNegative) & b) << 1)
-
-			for k := int32(0); k < 8; k++ {
-				if equal(int32(bAbs), k+1) == 1 { // optimisation
-					CachedGroupElementCMove(cur, &AiLs[j][k], equal(int32(bAbs), k+1))
-				}
-			}
-			FeCopy(&minusCur.yPlusX, &cur.yMinusX)
-			FeCopy(&minusCur.yMinusX, &cur.yPlusX)
-			FeCopy(&minusCur.Z, &cur.Z)
-			FeNeg(&minusCur.T2d, &cur.T2d)
-			CachedGroupElementCMove(cur, minusCur, int32(bNegative))
+// MultiScalarMultKeyVartime is MultiScalarMultKey in variable time, it is faster
+// but must only be used with public scalars, e.g. when verifying proofs
+func MultiScalarMultKeyVartime(points []*Key, scalars []*Key) (result *Key) {
+	return multiScalarMultPrecomputed(preComputeMultiScalarLs(points), scalars, true)
+}
 
-			geAdd(tmpt, cachedBase, cur)
-			tmpt.ToExtended(cachedBase)
-		}
-		tmpv := new(CachedGroupElement)
-		cachedBase.ToCached(tmpv)
-		geAdd(t, u, tmpv)
-		t.ToProjective(r)
+func preComputeMultiScalarLs(points []*Key) [][8]CachedGroupElement {
+	AiLs := make([][8]CachedGroupElement, len(points))
+	for i := range points {
+		AiLs[i] = PreComputeForMultiScalar(points[i])
 	}
-	result = new(Key)
-	r.ToBytes(result)
-	return result
+	return AiLs
 }
 
-func MultiScalarMultKey(points []*Key, scalars []*Key) (result *Key) {
+func multiScalarMultPrecomputed(AiLs [][8]CachedGroupElement, scalars []*Key, vartime bool) (result *Key) {
 	r := new(ProjectiveGroupElement)
 
-
-	pointLs := make([]ExtendedGroupElement, len(points))
-
 	digitsLs := make([][64]int8, len(scalars))
 	for i:= range digitsLs {
 		digitsLs[i] = scalars[i].SignedRadix16()
 	}
 
-	AiLs := make([][8]CachedGroupElement, len(scalars))
-	for i:= 0; i < len(scalars); i++ {
-		// A,2A,3A,4A,5A,6A,7A,8A
-		t := new(CompletedGroupElement)
-		u := new(ExtendedGroupElement)
-		pointLs[i].FromBytes(points[i])
-		pointLs[i].ToCached(&AiLs[i][0])
-		for j := 0; j < 7; j++ {
-			geAdd(t, &pointLs[i], &AiLs[i][j])
-			t.ToExtended(u)
-			u.ToCached(&AiLs[i][j+1])
-		}
-	}
-
 	t := new(CompletedGroupElement)
 	u := new(ExtendedGroupElement)
 
 	r.Zero()
 	cachedBase := new(ExtendedGroupElement)
 	cur := new(CachedGroupElement)
-	minusCur := new(CachedGroupElement)
 	for i := 63; i >= 0; i-- {
 		r.Double(t)
 		t.ToProjective(r)
@@ -583,21 +533,14 @@ func MultiScalarMultKey(points []*Key, scalars []*Key) (result *Key) {
 		cachedBase.Zero()
 		tmpt := new(CompletedGroupElement)
 		for j:= 0; j < len(scalars); j++ {
-			cur.Zero()
-			b := digitsLs[j][i]
-			bNegative := int8(negative(int32(b)))
-			bAbs := b - (((-bNegative) & b) << 1)
-
-			for k := int32(0); k < 8; k++ {
-				if equal(int32(bAbs), k+1) == 1 { // optimisation
-					CachedGroupElementCMove(cur, &AiLs[j][k], equal(int32(bAbs), k+1))
+			if vartime {
+				if digitsLs[j][i] == 0 {
+					continue
 				}
+				selectCachedPointVartime(cur, &AiLs[j], int32(digitsLs[j][i]))
+			} else {
+				selectCachedPoint(cur, &AiLs[j], int32(digitsLs[j][i]))
 			}
-			FeCopy(&minusCur.yPlusX, &cur.yMinusX)
-			FeCopy(&minusCur.yMinusX, &cur.yPlusX)
-			FeCopy(&minusCur.Z, &cur.Z)
-			FeNeg(&minusCur.T2d, &cur.T2d)
-			CachedGroupElementCMove(cur, minusCur, int32(bNegative))
 
 			geAdd(tmpt, cachedBase, cur)
 			tmpt.ToExtended(cachedBase)
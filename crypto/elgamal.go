@@ -1,5 +1,7 @@
 package crypto
 
+import "io"
+
 // elGamalPublicKeyOld represents to public key in ElGamal encryption
 // H = G^X, X is private key
 type elGamalPublicKey struct {
@@ -79,9 +81,9 @@ func (ciphertext *elGamalCipherText) SetBytes(bytes []byte) error {
 
 // encrypt encrypts plaintext (is an elliptic point) using public key ElGamal
 // returns ElGamal ciphertext
-func (pub elGamalPublicKey) encrypt(plaintext *Point) *elGamalCipherText {
+func (pub elGamalPublicKey) encrypt(plaintext *Point, rand io.Reader) *elGamalCipherText {
 	// r random, S:= h^r where h = g^x
-	r := RandomScalar()
+	r := RandomScalarFromReader(rand)
 	S := new(Point).ScalarMult(pub.h, r)
 
 	//return ciphertext (c1, c2) = (g^r, m.s=m.h^r)
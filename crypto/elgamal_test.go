@@ -21,7 +21,7 @@ func TestElGamalCipherText_Bytes(t *testing.T) {
 	message := RandomPoint()
 
 	// Encrypt message using public key
-	c := pubKey.encrypt(message)
+	c := pubKey.encrypt(message, nil)
 	cBytes := c.Bytes()
 	fmt.Println(len(cBytes))
 }
@@ -40,7 +40,7 @@ func TestElGamalPublicKey_Encryption(t *testing.T) {
 		message := RandomPoint()
 
 		// Encrypt message using public key
-		ciphertext1 := pubKey.encrypt(message)
+		ciphertext1 := pubKey.encrypt(message, nil)
 
 		// convert ciphertext1 to bytes array
 		ciphertext1Bytes := ciphertext1.Bytes()
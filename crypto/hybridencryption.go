@@ -1,10 +1,20 @@
 package crypto
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"github.com/0xkraken/incognito-sdk-golang/common"
 	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"io"
+)
+
+// versions of hybrid encryption
+// v1 encrypts message with AES-CTR, it is the format of coins on chain
+// v2 encrypts message with AES-GCM, so changes of ciphertext are detected when decrypting
+const (
+	HybridCipherTextV1 = byte(1)
+	HybridCipherTextV2 = byte(2)
 )
 
 // hybridCipherText_Old represents to hybridCipherText_Old for Hybrid encryption
@@ -13,6 +23,15 @@ import (
 type HybridCipherText struct {
 	msgEncrypted    []byte
 	symKeyEncrypted []byte
+	version         byte // 0 is v1, like ciphertexts created before versions existed
+}
+
+// GetVersion returns HybridCipherTextV1 or HybridCipherTextV2
+func (ciphertext HybridCipherText) GetVersion() byte {
+	if ciphertext.version == 0 {
+		return HybridCipherTextV1
+	}
+	return ciphertext.version
 }
 
 func (ciphertext HybridCipherText) GetMsgEncrypted() []byte {
@@ -45,11 +64,25 @@ func (hybridCipherText *HybridCipherText) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	hybridCipherText.SetBytes(temp)
-	return nil
+	return hybridCipherText.SetBytes(temp)
+}
+
+// hybridCipherTextV2Header returns the header of bytes of v2 ciphertexts: version 2 followed by zeros to Ed25519KeySize
+// v1 ciphertexts start with the first point of the encrypted AES key, the header is not a valid point (y = 2),
+// so a v2 ciphertext is never parsed as v1
+func hybridCipherTextV2Header() []byte {
+	header := make([]byte, Ed25519KeySize)
+	header[0] = HybridCipherTextV2
+	return header
+}
+
+// hasHybridCipherTextV2Header returns whether data starts with hybridCipherTextV2Header
+func hasHybridCipherTextV2Header(data []byte) bool {
+	return len(data) >= Ed25519KeySize && bytes.Equal(data[:Ed25519KeySize], hybridCipherTextV2Header())
 }
 
 // Bytes converts ciphertext to bytes array
+// v2 ciphertexts start with hybridCipherTextV2Header, v1 ciphertexts have no header to keep the format of coins
 // if ciphertext is nil, return empty byte array
 func (ciphertext HybridCipherText) Bytes() []byte {
 	if ciphertext.IsNil() {
@@ -57,6 +90,9 @@ func (ciphertext HybridCipherText) Bytes() []byte {
 	}
 
 	res := make([]byte, 0)
+	if ciphertext.GetVersion() == HybridCipherTextV2 {
+		res = append(res, hybridCipherTextV2Header()...)
+	}
 	res = append(res, ciphertext.symKeyEncrypted...)
 	res = append(res, ciphertext.msgEncrypted...)
 
@@ -64,17 +100,25 @@ func (ciphertext HybridCipherText) Bytes() []byte {
 }
 
 // SetBytes reverts bytes array to hybridCipherText_Old
+// the version is v2 if bytes start with hybridCipherTextV2Header, otherwise it is v1
 func (ciphertext *HybridCipherText) SetBytes(bytes []byte) error {
 	if len(bytes) == 0 {
 		return NewPrivacyErr(InvalidInputToSetBytesErr, nil)
 	}
 
-	if len(bytes) < elGamalCiphertextSize {
-		// out of range
+	version := byte(0)
+	if hasHybridCipherTextV2Header(bytes) {
+		version = HybridCipherTextV2
+		bytes = bytes[Ed25519KeySize:]
+	}
+
+	if len(bytes) <= elGamalCiphertextSize {
+		// out of range or no encrypted message
 		return errors.New("out of range Parse ciphertext")
 	}
 	ciphertext.symKeyEncrypted = bytes[0:elGamalCiphertextSize]
 	ciphertext.msgEncrypted = bytes[elGamalCiphertextSize:]
+	ciphertext.version = version
 	return nil
 }
 
@@ -83,16 +127,22 @@ func (ciphertext *HybridCipherText) SetBytes(bytes []byte) error {
 // using AES key to encrypt message
 // After that, using ElGamal encryption encrypt aesKeyPoint using publicKey
 func HybridEncrypt(msg []byte, publicKey *Point) (ciphertext *HybridCipherText, err error) {
+	return HybridEncryptWithRandomness(msg, publicKey, nil)
+}
+
+// HybridEncryptWithRandomness is the same as HybridEncrypt, but AES key, IV and ElGamal randomness are read from rand
+func HybridEncryptWithRandomness(msg []byte, publicKey *Point, rand io.Reader) (ciphertext *HybridCipherText, err error) {
 	ciphertext = new(HybridCipherText)
 
 	// Generate a AES key bytes
-	sKeyPoint := RandomPoint()
+	sKeyPoint := RandomPointFromReader(rand)
 	sKeyByte := sKeyPoint.ToBytes()
 	// Encrypt msg using aesKeyByte
 
 	aesKey := sKeyByte[:]
 	aesScheme := &common.AES{
-		Key: aesKey,
+		Key:  aesKey,
+		Rand: rand,
 	}
 	ciphertext.msgEncrypted, err = aesScheme.Encrypt(msg)
 	if err != nil {
@@ -102,12 +152,50 @@ func HybridEncrypt(msg []byte, publicKey *Point) (ciphertext *HybridCipherText,
 	// Using ElGamal cryptosystem for encrypting AES sym key
 	pubKey := new(elGamalPublicKey)
 	pubKey.h = publicKey
-	ciphertext.symKeyEncrypted = pubKey.encrypt(sKeyPoint).Bytes()
+	ciphertext.symKeyEncrypted = pubKey.encrypt(sKeyPoint, rand).Bytes()
+
+	return ciphertext, nil
+}
+
+// HybridEncryptV2 is HybridEncrypt with AES-GCM instead of AES-CTR,
+// the encrypted AES key is authenticated too, so any change of encrypted AES key or message makes HybridDecrypt fail
+// it is used to encrypt memos in info of coins (see EncryptMemo)
+func HybridEncryptV2(msg []byte, publicKey *Point) (ciphertext *HybridCipherText, err error) {
+	return HybridEncryptV2WithRandomness(msg, publicKey, nil)
+}
+
+// HybridEncryptV2WithRandomness is the same as HybridEncryptV2, but AES key, nonce and ElGamal randomness are read from rand
+func HybridEncryptV2WithRandomness(msg []byte, publicKey *Point, rand io.Reader) (ciphertext *HybridCipherText, err error) {
+	ciphertext = &HybridCipherText{version: HybridCipherTextV2}
+
+	sKeyPoint := RandomPointFromReader(rand)
+	pubKey := new(elGamalPublicKey)
+	pubKey.h = publicKey
+	ciphertext.symKeyEncrypted = pubKey.encrypt(sKeyPoint, rand).Bytes()
 
+	aesScheme := &common.AESGCM{
+		Key:  hybridV2AESKey(sKeyPoint),
+		Rand: rand,
+	}
+	ciphertext.msgEncrypted, err = aesScheme.Encrypt(msg, hybridV2AdditionalData(ciphertext.symKeyEncrypted))
+	if err != nil {
+		return nil, err
+	}
 	return ciphertext, nil
 }
 
-// hybridDecrypt_Old receives a ciphertext and privateKey
+// hybridV2AESKey derives AES key from the point encrypted by ElGamal
+func hybridV2AESKey(sKeyPoint *Point) []byte {
+	return common.HashB(append([]byte("hybrid encryption v2"), sKeyPoint.ToBytesS()...))
+}
+
+// hybridV2AdditionalData binds version and encrypted AES key to AES-GCM ciphertext
+func hybridV2AdditionalData(symKeyEncrypted []byte) []byte {
+	return append([]byte{HybridCipherTextV2}, symKeyEncrypted...)
+}
+
+// hybridDecrypt_Old receives a ciphertext and privateKey, ciphertext can be v1 or v2
+// only v2 ciphertexts are authenticated, callers that expect v2 must check GetVersion before trusting msg
 // it decrypts aesKeyPoint, using ElGamal encryption with privateKey
 // Using X-coordinate of aesKeyPoint to decrypts message
 func HybridDecrypt(ciphertext *HybridCipherText, privateKey *Scalar) (msg []byte, err error) {
@@ -123,6 +211,10 @@ func HybridDecrypt(ciphertext *HybridCipherText, privateKey *Scalar) (msg []byte
 	// Parse encrypted AES key encoded as an elliptic point from EncryptedSymKey
 	encryptedAESKey := new(elGamalCipherText)
 	err = encryptedAESKey.SetBytes(ciphertext.symKeyEncrypted)
+	if err != nil && ciphertext.GetVersion() == HybridCipherTextV2 {
+		// an invalid encrypted AES key of v2 ciphertext is a change of ciphertext too
+		return []byte{}, NewPrivacyErr(AuthenticateCipherTextFailedErr, err)
+	}
 	if err != nil {
 		return []byte{}, err
 	}
@@ -133,6 +225,20 @@ func HybridDecrypt(ciphertext *HybridCipherText, privateKey *Scalar) (msg []byte
 		return []byte{}, err
 	}
 
+	if ciphertext.GetVersion() == HybridCipherTextV2 {
+		aesScheme := &common.AESGCM{
+			Key: hybridV2AESKey(aesKeyPoint),
+		}
+		msg, err = aesScheme.Decrypt(ciphertext.msgEncrypted, hybridV2AdditionalData(ciphertext.symKeyEncrypted))
+		if err == common.AuthenticationFailedErr {
+			return []byte{}, NewPrivacyErr(AuthenticateCipherTextFailedErr, err)
+		}
+		if err != nil {
+			return []byte{}, err
+		}
+		return msg, nil
+	}
+
 	// Get AES key
 	aesKeyByte := aesKeyPoint.ToBytes()
 	aesKey := aesKeyByte[:]
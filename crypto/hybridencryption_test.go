@@ -2,6 +2,9 @@ package crypto
 
 import (
 	"crypto/rand"
+	"encoding/json"
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -49,4 +52,122 @@ func randomMessage() []byte {
 	msg := make([]byte, 128)
 	rand.Read(msg)
 	return msg
-}
\ No newline at end of file
+}
+func TestHybridEncryptionV2(t *testing.T) {
+	privateKey := RandomScalar()
+	publicKey := new(Point).ScalarMultBase(privateKey)
+
+	for i := 0; i < 100; i++ {
+		msg := randomMessage()
+		ciphertext, err := HybridEncryptV2(msg, publicKey)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, HybridCipherTextV2, ciphertext.GetVersion())
+
+		ciphertextBytes := ciphertext.Bytes()
+		assert.Equal(t, hybridCipherTextV2Header(), ciphertextBytes[:Ed25519KeySize])
+		ciphertext2 := new(HybridCipherText)
+		err = ciphertext2.SetBytes(ciphertextBytes)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, ciphertext, ciphertext2)
+
+		msg2, err := HybridDecrypt(ciphertext2, privateKey)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, msg, msg2)
+	}
+}
+
+func TestHybridEncryptionV2Tampered(t *testing.T) {
+	privateKey := RandomScalar()
+	publicKey := new(Point).ScalarMultBase(privateKey)
+	msg := randomMessage()
+	ciphertext, _ := HybridEncryptV2(msg, publicKey)
+	ciphertextBytes := ciphertext.Bytes()
+
+	// a change of encrypted AES key or encrypted message is detected
+	for _, i := range []int{Ed25519KeySize, Ed25519KeySize + elGamalCiphertextSize - 1, Ed25519KeySize + elGamalCiphertextSize, len(ciphertextBytes) - 1} {
+		tampered := append([]byte{}, ciphertextBytes...)
+		tampered[i] ^= 1
+		tamperedCiphertext := new(HybridCipherText)
+		err := tamperedCiphertext.SetBytes(tampered)
+		assert.Equal(t, nil, err)
+
+		_, err = HybridDecrypt(tamperedCiphertext, privateKey)
+		assert.Equal(t, ErrCodeMessage[AuthenticateCipherTextFailedErr].Code, err.(*PrivacyError).GetCode())
+	}
+
+	// wrong private key
+	_, err := HybridDecrypt(ciphertext, RandomScalar())
+	assert.Equal(t, ErrCodeMessage[AuthenticateCipherTextFailedErr].Code, err.(*PrivacyError).GetCode())
+
+	// a change of the header makes the ciphertext v1, which is not authenticated,
+	// so callers that expect v2 ciphertexts (e.g. DecryptMemo) must check the version
+	tampered := append([]byte{}, ciphertextBytes...)
+	tampered[0] ^= 1
+	tamperedCiphertext := new(HybridCipherText)
+	assert.Equal(t, nil, tamperedCiphertext.SetBytes(tampered))
+	assert.Equal(t, HybridCipherTextV1, tamperedCiphertext.GetVersion())
+	msg2, _ := HybridDecrypt(tamperedCiphertext, privateKey)
+	assert.NotEqual(t, msg, msg2)
+}
+
+// bytes of v1 ciphertexts start with a point, so they are never parsed as v2
+func TestHybridCipherTextV2Header(t *testing.T) {
+	_, err := new(Point).FromBytesS(hybridCipherTextV2Header())
+	assert.NotEqual(t, nil, err)
+
+	privateKey := RandomScalar()
+	publicKey := new(Point).ScalarMultBase(privateKey)
+	for i := 0; i < 100; i++ {
+		ciphertext, _ := HybridEncrypt(randomMessage(), publicKey)
+		ciphertext2 := new(HybridCipherText)
+		assert.Equal(t, nil, ciphertext2.SetBytes(ciphertext.Bytes()))
+		assert.Equal(t, HybridCipherTextV1, ciphertext2.GetVersion())
+	}
+}
+
+func TestHybridCipherTextJSON(t *testing.T) {
+	privateKey := RandomScalar()
+	publicKey := new(Point).ScalarMultBase(privateKey)
+	msg := randomMessage()
+
+	ciphertextV1, _ := HybridEncrypt(msg, publicKey)
+	ciphertextV2, _ := HybridEncryptV2(msg, publicKey)
+	for _, ciphertext := range []*HybridCipherText{ciphertextV1, ciphertextV2} {
+		data, err := json.Marshal(ciphertext)
+		assert.Equal(t, nil, err)
+
+		ciphertext2 := new(HybridCipherText)
+		err = json.Unmarshal(data, ciphertext2)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, ciphertext.GetVersion(), ciphertext2.GetVersion())
+
+		msg2, err := HybridDecrypt(ciphertext2, privateKey)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, msg, msg2)
+	}
+
+	// JSON of v1 ciphertext is unchanged
+	data, _ := json.Marshal(ciphertextV1)
+	var dataStr string
+	json.Unmarshal(data, &dataStr)
+	assert.Equal(t, base58.Base58Check{}.Encode(ciphertextV1.Bytes(), common.ZeroByte), dataStr)
+}
+
+func FuzzHybridCipherTextSetBytes(f *testing.F) {
+	privateKey := RandomScalar()
+	publicKey := new(Point).ScalarMultBase(privateKey)
+	ciphertextV1, _ := HybridEncrypt(randomMessage(), publicKey)
+	ciphertextV2, _ := HybridEncryptV2(randomMessage(), publicKey)
+	f.Add(ciphertextV1.Bytes())
+	f.Add(ciphertextV2.Bytes())
+	f.Add(ciphertextV1.Bytes()[:elGamalCiphertextSize+1])
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ciphertext := new(HybridCipherText)
+		if ciphertext.SetBytes(data) == nil {
+			assert.Equal(t, data, ciphertext.Bytes())
+			assert.Equal(t, hasHybridCipherTextV2Header(data), ciphertext.GetVersion() == HybridCipherTextV2)
+			HybridDecrypt(ciphertext, privateKey)
+		}
+	})
+}
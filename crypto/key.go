@@ -46,6 +46,7 @@ func GeneratePrivateKey(seed []byte) PrivateKey {
 // GeneratePublicKey computes a 32-byte public-key corresponding to a spending key
 func GeneratePublicKey(privateKey []byte) PublicKey {
 	privScalar := new(Scalar).FromBytesS(privateKey)
+	defer privScalar.Zero()
 	publicKey := new(Point).ScalarMultBase(privScalar)
 	return publicKey.ToBytesS()
 }
@@ -0,0 +1,255 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+)
+
+// n-of-n Schnorr multi-signature (MuSig)
+// public key of signer i: X_i = x_i*G
+// key aggregation: L = X_1 || ... || X_n (sorted), a_i = H(L || X_i), X = sum(a_i*X_i)
+// round 1: each signer picks nonce r_i, R_i = r_i*G and broadcasts commitment t_i = H(R_i)
+// round 2: after receiving all commitments, each signer broadcasts R_i and checks H(R_j) = t_j
+// round 3: R = sum(R_i), e = H(R || data), each signer broadcasts z_i = r_i - e*a_i*x_i
+// the aggregated signature (e, z = sum(z_i)) is verified by SchnorrPublicKey.Verify with X
+
+// sortMultiSigPublicKeys returns a sorted copy of pubKeys and the concatenation L of them
+func sortMultiSigPublicKeys(pubKeys []*Point) ([]*Point, []byte, error) {
+	if len(pubKeys) == 0 {
+		return nil, nil, NewPrivacyErr(InvalidMultiSigErr, errors.New("list of public keys is empty"))
+	}
+	sorted := make([]*Point, len(pubKeys))
+	for i, pk := range pubKeys {
+		if pk == nil || !pk.PointValid() || pk.IsIdentity() {
+			return nil, nil, NewPrivacyErr(InvalidMultiSigErr, fmt.Errorf("public key %v is invalid", i))
+		}
+		sorted[i] = new(Point).Set(pk)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].ToBytesS(), sorted[j].ToBytesS()) < 0
+	})
+
+	l := make([]byte, 0, len(sorted)*Ed25519KeySize)
+	for i, pk := range sorted {
+		if i > 0 && IsPointEqual(pk, sorted[i-1]) {
+			return nil, nil, NewPrivacyErr(InvalidMultiSigErr, errors.New("duplicate public keys"))
+		}
+		l = append(l, pk.ToBytesS()...)
+	}
+	return sorted, l, nil
+}
+
+// multiSigKeyCoefficient returns a_i = H(L || X_i), it prevents rogue key attacks
+func multiSigKeyCoefficient(l []byte, pk *Point) *Scalar {
+	msg := append(append([]byte{}, l...), pk.ToBytesS()...)
+	return HashToScalar(msg)
+}
+
+// multiSigNonceCommitment returns commitment of nonce point R_i
+func multiSigNonceCommitment(noncePoint *Point) []byte {
+	return common.HashB(noncePoint.ToBytesS())
+}
+
+// AggregateSchnorrPublicKeys returns the aggregated public key of n-of-n multi-signature
+// order of pubKeys does not matter
+func AggregateSchnorrPublicKeys(pubKeys []*Point) (*SchnorrPublicKey, error) {
+	sorted, l, err := sortMultiSigPublicKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+	aggPubKey := new(Point).Identity()
+	for _, pk := range sorted {
+		aggPubKey.Add(aggPubKey, new(Point).ScalarMult(pk, multiSigKeyCoefficient(l, pk)))
+	}
+
+	publicKey := new(SchnorrPublicKey)
+	publicKey.Set(aggPubKey)
+	return publicKey, nil
+}
+
+// MultiSigSession is the state of a signer in a multi-signature signing on data
+// a session must be used to sign only once, nonce is erased after PartialSign
+type MultiSigSession struct {
+	privateKey   *Scalar
+	index        int
+	pubKeys      []*Point
+	coefficients []*Scalar
+	aggPubKey    *SchnorrPublicKey
+	data         []byte
+
+	nonce       *Scalar
+	noncePoint  *Point
+	commitments [][]byte
+	noncePoints []*Point
+	challenge   *Scalar
+}
+
+// NewMultiSigSession starts a signing session of signer with privateKey on hash data
+// pubKeys are public keys (privateKey*G) of all signers, including this signer
+func NewMultiSigSession(privateKey *Scalar, pubKeys []*Point, data []byte) (*MultiSigSession, error) {
+	if len(data) != common.HashSize {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("hash length must be 32 bytes"))
+	}
+	if privateKey == nil || privateKey.IsZero() {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("private key is invalid"))
+	}
+	sorted, l, err := sortMultiSigPublicKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &MultiSigSession{
+		privateKey:   new(Scalar).Set(privateKey),
+		index:        -1,
+		pubKeys:      sorted,
+		coefficients: make([]*Scalar, len(sorted)),
+		data:         append([]byte{}, data...),
+	}
+	publicKey := new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], privateKey)
+	aggPubKey := new(Point).Identity()
+	for i, pk := range sorted {
+		if IsPointEqual(pk, publicKey) {
+			session.index = i
+		}
+		session.coefficients[i] = multiSigKeyCoefficient(l, pk)
+		aggPubKey.Add(aggPubKey, new(Point).ScalarMult(pk, session.coefficients[i]))
+	}
+	if session.index < 0 {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("public key of signer is not in list of public keys"))
+	}
+	session.aggPubKey = new(SchnorrPublicKey)
+	session.aggPubKey.Set(aggPubKey)
+
+	session.nonce = RandomScalar()
+	session.noncePoint = new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], session.nonce)
+	return session, nil
+}
+
+// PublicKeys returns sorted public keys of all signers
+// commitments, nonces and partial signatures are exchanged in this order
+func (session MultiSigSession) PublicKeys() []*Point {
+	return session.pubKeys
+}
+
+// Index returns index of this signer in PublicKeys
+func (session MultiSigSession) Index() int {
+	return session.index
+}
+
+// AggregatedPublicKey returns the public key that verifies the aggregated signature
+func (session MultiSigSession) AggregatedPublicKey() *SchnorrPublicKey {
+	return session.aggPubKey
+}
+
+// NonceCommitment returns commitment of nonce of this signer (round 1)
+func (session MultiSigSession) NonceCommitment() []byte {
+	return multiSigNonceCommitment(session.noncePoint)
+}
+
+// SetNonceCommitments sets commitments of all signers, in order of PublicKeys
+func (session *MultiSigSession) SetNonceCommitments(commitments [][]byte) error {
+	if len(commitments) != len(session.pubKeys) {
+		return NewPrivacyErr(InvalidLengthMultiSigErr, errors.New("number of nonce commitments is invalid"))
+	}
+	if !bytes.Equal(commitments[session.index], session.NonceCommitment()) {
+		return NewPrivacyErr(SignMultiSigErr, errors.New("nonce commitment of signer is modified"))
+	}
+	session.commitments = make([][]byte, len(commitments))
+	for i, commitment := range commitments {
+		session.commitments[i] = append([]byte{}, commitment...)
+	}
+	return nil
+}
+
+// Nonce returns nonce point of this signer (round 2)
+// it is only revealed after all commitments are received
+func (session MultiSigSession) Nonce() (*Point, error) {
+	if session.commitments == nil {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("nonce commitments have not been received"))
+	}
+	return new(Point).Set(session.noncePoint), nil
+}
+
+// SetNonces sets nonce points of all signers, in order of PublicKeys
+// and checks them against the commitments
+func (session *MultiSigSession) SetNonces(noncePoints []*Point) error {
+	if session.commitments == nil {
+		return NewPrivacyErr(SignMultiSigErr, errors.New("nonce commitments have not been received"))
+	}
+	if len(noncePoints) != len(session.pubKeys) {
+		return NewPrivacyErr(InvalidLengthMultiSigErr, errors.New("number of nonces is invalid"))
+	}
+	aggNonce := new(Point).Identity()
+	for i, noncePoint := range noncePoints {
+		if noncePoint == nil || !noncePoint.PointValid() {
+			return NewPrivacyErr(SignMultiSigErr, fmt.Errorf("nonce of signer %v is invalid", i))
+		}
+		if subtle.ConstantTimeCompare(multiSigNonceCommitment(noncePoint), session.commitments[i]) != 1 {
+			return NewPrivacyErr(SignMultiSigErr, fmt.Errorf("nonce of signer %v does not match its commitment", i))
+		}
+		aggNonce.Add(aggNonce, noncePoint)
+	}
+
+	session.noncePoints = make([]*Point, len(noncePoints))
+	for i, noncePoint := range noncePoints {
+		session.noncePoints[i] = new(Point).Set(noncePoint)
+	}
+	session.challenge = HashToScalar(append(aggNonce.ToBytesS(), session.data...))
+	return nil
+}
+
+// PartialSign returns partial signature z_i = r_i - e*a_i*x_i of this signer (round 3)
+// the nonce is erased, so a session can not sign twice
+func (session *MultiSigSession) PartialSign() (*Scalar, error) {
+	if session.challenge == nil {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("nonces have not been received"))
+	}
+	if session.nonce == nil {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("session was already used to sign"))
+	}
+	ex := new(Scalar).Mul(session.challenge, session.coefficients[session.index])
+	ex.Mul(ex, session.privateKey)
+	z := new(Scalar).Sub(session.nonce, ex)
+	session.nonce = nil
+	return z, nil
+}
+
+// VerifyPartialSignature checks partial signature z of signer at index: z*G + e*a_i*X_i = R_i
+func (session MultiSigSession) VerifyPartialSignature(index int, z *Scalar) bool {
+	if session.challenge == nil || index < 0 || index >= len(session.pubKeys) || z == nil {
+		return false
+	}
+	ea := new(Scalar).Mul(session.challenge, session.coefficients[index])
+	rv := new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], z)
+	rv.Add(rv, new(Point).ScalarMult(session.pubKeys[index], ea))
+	return IsPointEqual(rv, session.noncePoints[index])
+}
+
+// AggregateSignatures combines partial signatures of all signers, in order of PublicKeys
+// into a signature that is verified by AggregatedPublicKey
+func (session MultiSigSession) AggregateSignatures(partialSigs []*Scalar) (*SchnSignature, error) {
+	if session.challenge == nil {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("nonces have not been received"))
+	}
+	if len(partialSigs) != len(session.pubKeys) {
+		return nil, NewPrivacyErr(InvalidLengthMultiSigErr, errors.New("number of partial signatures is invalid"))
+	}
+	z := new(Scalar).FromUint64(0)
+	for i, partialSig := range partialSigs {
+		if !session.VerifyPartialSignature(i, partialSig) {
+			return nil, NewPrivacyErr(SignMultiSigErr, fmt.Errorf("partial signature of signer %v is invalid", i))
+		}
+		z.Add(z, partialSig)
+	}
+
+	signature := new(SchnSignature)
+	signature.e = new(Scalar).Set(session.challenge)
+	signature.z1 = z
+	signature.z2 = nil
+	return signature, nil
+}
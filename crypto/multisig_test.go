@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMultiSigSessions creates sessions of n signers on data, in order of sorted public keys
+func newMultiSigSessions(t *testing.T, n int, data []byte) []*MultiSigSession {
+	privKeys := make([]*Scalar, n)
+	pubKeys := make([]*Point, n)
+	for i := 0; i < n; i++ {
+		privKeys[i] = RandomScalar()
+		pubKeys[i] = new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], privKeys[i])
+	}
+	sessions := make([]*MultiSigSession, n)
+	for i := 0; i < n; i++ {
+		session, err := NewMultiSigSession(privKeys[i], pubKeys, data)
+		assert.Equal(t, nil, err)
+		sessions[session.Index()] = session
+	}
+	return sessions
+}
+
+func exchangeMultiSigNonces(t *testing.T, sessions []*MultiSigSession) {
+	commitments := make([][]byte, len(sessions))
+	for i, session := range sessions {
+		commitments[i] = session.NonceCommitment()
+	}
+	nonces := make([]*Point, len(sessions))
+	for i, session := range sessions {
+		assert.Equal(t, nil, session.SetNonceCommitments(commitments))
+		var err error
+		nonces[i], err = session.Nonce()
+		assert.Equal(t, nil, err)
+	}
+	for _, session := range sessions {
+		assert.Equal(t, nil, session.SetNonces(nonces))
+	}
+}
+
+func TestMultiSig(t *testing.T) {
+	for n := 1; n <= 5; n++ {
+		data := RandomScalar().ToBytesS()
+		sessions := newMultiSigSessions(t, n, data)
+		exchangeMultiSigNonces(t, sessions)
+
+		partialSigs := make([]*Scalar, n)
+		for i, session := range sessions {
+			var err error
+			partialSigs[i], err = session.PartialSign()
+			assert.Equal(t, nil, err)
+		}
+		for i := range partialSigs {
+			assert.Equal(t, true, sessions[0].VerifyPartialSignature(i, partialSigs[i]))
+		}
+
+		signature, err := sessions[n-1].AggregateSignatures(partialSigs)
+		assert.Equal(t, nil, err)
+
+		// aggregated public key does not depend on order of public keys
+		pubKeys := sessions[0].PublicKeys()
+		reversed := make([]*Point, n)
+		for i := range pubKeys {
+			reversed[n-1-i] = pubKeys[i]
+		}
+		aggPubKey, err := AggregateSchnorrPublicKeys(reversed)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, sessions[0].AggregatedPublicKey().GetPublicKey().ToBytesS(), aggPubKey.GetPublicKey().ToBytesS())
+
+		signature2 := new(SchnSignature)
+		assert.Equal(t, nil, signature2.SetBytes(signature.Bytes()))
+		assert.Equal(t, true, aggPubKey.Verify(signature2, data))
+		assert.Equal(t, false, aggPubKey.Verify(signature2, RandomScalar().ToBytesS()))
+	}
+}
+
+func TestMultiSigInvalidPartialSignature(t *testing.T) {
+	data := RandomScalar().ToBytesS()
+	sessions := newMultiSigSessions(t, 3, data)
+	exchangeMultiSigNonces(t, sessions)
+
+	partialSigs := make([]*Scalar, 3)
+	for i, session := range sessions {
+		partialSigs[i], _ = session.PartialSign()
+	}
+	partialSigs[1] = RandomScalar()
+	assert.Equal(t, false, sessions[0].VerifyPartialSignature(1, partialSigs[1]))
+	_, err := sessions[0].AggregateSignatures(partialSigs)
+	assert.NotEqual(t, nil, err)
+
+	// a session can not sign twice
+	_, err = sessions[0].PartialSign()
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMultiSigNonceCommitment(t *testing.T) {
+	data := RandomScalar().ToBytesS()
+	sessions := newMultiSigSessions(t, 2, data)
+
+	// nonce is not revealed before commitments are received
+	_, err := sessions[0].Nonce()
+	assert.NotEqual(t, nil, err)
+
+	commitments := [][]byte{sessions[0].NonceCommitment(), sessions[1].NonceCommitment()}
+	assert.Equal(t, nil, sessions[0].SetNonceCommitments(commitments))
+	assert.NotEqual(t, nil, sessions[0].SetNonceCommitments(commitments[:1]))
+
+	// nonce that does not match its commitment is rejected
+	nonce0, _ := sessions[0].Nonce()
+	err = sessions[0].SetNonces([]*Point{nonce0, RandomPoint()})
+	assert.NotEqual(t, nil, err)
+	_, err = sessions[0].PartialSign()
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMultiSigInvalidKeys(t *testing.T) {
+	data := RandomScalar().ToBytesS()
+	sk := RandomScalar()
+	pk := new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], sk)
+
+	_, err := NewMultiSigSession(sk, []*Point{pk, pk}, data)
+	assert.NotEqual(t, nil, err)
+	_, err = NewMultiSigSession(sk, []*Point{RandomPoint()}, data)
+	assert.NotEqual(t, nil, err)
+	_, err = NewMultiSigSession(sk, []*Point{pk}, data[:10])
+	assert.NotEqual(t, nil, err)
+	_, err = AggregateSchnorrPublicKeys(nil)
+	assert.NotEqual(t, nil, err)
+}
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	C25519 "github.com/0xkraken/incognito-sdk-golang/crypto/curve25519"
 	"github.com/pkg/errors"
 )
 
@@ -20,6 +21,8 @@ type PedersenCommitment struct {
 	// G[2]: SNDerivator
 	// G[3]: ShardID
 	// G[4]: Randomness
+
+	precomputed *PrecomputedTables // tables of G, G must not be changed after params are created
 }
 
 func newPedersenParams() PedersenCommitment {
@@ -31,6 +34,7 @@ func newPedersenParams() PedersenCommitment {
 	for i := 1; i < len(pcm.G); i++ {
 		pcm.G[i] = HashToPointFromIndex(int64(i), CStringBulletProof)
 	}
+	pcm.precomputed = NewPrecomputedTables(pcm.G)
 	return pcm
 }
 
@@ -42,6 +46,10 @@ func (com PedersenCommitment) commitAll(openings []*Scalar) (*Point, error) {
 		return nil, errors.New("invalid length of openings to commit")
 	}
 
+	if com.precomputed != nil {
+		return new(Point).MultiScalarMultCached(openings, com.precomputed.Multiples()), nil
+	}
+
 	commitment := new(Point).ScalarMult(com.G[0], openings[0])
 
 	for i := 1; i < len(com.G); i++ {
@@ -57,7 +65,13 @@ func (com PedersenCommitment) CommitAtIndex(value, rand *Scalar, index byte) *Po
 	//commitment.Add(commitment, new(Point).ScalarMult(com.G[PedersenRandomnessIndex], rand))
 	//
 	//return commitment
-	return new(Point).AddPedersen(value, com.G[index], rand, com.G[PedersenRandomnessIndex])
+	// value and rand are usually secret, so multiplications in constant time are used instead of AddPedersen
+	scalars := []*Scalar{value, rand}
+	if com.precomputed != nil {
+		tables := com.precomputed.Multiples()
+		return new(Point).MultiScalarMultCached(scalars, [][8]C25519.CachedGroupElement{tables[index], tables[PedersenRandomnessIndex]})
+	}
+	return new(Point).MultiScalarMult(scalars, []*Point{com.G[index], com.G[PedersenRandomnessIndex]})
 }
 
 
@@ -15,6 +15,10 @@ func TestPedersenCommitAll(t *testing.T) {
 		commitment, err := PedCom.commitAll(openings)
 		isValid := commitment.PointValid()
 
+		// commitment by precomputed tables is the same as without them
+		expectedCm, _ := PedersenCommitment{G: PedCom.G}.commitAll(openings)
+		assert.Equal(t, expectedCm, commitment)
+
 		assert.NotEqual(t, commitment, nil)
 		assert.Equal(t, true, isValid)
 		assert.Equal(t, nil, err)
@@ -154,12 +154,25 @@ func (p *Point) MultiScalarMultCached(scalarLs []*Scalar, pointPreComputedLs [][
 		scalarKeyLs[i] = &scalarLs[i].key
 	}
 	key := C25519.MultiScalarMultKeyCached(pointPreComputedLs, scalarKeyLs)
-	res, _ := new(Point).SetKey(key)
+	// the result is a valid point, it is not decoded by SetKey because decoding runs in variable time
+	res := new(Point)
+	res.key = *key
 	return res
 }
 
 
+// MultiScalarMult returns sum of scalarLs[i]*pointLs[i] in constant time
 func (p *Point) MultiScalarMult(scalarLs []*Scalar, pointLs []*Point) *Point {
+	return multiScalarMult(scalarLs, pointLs, C25519.MultiScalarMultKey)
+}
+
+// MultiScalarMultVartime is MultiScalarMult in variable time,
+// it is faster but must only be used with public scalars, e.g. when verifying proofs
+func (p *Point) MultiScalarMultVartime(scalarLs []*Scalar, pointLs []*Point) *Point {
+	return multiScalarMult(scalarLs, pointLs, C25519.MultiScalarMultKeyVartime)
+}
+
+func multiScalarMult(scalarLs []*Scalar, pointLs []*Point, multiScalarMultKey func(points []*C25519.Key, scalars []*C25519.Key) *C25519.Key) *Point {
 	nSc := len(scalarLs)
 	nPoint := len(pointLs)
 
@@ -173,8 +186,10 @@ func (p *Point) MultiScalarMult(scalarLs []*Scalar, pointLs []*Point) *Point {
 		scalarKeyLs[i] = &scalarLs[i].key
 		pointKeyLs[i] = &pointLs[i].key
 	}
-	key := C25519.MultiScalarMultKey(pointKeyLs, scalarKeyLs)
-	res, _ := new(Point).SetKey(key)
+	key := multiScalarMultKey(pointKeyLs, scalarKeyLs)
+	// the result is a valid point, it is not decoded by SetKey because decoding runs in variable time
+	res := new(Point)
+	res.key = *key
 	return res
 }
 
@@ -182,13 +197,13 @@ func (p *Point) InvertScalarMultBase(a *Scalar) *Point {
 	if p == nil {
 		p = new(Point)
 	}
-	inv := new(Scalar).Invert(a)
+	inv := new(Scalar).InvertConstantTime(a)
 	p.ScalarMultBase(inv)
 	return p
 }
 
 func (p *Point) InvertScalarMult(pa *Point, a *Scalar) *Point {
-	inv := new(Scalar).Invert(a)
+	inv := new(Scalar).InvertConstantTime(a)
 	p.ScalarMult(pa,inv)
 	return p
 }
@@ -209,6 +224,7 @@ func (p *Point) Add(pa, pb *Point) *Point {
 }
 
 // aA + bB
+// it runs in variable time, so a and b must not be secret
 func (p *Point) AddPedersen(a *Scalar, A *Point, b *Scalar, B *Point) *Point {
 	if p == nil {
 		p = new(Point)
@@ -230,6 +246,7 @@ func (p *Point) AddPedersen(a *Scalar, A *Point, b *Scalar, B *Point) *Point {
 	return p
 }
 
+// AddPedersenCached is AddPedersen with the odd multiples tables of A and B, it runs in variable time
 func (p *Point) AddPedersenCached(a *Scalar, APreCompute [8]C25519.CachedGroupElement, b *Scalar, BPreCompute [8]C25519.CachedGroupElement) *Point {
 	if p == nil {
 		p = new(Point)
@@ -262,7 +279,8 @@ func HashToPointFromIndex(index int64, padStr string) *Point {
 	array := C25519.GBASE.ToBytes()
 	msg := array[:]
 	msg = append(msg,[]byte(padStr)...)
-	msg = append(msg,[]byte(string(index))...)
+	// index is encoded as a rune, generators depend on these bytes
+	msg = append(msg,[]byte(string(rune(index)))...)
 
 	keyHash := C25519.Key(C25519.Keccak256(msg))
 	keyPoint := keyHash.HashToPoint()
@@ -6,6 +6,7 @@ import (
 
 	//C25519 "github.com/deroproject/derosuite/crypto"
 	C25519 "github.com/0xkraken/incognito-sdk-golang/crypto/curve25519"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -24,6 +25,88 @@ func BenchmarkPoint_AddPedersen(b *testing.B) {
 	}
 }
 
+func TestPrecomputedTables(t *testing.T) {
+	points := make([]*Point, 10)
+	scalars := make([]*Scalar, len(points))
+	for i := range points {
+		points[i] = RandomPoint()
+		scalars[i] = RandomScalar()
+	}
+	tables := NewPrecomputedTables(points)
+
+	expected := new(Point).MultiScalarMult(scalars, points)
+	assert.Equal(t, expected, new(Point).MultiScalarMultCached(scalars, tables.Multiples()))
+	assert.Equal(t, expected, new(Point).MultiScalarMultCached(scalars, tables.Multiples()))
+
+	expected = new(Point).AddPedersen(scalars[0], points[3], scalars[1], points[7])
+	assert.Equal(t, expected, new(Point).AddPedersenCached(scalars[0], tables.OddMultiples()[3], scalars[1], tables.OddMultiples()[7]))
+
+	// tables are of the points at the time they are created
+	points[0].Set(RandomPoint())
+	assert.NotEqual(t, new(Point).MultiScalarMult(scalars, points), new(Point).MultiScalarMultCached(scalars, tables.Multiples()))
+}
+
+func BenchmarkPedersenCommitment_CommitAll(b *testing.B) {
+	openings := make([]*Scalar, len(PedCom.G))
+	for i := range openings {
+		openings[i] = RandomScalar()
+	}
+	for _, bc := range []struct {
+		name string
+		com  PedersenCommitment
+	}{
+		{"precomputed", PedCom},
+		{"not_precomputed", PedersenCommitment{G: PedCom.G}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bc.com.commitAll(openings)
+			}
+		})
+	}
+}
+
+func BenchmarkPedersenCommitment_CommitAtIndex(b *testing.B) {
+	value := RandomScalar()
+	rand := RandomScalar()
+	for _, bc := range []struct {
+		name string
+		com  PedersenCommitment
+	}{
+		{"precomputed", PedCom},
+		{"not_precomputed", PedersenCommitment{G: PedCom.G}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bc.com.CommitAtIndex(value, rand, PedersenValueIndex)
+			}
+		})
+	}
+}
+
+func BenchmarkPoint_MultiScalarMultCached(b *testing.B) {
+	for _, n := range []int{64, 256} {
+		points := make([]*Point, n)
+		scalars := make([]*Scalar, n)
+		for i := range points {
+			points[i] = RandomPoint()
+			scalars[i] = RandomScalar()
+		}
+		tables := NewPrecomputedTables(points).Multiples()
+
+		b.Run(fmt.Sprintf("precomputed/n=%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				new(Point).MultiScalarMultCached(scalars, tables)
+			}
+		})
+		b.Run(fmt.Sprintf("not_precomputed/n=%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				new(Point).MultiScalarMult(scalars, points)
+			}
+		})
+	}
+}
+
 func TestPoint_ScalarMultPRIME(t *testing.T) {
 	for i:=0; i< 10000; i++ {
 		a := RandomScalar()
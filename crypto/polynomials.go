@@ -4,9 +4,8 @@ import (
 	"fmt"
 	"github.com/0xkraken/incognito-sdk-golang/common"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/curve25519"
+	"io"
 	"math/big"
-	"math/rand"
-	"time"
 )
 
 // Data structure for a polynomial
@@ -45,15 +44,14 @@ func BigIntToScalar( bi *big.Int) *Scalar {
 
 // Returns a polynomial with random coefficients
 // You can give the degree of the polynomial
-// A random coefficients have a [0, 2^bits) integer
-func randomPoly(degree, bits int64) (p Poly) {
+// A random coefficients have a [0, 2^bits) integer read from rand
+func randomPoly(degree, bits int64, rand io.Reader) (p Poly) {
 	p = make(Poly, degree+1)
-	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
 	exp := big.NewInt(2)
 	exp.Exp(exp, big.NewInt(bits), nil)
 	for i := 0; i <= p.GetDegree(); i++ {
-		p[i] = new(big.Int)
-		p[i].Rand(rr, exp)
+		p[i] = new(big.Int).SetBytes(RandBytesFromReader(rand, int(bits+7)/8))
+		p[i].Mod(p[i], exp)
 	}
 	p.trim()
 	return
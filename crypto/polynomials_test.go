@@ -212,13 +212,13 @@ func TestAdd(t *testing.T) {
 	}
 }
 
-func ExampleRandPoly() {
-	p := randomPoly(10, 128) // 계수의 크기가 0~2^128인 임의의 10차 다항식 생성
+func Example_randomPoly() {
+	p := randomPoly(10, 128, nil) // 계수의 크기가 0~2^128인 임의의 10차 다항식 생성
 	fmt.Println(p)
 }
 
 func TestRandomPoly(t *testing.T) {
-	p := randomPoly(10, 128)
+	p := randomPoly(10, 128, nil)
 	if p.GetDegree() != 10 {
 		t.Errorf("Polynomial %v should have %v degrees", p, p.GetDegree())
 	}
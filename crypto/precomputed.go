@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"sync"
+
+	C25519 "github.com/0xkraken/incognito-sdk-golang/crypto/curve25519"
+)
+
+// PrecomputedTables holds the precomputed tables of a list of fixed points,
+// each kind of table is built on first use and shared by all callers
+type PrecomputedTables struct {
+	points []*Point
+
+	multiplesOnce sync.Once
+	multiples     [][8]C25519.CachedGroupElement // P, 2P, ..., 8P
+
+	oddMultiplesOnce sync.Once
+	oddMultiples     [][8]C25519.CachedGroupElement // P, 3P, ..., 15P
+}
+
+// NewPrecomputedTables returns tables of copies of points, nothing is computed until the tables are used
+func NewPrecomputedTables(points []*Point) *PrecomputedTables {
+	tables := &PrecomputedTables{points: make([]*Point, len(points))}
+	for i, p := range points {
+		tables.points[i] = new(Point).Set(p)
+	}
+	return tables
+}
+
+// Multiples returns the tables of points for MultiScalarMultCached
+func (tables *PrecomputedTables) Multiples() [][8]C25519.CachedGroupElement {
+	tables.multiplesOnce.Do(func() {
+		tables.multiples = make([][8]C25519.CachedGroupElement, len(tables.points))
+		for i, p := range tables.points {
+			tables.multiples[i] = C25519.PreComputeForMultiScalar(&p.key)
+		}
+	})
+	return tables.multiples
+}
+
+// OddMultiples returns the tables of points for AddPedersenCached
+func (tables *PrecomputedTables) OddMultiples() [][8]C25519.CachedGroupElement {
+	tables.oddMultiplesOnce.Do(func() {
+		tables.oddMultiples = make([][8]C25519.CachedGroupElement, len(tables.points))
+		for i, p := range tables.points {
+			C25519.GePrecompute(&tables.oddMultiples[i], p.key.ToExtended())
+		}
+	})
+	return tables.oddMultiples
+}
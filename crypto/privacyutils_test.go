@@ -8,12 +8,6 @@ import (
 	"testing"
 )
 
-var _ = func() (_ struct{}) {
-	fmt.Println("This runs before init()!")
-	Logger.Init(common.NewBackend(nil).Logger("test", true))
-	return
-}()
-
 func TestUtilsRandBytes(t *testing.T) {
 	data := []int{
 		0,
@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"io"
+
+	C25519 "github.com/0xkraken/incognito-sdk-golang/crypto/curve25519"
+	"golang.org/x/crypto/sha3"
+)
+
+// Functions in this file take the source of randomness as an io.Reader, crypto/rand is used if it is nil
+// a deterministic source (see NewDRBG) makes proofs, ciphertexts and signatures reproducible,
+// it must only be used in tests, or with a secret seed that is never reused
+
+// RandBytesFromReader reads random bytes with length from r
+// it panics if r fails, because a predictable value must never be used as randomness
+func RandBytesFromReader(r io.Reader, length int) []byte {
+	if r == nil {
+		r = rand.Reader
+	}
+	rbytes := make([]byte, length)
+	if _, err := io.ReadFull(r, rbytes); err != nil {
+		panic("can not read randomness: " + err.Error())
+	}
+	return rbytes
+}
+
+// RandomScalarFromReader returns a random scalar by reducing 64 bytes read from r,
+// the same as RandomScalar does with crypto/rand
+func RandomScalarFromReader(r io.Reader) *Scalar {
+	if r == nil {
+		return RandomScalar()
+	}
+	var reduceFrom [Ed25519KeySize * 2]byte
+	copy(reduceFrom[:], RandBytesFromReader(r, Ed25519KeySize*2))
+	sc := new(Scalar)
+	C25519.ScReduce(&sc.key, &reduceFrom)
+	return sc
+}
+
+// RandomPointFromReader returns g^x where x is a random scalar read from r
+func RandomPointFromReader(r io.Reader) *Point {
+	return new(Point).ScalarMultBase(RandomScalarFromReader(r))
+}
+
+// NewDRBG returns a deterministic random bit generator that outputs SHAKE256(seed)
+// the same seed always gives the same sequence of bytes
+func NewDRBG(seed []byte) io.Reader {
+	drbg := sha3.NewShake256()
+	drbg.Write(seed)
+	return drbg
+}
+
+// NewDRBGFromReader returns a DRBG seeded by 32 bytes read from r, or nil if r is nil,
+// it is used to split r into independent sources for tasks that run concurrently
+func NewDRBGFromReader(r io.Reader) io.Reader {
+	if r == nil {
+		return nil
+	}
+	return NewDRBG(RandBytesFromReader(r, Ed25519KeySize))
+}
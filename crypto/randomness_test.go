@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for deterministic randomness, golden vectors pin the output for fixed seeds
+*/
+
+func TestNewDRBG(t *testing.T) {
+	drbg1 := NewDRBG([]byte("seed"))
+	drbg2 := NewDRBG([]byte("seed"))
+	assert.Equal(t, RandBytesFromReader(drbg1, 100), RandBytesFromReader(drbg2, 100))
+	assert.Equal(t, RandomScalarFromReader(drbg1), RandomScalarFromReader(drbg2))
+	assert.NotEqual(t, RandBytesFromReader(NewDRBG([]byte("seed")), 32), RandBytesFromReader(NewDRBG([]byte("seed2")), 32))
+
+	sc := RandomScalarFromReader(NewDRBG([]byte("seed")))
+	assert.Equal(t, true, sc.ScalarValid())
+	assert.Equal(t, "0195fcdfbb8e130450097d5da1c91364d67d5dedd66c748dd30931c6ae325f08", hex.EncodeToString(sc.ToBytesS()))
+
+	// sub sources are independent of each other and are nil for crypto/rand
+	drbg := NewDRBG([]byte("seed"))
+	assert.NotEqual(t, RandBytesFromReader(NewDRBGFromReader(drbg), 32), RandBytesFromReader(NewDRBGFromReader(drbg), 32))
+	assert.Equal(t, nil, NewDRBGFromReader(nil))
+	assert.Equal(t, 32, len(RandBytesFromReader(nil, 32)))
+}
+
+func TestSignWithRandomnessGolden(t *testing.T) {
+	drbg := NewDRBG([]byte("schnorr"))
+	privKey := new(SchnorrPrivateKey)
+	privKey.Set(RandomScalarFromReader(drbg), RandomScalarFromReader(drbg))
+	data := RandBytesFromReader(drbg, 32)
+
+	signature, err := privKey.SignWithRandomness(data, NewDRBG([]byte("nonce")))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, privKey.publicKey.Verify(signature, data))
+	assert.Equal(t, "c8d4663e8801676800fe2692d214dafb99c33c782fed27907a6782f791d32c06bfd0d5348b71b48ee0c602b01b9760921ea6e49842ad61ead20a70eca9ee0d04fe7e4e707a37a5eac5f8a3ab3a10b397fbe55b270dcf778bd5d622b7867be40c", hex.EncodeToString(signature.Bytes()))
+
+	privKey.Set(privKey.privateKey, new(Scalar).FromUint64(0))
+	signature, err = privKey.SignWithRandomness(data, NewDRBG([]byte("nonce")))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "77d0eab8e2d72132f5291c1723f0ebfbb2768e88826d03a6dec6d930ef87940e692c4eb8afcd2efc4242605b577998c96dab1c792fe7a4ec65365cb4b3385c07", hex.EncodeToString(signature.Bytes()))
+}
+
+func TestHybridEncryptWithRandomnessGolden(t *testing.T) {
+	drbg := NewDRBG([]byte("hybrid"))
+	privateKey := RandomScalarFromReader(drbg)
+	publicKey := new(Point).ScalarMultBase(privateKey)
+	msg := []byte("hybrid encryption with deterministic randomness")
+
+	ciphertext, err := HybridEncryptWithRandomness(msg, publicKey, NewDRBG([]byte("encrypt")))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "f2cf993c19b7e32a453bc454f15e634c336fb88f2ec30a35da1161895ea473b2da6ec8e8ae605789966ff5f7b55e1fecfa875b6f7b0b1a40b59e35c154be3e0f2a40131fee2e2c25ed5d99b55e31732c62c7758eb659cb902100c7ec444fb69e02ff3e908531e7c2ad1464c34a90ff5f4d86baa1eb995c56e5f347ba570729", hex.EncodeToString(ciphertext.Bytes()))
+
+	plaintext, err := HybridDecrypt(ciphertext, privateKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, msg, plaintext)
+}
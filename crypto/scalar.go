@@ -206,20 +206,22 @@ func IsScalarEqual(sc1, sc2 *Scalar) bool {
 	return subtle.ConstantTimeCompare(tmpa, tmpb) == 1
 }
 
+// Compare returns 1 if sca > scb, -1 if sca < scb and 0 otherwise, in constant time
 func Compare(sca, scb *Scalar) int {
 	tmpa := sca.ToBytesS()
 	tmpb := scb.ToBytesS()
 
-	for i:= Ed25519KeySize -1; i>=0; i-- {
-		if uint64(tmpa[i]) > uint64(tmpb[i]) {
-			return 1
-		}
-
-		if uint64(tmpa[i]) < uint64(tmpb[i]) {
-			return -1
-		}
+	// bytes are little-endian, the result of a more significant byte overrides the one of less significant bytes
+	gt, lt := 0, 0
+	for i := 0; i < Ed25519KeySize; i++ {
+		a, b := int(tmpa[i]), int(tmpb[i])
+		isGt := ((b - a) >> 8) & 1
+		isLt := ((a - b) >> 8) & 1
+		isEq := 1 ^ (isGt | isLt)
+		gt = isGt | (isEq & gt)
+		lt = isLt | (isEq & lt)
 	}
-	return 0
+	return gt - lt
 }
 
 func (sc *Scalar) IsZero() bool {
@@ -274,6 +276,31 @@ func (sc *Scalar) Invert(a *Scalar) *Scalar {
 	return sc
 }
 
+// InvertConstantTime is Invert in constant time by computing a^(l-2) where l is the curve order,
+// it is slower than Invert, so it is only used for secret values
+func (sc *Scalar) InvertConstantTime(a *Scalar) *Scalar {
+	if sc == nil {
+		sc = new(Scalar)
+	}
+
+	// l - 2, the lowest byte of l is 0xed
+	exp := C25519.CurveOrder()
+	exp[0] -= 2
+
+	var res C25519.Key
+	res[0] = 1
+	x := a.key
+	// bits of exp are public, so branching on them does not leak a
+	for i := 8*Ed25519KeySize - 1; i >= 0; i-- {
+		C25519.ScMul(&res, &res, &res)
+		if (exp[i/8]>>uint(i%8))&1 == 1 {
+			C25519.ScMul(&res, &res, &x)
+		}
+	}
+	sc.key = res
+	return sc
+}
+
 func Reverse(x C25519.Key) (result C25519.Key) {
 	result = x
 	// A key is in little-endian, but the big package wants the bytes in
@@ -287,7 +314,8 @@ func Reverse(x C25519.Key) (result C25519.Key) {
 
 func d2h(val uint64) *C25519.Key {
 	key := new(C25519.Key)
-	for i := 0; val > 0; i++ {
+	// all 8 bytes are set so that the time does not depend on val
+	for i := 0; i < 8; i++ {
 		key[i] = byte(val & 0xFF)
 		val /= 256
 	}
@@ -4,6 +4,7 @@ import (
 	"crypto/subtle"
 	"errors"
 	"github.com/0xkraken/incognito-sdk-golang/common"
+	"io"
 )
 
 // SchnorrPublicKey represents Schnorr Publickey
@@ -54,6 +55,11 @@ func (publicKey *SchnorrPublicKey) Set(pk *Point) {
 
 //Sign is function which using for signing on hash array by private key
 func (privateKey SchnorrPrivateKey) Sign(data []byte) (*SchnSignature, error) {
+	return privateKey.SignWithRandomness(data, nil)
+}
+
+// SignWithRandomness is the same as Sign, but nonces are read from rand
+func (privateKey SchnorrPrivateKey) SignWithRandomness(data []byte, rand io.Reader) (*SchnSignature, error) {
 	if len(data) != common.HashSize {
 		return nil, NewPrivacyErr(UnexpectedErr, errors.New("hash length must be 32 bytes"))
 	}
@@ -64,8 +70,8 @@ func (privateKey SchnorrPrivateKey) Sign(data []byte) (*SchnSignature, error) {
 	if !privateKey.randomness.IsZero() {
 		// generates random numbers s1, s2 in [0, Curve.Params().N - 1]
 
-		s1 := RandomScalar()
-		s2 := RandomScalar()
+		s1 := RandomScalarFromReader(rand)
+		s2 := RandomScalarFromReader(rand)
 
 		// t = s1*G + s2*H
 		t := new(Point).ScalarMult(privateKey.publicKey.g, s1)
@@ -86,7 +92,7 @@ func (privateKey SchnorrPrivateKey) Sign(data []byte) (*SchnSignature, error) {
 	}
 
 	// generates random numbers s, k2 in [0, Curve.Params().N - 1]
-	s := RandomScalar()
+	s := RandomScalarFromReader(rand)
 
 	// t = s*G
 	t := new(Point).ScalarMult(privateKey.publicKey.g, s)
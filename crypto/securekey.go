@@ -0,0 +1,104 @@
+package crypto
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ZeroBytes overwrites b with zeros
+func ZeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Zero overwrites private key with zeros
+func (privateKey PrivateKey) Zero() {
+	ZeroBytes(privateKey)
+}
+
+// Zero overwrites receiving key with zeros
+func (receivingKey ReceivingKey) Zero() {
+	ZeroBytes(receivingKey)
+}
+
+// Zero overwrites scalar with zeros, it is used to erase scalars derived from private keys
+func (sc *Scalar) Zero() {
+	if sc == nil {
+		return
+	}
+	for i := range sc.key {
+		sc.key[i] = 0
+	}
+}
+
+// SecureKey holds a secret (private key, seed, serialized private key) in a buffer that is zeroed by Destroy
+// the secret is only accessed in Use, and it is not printed or marshaled to avoid leaking it in logs
+// note that the garbage collector may have moved or copied the buffer before, so zeroing is best effort
+type SecureKey struct {
+	mu  sync.RWMutex
+	buf []byte
+}
+
+// NewSecureKey returns a SecureKey that takes ownership of secret
+// secret is zeroed by Destroy, the caller must not use it afterward
+func NewSecureKey(secret []byte) *SecureKey {
+	key := &SecureKey{buf: secret}
+	runtime.SetFinalizer(key, (*SecureKey).Destroy)
+	return key
+}
+
+// NewSecureKeyFromCopy returns a SecureKey that holds a copy of secret
+func NewSecureKeyFromCopy(secret []byte) *SecureKey {
+	return NewSecureKey(append([]byte{}, secret...))
+}
+
+// Use calls fn with the secret, fn must not retain the secret after it returns
+// it returns error if key was destroyed
+func (key *SecureKey) Use(fn func(secret []byte) error) error {
+	key.mu.RLock()
+	defer key.mu.RUnlock()
+	if key.buf == nil {
+		return errors.New("secure key was destroyed")
+	}
+	return fn(key.buf)
+}
+
+// Len returns length of the secret, 0 if key was destroyed
+func (key *SecureKey) Len() int {
+	key.mu.RLock()
+	defer key.mu.RUnlock()
+	return len(key.buf)
+}
+
+// IsDestroyed returns true if key was destroyed
+func (key *SecureKey) IsDestroyed() bool {
+	key.mu.RLock()
+	defer key.mu.RUnlock()
+	return key.buf == nil
+}
+
+// Destroy zeroes the secret, key can not be used afterward
+func (key *SecureKey) Destroy() {
+	key.mu.Lock()
+	defer key.mu.Unlock()
+	ZeroBytes(key.buf)
+	key.buf = nil
+}
+
+func (key *SecureKey) String() string {
+	return "SecureKey(redacted)"
+}
+
+func (key *SecureKey) GoString() string {
+	return key.String()
+}
+
+func (key *SecureKey) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("secure key can not be marshaled")
+}
+
+func (key *SecureKey) MarshalText() ([]byte, error) {
+	return nil, errors.New("secure key can not be marshaled")
+}
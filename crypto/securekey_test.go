@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureKey(t *testing.T) {
+	secret := []byte{1, 2, 3, 4}
+	key := NewSecureKey(secret)
+	assert.Equal(t, 4, key.Len())
+
+	err := key.Use(func(b []byte) error {
+		assert.Equal(t, []byte{1, 2, 3, 4}, b)
+		return nil
+	})
+	assert.Equal(t, nil, err)
+
+	// secret is not leaked by printing or marshaling
+	assert.Equal(t, "SecureKey(redacted)", fmt.Sprintf("%v", key))
+	assert.Equal(t, "SecureKey(redacted)", fmt.Sprintf("%#v", key))
+	_, err = json.Marshal(struct{ Key *SecureKey }{key})
+	assert.NotEqual(t, nil, err)
+
+	key.Destroy()
+	assert.Equal(t, []byte{0, 0, 0, 0}, secret)
+	assert.Equal(t, true, key.IsDestroyed())
+	assert.Equal(t, 0, key.Len())
+	err = key.Use(func(b []byte) error {
+		t.Fatal("destroyed key must not be used")
+		return nil
+	})
+	assert.NotEqual(t, nil, err)
+}
+
+func TestSecureKeyFromCopy(t *testing.T) {
+	secret := []byte{1, 2, 3, 4}
+	key := NewSecureKeyFromCopy(secret)
+	key.Destroy()
+	assert.Equal(t, []byte{1, 2, 3, 4}, secret)
+}
+
+func TestZeroPrivateKey(t *testing.T) {
+	privateKey := GeneratePrivateKey([]byte{1, 2, 3})
+	privateKey.Zero()
+	assert.Equal(t, make([]byte, Ed25519KeySize), []byte(privateKey))
+
+	sc := RandomScalar()
+	sc.Zero()
+	assert.Equal(t, true, sc.IsZero())
+}
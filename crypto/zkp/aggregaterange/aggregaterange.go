@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/pkg/errors"
+	"io"
 )
 
 // This protocol proves in zero-knowledge that a list of committed values falls in [0, 2^64)
@@ -56,6 +57,11 @@ func (proof AggregatedRangeProof) ValidateSanity() bool {
 	return proof.innerProductProof.ValidateSanity()
 }
 
+// GetCommitments returns the commitments of the values that are proved to be in range
+func (proof AggregatedRangeProof) GetCommitments() []*crypto.Point {
+	return proof.cmsValue
+}
+
 func (proof *AggregatedRangeProof) Init() {
 	proof.a = new(crypto.Point).Identity()
 	proof.s = new(crypto.Point).Identity()
@@ -127,6 +133,11 @@ func (proof *AggregatedRangeProof) SetBytes(bytes []byte) error {
 	offset := 1
 	var err error
 
+	// cmsValue, a, s, t1, t2, tauX, tHat, mu and a non-empty inner product proof
+	if len(bytes) <= offset+(lenValues+7)*crypto.Ed25519KeySize {
+		return errors.New("out of range parse aggregated range proof")
+	}
+
 	proof.cmsValue = make([]*crypto.Point, lenValues)
 	for i := 0; i < lenValues; i++ {
 		proof.cmsValue[i], err = new(crypto.Point).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
@@ -170,7 +181,10 @@ func (proof *AggregatedRangeProof) SetBytes(bytes []byte) error {
 	offset += crypto.Ed25519KeySize
 
 	proof.innerProductProof = new(InnerProductProof)
-	proof.innerProductProof.SetBytes(bytes[offset:])
+	err = proof.innerProductProof.SetBytes(bytes[offset:])
+	if err != nil {
+		return err
+	}
 
 	//crypto.Logger.Log.Debugf("AFTER SETBYTES ------------ %v\n", proof.Bytes())
 	return nil
@@ -188,6 +202,11 @@ func (wit *AggregatedRangeWitness) Set(values []uint64, rands []*crypto.Scalar)
 }
 
 func (wit AggregatedRangeWitness) Prove() (*AggregatedRangeProof, error) {
+	return wit.ProveWithRandomness(nil)
+}
+
+// ProveWithRandomness is the same as Prove, but random numbers are read from rand
+func (wit AggregatedRangeWitness) ProveWithRandomness(rand io.Reader) (*AggregatedRangeProof, error) {
 	proof := new(AggregatedRangeProof)
 
 	numValue := len(wit.values)
@@ -199,6 +218,8 @@ func (wit AggregatedRangeWitness) Prove() (*AggregatedRangeProof, error) {
 	aggParam.g = AggParam.g[0 : numValuePad*maxExp]
 	aggParam.h = AggParam.h[0 : numValuePad*maxExp]
 	aggParam.u = AggParam.u
+	aggParam.precomputedG = AggParam.precomputedG
+	aggParam.precomputedH = AggParam.precomputedH
 	csByteH := []byte{}
 	csByteG := []byte{}
 	for i := 0; i < len(aggParam.g); i++ {
@@ -247,10 +268,10 @@ func (wit AggregatedRangeWitness) Prove() (*AggregatedRangeProof, error) {
 	}
 
 	// random alpha
-	alpha := crypto.RandomScalar()
+	alpha := crypto.RandomScalarFromReader(rand)
 
 	// Commitment to aL, aR: A = h^alpha * G^aL * H^aR
-	A, err := encodeVectors(aL, aR, aggParam.g, aggParam.h)
+	A, err := encodeVectorsPrecomputed(aL, aR, aggParam, 0, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -261,15 +282,15 @@ func (wit AggregatedRangeWitness) Prove() (*AggregatedRangeProof, error) {
 	sL := make([]*crypto.Scalar, n*numValuePad)
 	sR := make([]*crypto.Scalar, n*numValuePad)
 	for i := range sL {
-		sL[i] = crypto.RandomScalar()
-		sR[i] = crypto.RandomScalar()
+		sL[i] = crypto.RandomScalarFromReader(rand)
+		sR[i] = crypto.RandomScalarFromReader(rand)
 	}
 
 	// random rho
-	rho := crypto.RandomScalar()
+	rho := crypto.RandomScalarFromReader(rand)
 
 	// Commitment to sL, sR : S = h^rho * G^sL * H^sR
-	S, err := encodeVectors(sL, sR, aggParam.g, aggParam.h)
+	S, err := encodeVectorsPrecomputed(sL, sR, aggParam, 0, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -362,8 +383,8 @@ func (wit AggregatedRangeWitness) Prove() (*AggregatedRangeProof, error) {
 	}
 
 	// commitment to t1, t2
-	tau1 := crypto.RandomScalar()
-	tau2 := crypto.RandomScalar()
+	tau1 := crypto.RandomScalarFromReader(rand)
+	tau2 := crypto.RandomScalarFromReader(rand)
 
 	proof.t1 = crypto.PedCom.CommitAtIndex(t1, tau1, crypto.PedersenValueIndex)
 	proof.t2 = crypto.PedCom.CommitAtIndex(t2, tau2, crypto.PedersenValueIndex)
@@ -428,7 +449,7 @@ func (wit AggregatedRangeWitness) Prove() (*AggregatedRangeProof, error) {
 	innerProductWit := new(InnerProductWitness)
 	innerProductWit.a = lVector
 	innerProductWit.b = rVector
-	innerProductWit.p, err = encodeVectors(lVector, rVector, aggParam.g, aggParam.h)
+	innerProductWit.p, err = encodeVectorsPrecomputed(lVector, rVector, aggParam, 0, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -528,7 +549,7 @@ func (proof AggregatedRangeProof) Verify() (bool, error) {
 	right1.Add(right1, new(crypto.Point).AddPedersen(deltaYZ, crypto.PedCom.G[crypto.PedersenValueIndex], x, proof.t1))
 
 	expVector := vectorMulScalar(powerVector(z, numValuePad), zSquare)
-	right1.Add(right1, new(crypto.Point).MultiScalarMult(expVector, tmpcmsValue))
+	right1.Add(right1, new(crypto.Point).MultiScalarMultVartime(expVector, tmpcmsValue))
 
 	if !crypto.IsPointEqual(left1, right1) {
 		fmt.Errorf("verify aggregated range proof statement 1 failed")
@@ -633,7 +654,7 @@ func VerifyBatchingAggregatedRangeProofs(proofs []*AggregatedRangeProof) (bool,
 		right1.Add(right1, new(crypto.Point).AddPedersen(deltaYZ, crypto.PedCom.G[crypto.PedersenValueIndex], x, proof.t1))
 
 		expVector := vectorMulScalar(powerVector(z, numValuePad), zSquare)
-		right1.Add(right1, new(crypto.Point).MultiScalarMult(expVector, tmpcmsValue))
+		right1.Add(right1, new(crypto.Point).MultiScalarMultVartime(expVector, tmpcmsValue))
 
 		if !crypto.IsPointEqual(left1, right1) {
 			fmt.Errorf("verify aggregated range proof statement 1 failed index %d", k)
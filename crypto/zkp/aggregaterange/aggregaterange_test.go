@@ -2,7 +2,6 @@ package aggregaterange
 
 import (
 	"fmt"
-	"github.com/0xkraken/incognito-sdk-golang/common"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
@@ -17,12 +16,6 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
-var _ = func() (_ struct{}) {
-	fmt.Println("This runs before init()!")
-	crypto.Logger.Init(common.NewBackend(nil).Logger("test", true))
-	return
-}()
-
 func TestPad(t *testing.T) {
 	data := []struct {
 		number       int
@@ -83,7 +76,7 @@ func TestEncodeVectors(t *testing.T) {
 
 		actualRes, err := encodeVectors(a, b, G, H)
 		if err != nil {
-			crypto.Logger.Log.Info("Err: %v\n", err)
+			fmt.Printf("Err: %v\n", err)
 		}
 
 		expectedRes := new(crypto.Point).Identity()
@@ -230,7 +223,7 @@ func TestInnerProductProveVerify(t *testing.T) {
 		c, err := innerProduct(wit.a, wit.b)
 
 		if err != nil {
-			crypto.Logger.Log.Info("Err: %v\n", err)
+			fmt.Printf("Err: %v\n", err)
 		}
 		wit.p = new(crypto.Point).ScalarMult(aggParam.u, c)
 
@@ -283,7 +276,7 @@ func TestInnerProductProveVerifyUltraFast(t *testing.T) {
 
 		c, err := innerProduct(wit.a, wit.b)
 		if err != nil {
-			crypto.Logger.Log.Info("Err: %v\n", err)
+			fmt.Printf("Err: %v\n", err)
 		}
 		if k == 0 {
 			wit.p = new(crypto.Point).ScalarMult(aggParam.u, c.Add(c, new(crypto.Scalar).FromUint64(1)))
@@ -358,7 +351,7 @@ func TestAnStrictInnerProductProveVerifyUltraFast(t *testing.T) {
 		}
 		c, err := innerProduct(wit.a, wit.b)
 		if err != nil {
-			crypto.Logger.Log.Info("Err: %v\n", err)
+			fmt.Printf("Err: %v\n", err)
 		}
 		wit.p = new(crypto.Point).ScalarMult(aggParam.u, c)
 		for i := range wit.a {
@@ -376,10 +369,10 @@ func TestAnStrictInnerProductProveVerifyUltraFast(t *testing.T) {
 	res := VerifyBatchingInnerProductProofs(proofs, csList)
 	assert.Equal(t, true, res)
 	for j := 0; j < 50; j += 1 {
-		i := common.RandInt() % len(proofs)
-		r := common.RandInt() % 5
+		i := rand.Int() % len(proofs)
+		r := rand.Int() % 5
 		if r == 0 {
-			ran := common.RandInt() % len(proofs[i].l)
+			ran := rand.Int() % len(proofs[i].l)
 			remember := proofs[i].l[ran]
 			proofs[i].l[ran] = obfuscatePoint(proofs[i].l[ran])
 			assert.NotEqual(t, remember, proofs[i].l[ran])
@@ -387,7 +380,7 @@ func TestAnStrictInnerProductProveVerifyUltraFast(t *testing.T) {
 			assert.Equal(t, false, res)
 			proofs[i].l[ran] = remember
 		} else if r == 1 {
-			ran := common.RandInt() % len(proofs[i].r)
+			ran := rand.Int() % len(proofs[i].r)
 			remember := proofs[i].r[ran]
 			proofs[i].r[ran] = obfuscatePoint(proofs[i].r[ran])
 			assert.NotEqual(t, remember, proofs[i].r[ran])
@@ -423,8 +416,8 @@ func TestAnStrictInnerProductProveVerifyUltraFast(t *testing.T) {
 func obfuscatePoint(value *crypto.Point) *crypto.Point {
 	for {
 		k := value.GetKey()
-		r := common.RandInt() % len(k)
-		i := common.RandInt() % 8
+		r := rand.Int() % len(k)
+		i := rand.Int() % 8
 		k[r] ^= (1 << uint8(i))
 		after, err := new(crypto.Point).SetKey(&k)
 		if err == nil {
@@ -435,8 +428,8 @@ func obfuscatePoint(value *crypto.Point) *crypto.Point {
 func obfuscateScalar(value *crypto.Scalar) *crypto.Scalar {
 	for {
 		k := value.GetKey()
-		r := common.RandInt() % len(k)
-		i := common.RandInt() % 8
+		r := rand.Int() % len(k)
+		i := rand.Int() % 8
 		k[r] ^= (1 << uint8(i))
 		after, err := new(crypto.Scalar).SetKey(&k)
 		if err == nil {
@@ -451,7 +444,7 @@ func benchmarkAggRangeProof_Verify(numberofOutput int, b *testing.B) {
 	rands := make([]*crypto.Scalar, numberofOutput)
 
 	for i := range values {
-		values[i] = uint64(common.RandInt64())
+		values[i] = uint64(rand.Int63())
 		rands[i] = crypto.RandomScalar()
 	}
 	wit.Set(values, rands)
@@ -470,7 +463,7 @@ func benchmarkAggRangeProof_VerifyFaster(numberofOutput int, b *testing.B) {
 	rands := make([]*crypto.Scalar, numberofOutput)
 
 	for i := range values {
-		values[i] = uint64(common.RandInt64())
+		values[i] = uint64(rand.Int63())
 		rands[i] = crypto.RandomScalar()
 	}
 	wit.Set(values, rands)
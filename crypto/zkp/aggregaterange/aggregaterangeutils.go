@@ -20,6 +20,10 @@ type bulletproofParams struct {
 	h  []*crypto.Point
 	u  *crypto.Point
 	cs []byte
+
+	// tables of g and h of AggParam, the tables of g[i], h[i] are at index i
+	precomputedG *crypto.PrecomputedTables
+	precomputedH *crypto.PrecomputedTables
 }
 
 var AggParam = newBulletproofParams(numOutputParam)
@@ -46,6 +50,8 @@ func newBulletproofParams(m int) *bulletproofParams {
 	gen.cs = append(gen.cs, csByteH...)
 	gen.cs = append(gen.cs, gen.u.ToBytesS()...)
 
+	gen.precomputedG = crypto.NewPrecomputedTables(gen.g)
+	gen.precomputedH = crypto.NewPrecomputedTables(gen.h)
 	return gen
 }
 
@@ -181,6 +187,20 @@ func encodeVectors(l []*crypto.Scalar, r []*crypto.Scalar, g []*crypto.Point, h
 	return res, nil
 }
 
+// encodeVectorsPrecomputed is encodeVectors with generators g[offsetG:], h[offsetH:] of aggParam,
+// it uses the precomputed tables of the generators if aggParam has them
+func encodeVectorsPrecomputed(l []*crypto.Scalar, r []*crypto.Scalar, aggParam *bulletproofParams, offsetG int, offsetH int) (*crypto.Point, error) {
+	if len(l) != len(r) || offsetG+len(l) > len(aggParam.g) || offsetH+len(r) > len(aggParam.h) {
+		return nil, errors.New("invalid input")
+	}
+	if aggParam.precomputedG == nil || aggParam.precomputedH == nil {
+		return encodeVectors(l, r, aggParam.g[offsetG:offsetG+len(l)], aggParam.h[offsetH:offsetH+len(r)])
+	}
+	tmp1 := new(crypto.Point).MultiScalarMultCached(l, aggParam.precomputedG.Multiples()[offsetG:offsetG+len(l)])
+	tmp2 := new(crypto.Point).MultiScalarMultCached(r, aggParam.precomputedH.Multiples()[offsetH:offsetH+len(r)])
+	return new(crypto.Point).Add(tmp1, tmp2), nil
+}
+
 // estimateMultiRangeProofSize estimate multi range proof size
 func EstimateMultiRangeProofSize(nOutput int) uint64 {
 	return uint64((nOutput+2*int(math.Log2(float64(maxExp*pad(nOutput))))+5)*crypto.Ed25519KeySize + 5*crypto.Ed25519KeySize + 2)
@@ -67,6 +67,11 @@ func (proof *InnerProductProof) SetBytes(bytes []byte) error {
 	offset := 1
 	var err error
 
+	// l, r, a, b and p
+	if len(bytes) != offset+(2*lenLArray+3)*crypto.Ed25519KeySize {
+		return errors.New("invalid length of inner product proof")
+	}
+
 	proof.l = make([]*crypto.Point, lenLArray)
 	for i := 0; i < lenLArray; i++ {
 		proof.l[i], err = new(crypto.Point).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
@@ -127,6 +132,8 @@ func (wit InnerProductWitness) Prove(aggParam *bulletproofParams) (*InnerProduct
 	proof.r = make([]*crypto.Point, 0)
 	proof.p = new(crypto.Point).Set(wit.p)
 
+	// G, H of the first round are the generators of aggParam, so their precomputed tables are used
+	firstRound := true
 	for n > 1 {
 		nPrime := n / 2
 
@@ -140,14 +147,23 @@ func (wit InnerProductWitness) Prove(aggParam *bulletproofParams) (*InnerProduct
 			return nil, err
 		}
 
-		L, err := encodeVectors(a[:nPrime], b[nPrime:], G[nPrime:], H[:nPrime])
+		var L, R *crypto.Point
+		if firstRound {
+			L, err = encodeVectorsPrecomputed(a[:nPrime], b[nPrime:], aggParam, nPrime, 0)
+		} else {
+			L, err = encodeVectors(a[:nPrime], b[nPrime:], G[nPrime:], H[:nPrime])
+		}
 		if err != nil {
 			return nil, err
 		}
 		L.Add(L, new(crypto.Point).ScalarMult(aggParam.u, cL))
 		proof.l = append(proof.l, L)
 
-		R, err := encodeVectors(a[nPrime:], b[:nPrime], G[:nPrime], H[nPrime:])
+		if firstRound {
+			R, err = encodeVectorsPrecomputed(a[nPrime:], b[:nPrime], aggParam, 0, nPrime)
+		} else {
+			R, err = encodeVectors(a[nPrime:], b[:nPrime], G[:nPrime], H[nPrime:])
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -165,9 +181,18 @@ func (wit InnerProductWitness) Prove(aggParam *bulletproofParams) (*InnerProduct
 		GPrime := make([]*crypto.Point, nPrime)
 		HPrime := make([]*crypto.Point, nPrime)
 
-		for i := range GPrime {
-			GPrime[i] = new(crypto.Point).AddPedersen(xInverse, G[i], x, G[i+nPrime])
-			HPrime[i] = new(crypto.Point).AddPedersen(x, H[i], xInverse, H[i+nPrime])
+		if firstRound && aggParam.precomputedG != nil && aggParam.precomputedH != nil {
+			gTables := aggParam.precomputedG.OddMultiples()
+			hTables := aggParam.precomputedH.OddMultiples()
+			for i := range GPrime {
+				GPrime[i] = new(crypto.Point).AddPedersenCached(xInverse, gTables[i], x, gTables[i+nPrime])
+				HPrime[i] = new(crypto.Point).AddPedersenCached(x, hTables[i], xInverse, hTables[i+nPrime])
+			}
+		} else {
+			for i := range GPrime {
+				GPrime[i] = new(crypto.Point).AddPedersen(xInverse, G[i], x, G[i+nPrime])
+				HPrime[i] = new(crypto.Point).AddPedersen(x, H[i], xInverse, H[i+nPrime])
+			}
 		}
 
 		// x^2 * l + P + xInverse^2 * r
@@ -192,6 +217,7 @@ func (wit InnerProductWitness) Prove(aggParam *bulletproofParams) (*InnerProduct
 		G = GPrime
 		H = HPrime
 		n = nPrime
+		firstRound = false
 	}
 
 	proof.a = new(crypto.Scalar).Set(a[0])
@@ -247,15 +273,15 @@ func (proof InnerProductProof) Verify(aggParam *bulletproofParams) bool {
 
 	// Compute (g^s)^a (h^-s)^b u^(ab) = p l^(x^2) r^(-x^2)
 	c := new(crypto.Scalar).Mul(proof.a, proof.b)
-	rightHSPart1 := new(crypto.Point).MultiScalarMult(s, G)
+	rightHSPart1 := new(crypto.Point).MultiScalarMultVartime(s, G)
 	rightHSPart1.ScalarMult(rightHSPart1, proof.a)
-	rightHSPart2 := new(crypto.Point).MultiScalarMult(sInverse, H)
+	rightHSPart2 := new(crypto.Point).MultiScalarMultVartime(sInverse, H)
 	rightHSPart2.ScalarMult(rightHSPart2, proof.b)
 	rightHS := new(crypto.Point).Add(rightHSPart1, rightHSPart2)
 	rightHS.Add(rightHS, new(crypto.Point).ScalarMult(aggParam.u, c))
 
-	leftHSPart1 := new(crypto.Point).MultiScalarMult(xSquareList, proof.l)
-	leftHSPart2 := new(crypto.Point).MultiScalarMult(xInverseSquare_List, proof.r)
+	leftHSPart1 := new(crypto.Point).MultiScalarMultVartime(xSquareList, proof.l)
+	leftHSPart2 := new(crypto.Point).MultiScalarMultVartime(xInverseSquare_List, proof.r)
 	leftHS := new(crypto.Point).Add(leftHSPart1, leftHSPart2)
 	leftHS.Add(leftHS, proof.p)
 
@@ -352,15 +378,15 @@ func VerifyBatchingInnerProductProofs(proofs []*InnerProductProof, csList [][]by
 		nXInverseSquareList = append(nXInverseSquareList, xInverseSquareAlphaList...)
 	}
 
-	gAlphaAS := new(crypto.Point).MultiScalarMult(asAlphaList[0:maxN], AggParam.g[0:maxN])
-	hAlphaBSInverse := new(crypto.Point).MultiScalarMult(bsInverseAlphaList[0:maxN], AggParam.h[0:maxN])
+	gAlphaAS := new(crypto.Point).MultiScalarMultVartime(asAlphaList[0:maxN], AggParam.g[0:maxN])
+	hAlphaBSInverse := new(crypto.Point).MultiScalarMultVartime(bsInverseAlphaList[0:maxN], AggParam.h[0:maxN])
 	LHS := new(crypto.Point).Add(gAlphaAS, hAlphaBSInverse)
 	LHS.Add(LHS, new(crypto.Point).ScalarMult(AggParam.u, sum_abAlpha))
 	//fmt.Println("LHS:", LHS )
 
-	prod_PAlpha := new(crypto.Point).MultiScalarMult(alphaList, pList)
-	prod_LX := new(crypto.Point).MultiScalarMult(nXSquareList, LList)
-	prod_RX := new(crypto.Point).MultiScalarMult(nXInverseSquareList, RList)
+	prod_PAlpha := new(crypto.Point).MultiScalarMultVartime(alphaList, pList)
+	prod_LX := new(crypto.Point).MultiScalarMultVartime(nXSquareList, LList)
+	prod_RX := new(crypto.Point).MultiScalarMultVartime(nXInverseSquareList, RList)
 
 	RHS := new(crypto.Point).Add(prod_LX, prod_RX)
 	RHS.Add(RHS, prod_PAlpha)
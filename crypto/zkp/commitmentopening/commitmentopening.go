@@ -0,0 +1,206 @@
+package commitmentopening
+
+import (
+	"errors"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
+	"io"
+)
+
+// This protocol proves in zero-knowledge the knowledge of value and randomness of a Pedersen commitment
+// commitment = G[index]^value * H^rand, where H is the generator of randomness
+
+type OpeningStatement struct {
+	commitment *crypto.Point
+	index      byte
+}
+
+// OpeningWitness includes Witness: value, rand
+type OpeningWitness struct {
+	stmt  OpeningStatement
+	value *crypto.Scalar
+	rand  *crypto.Scalar
+}
+
+// OpeningProof contains Proof's value
+type OpeningProof struct {
+	stmt OpeningStatement
+
+	t *crypto.Point
+
+	zValue *crypto.Scalar
+	zRand  *crypto.Scalar
+}
+
+func (proof OpeningProof) GetCommitment() *crypto.Point {
+	return proof.stmt.commitment
+}
+
+func (proof OpeningProof) GetIndex() byte {
+	return proof.stmt.index
+}
+
+func (proof OpeningProof) ValidateSanity() bool {
+	if int(proof.stmt.index) >= len(crypto.PedCom.G) || proof.stmt.index == crypto.PedersenRandomnessIndex {
+		return false
+	}
+	if !proof.stmt.commitment.PointValid() {
+		return false
+	}
+	if !proof.t.PointValid() {
+		return false
+	}
+	if !proof.zValue.ScalarValid() {
+		return false
+	}
+	return proof.zRand.ScalarValid()
+}
+
+func (proof OpeningProof) isNil() bool {
+	if proof.stmt.commitment == nil {
+		return true
+	}
+	if proof.t == nil {
+		return true
+	}
+	if proof.zValue == nil {
+		return true
+	}
+	return proof.zRand == nil
+}
+
+func (proof *OpeningProof) Init() *OpeningProof {
+	proof.stmt.commitment = new(crypto.Point)
+	proof.t = new(crypto.Point)
+	proof.zValue = new(crypto.Scalar)
+	proof.zRand = new(crypto.Scalar)
+	return proof
+}
+
+// Set sets Witness
+func (wit *OpeningWitness) Set(
+	commitment *crypto.Point,
+	index byte,
+	value *crypto.Scalar,
+	rand *crypto.Scalar) {
+
+	wit.stmt.commitment = commitment
+	wit.stmt.index = index
+	wit.value = value
+	wit.rand = rand
+}
+
+// Set sets Proof
+func (proof *OpeningProof) Set(
+	commitment *crypto.Point,
+	index byte,
+	t *crypto.Point,
+	zValue *crypto.Scalar,
+	zRand *crypto.Scalar) {
+
+	proof.stmt.commitment = commitment
+	proof.stmt.index = index
+	proof.t = t
+	proof.zValue = zValue
+	proof.zRand = zRand
+}
+
+func (proof OpeningProof) Bytes() []byte {
+	// if proof is nil, return an empty array
+	if proof.isNil() {
+		return []byte{}
+	}
+
+	var bytes []byte
+	bytes = append(bytes, proof.stmt.index)
+	bytes = append(bytes, proof.stmt.commitment.ToBytesS()...)
+	bytes = append(bytes, proof.t.ToBytesS()...)
+	bytes = append(bytes, proof.zValue.ToBytesS()...)
+	bytes = append(bytes, proof.zRand.ToBytesS()...)
+	return bytes
+}
+
+func (proof *OpeningProof) SetBytes(bytes []byte) error {
+	if len(bytes) == 0 {
+		return errors.New("Bytes array is empty")
+	}
+	if len(bytes) != utils.OpeningProofSize {
+		return errors.New("invalid length of opening proof")
+	}
+
+	offset := 0
+	proof.stmt.index = bytes[offset]
+	offset++
+
+	var err error
+	proof.stmt.commitment, err = new(crypto.Point).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
+	if err != nil {
+		return err
+	}
+	offset += crypto.Ed25519KeySize
+
+	proof.t, err = new(crypto.Point).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
+	if err != nil {
+		return err
+	}
+	offset += crypto.Ed25519KeySize
+
+	proof.zValue = new(crypto.Scalar).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
+	offset += crypto.Ed25519KeySize
+
+	proof.zRand = new(crypto.Scalar).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
+	return nil
+}
+
+// challenge x = hash(index || commitment || t || context)
+func generateChallenge(stmt OpeningStatement, t *crypto.Point, context []byte) *crypto.Scalar {
+	return utils.GenerateChallenge([][]byte{{stmt.index}, stmt.commitment.ToBytesS(), t.ToBytesS(), context})
+}
+
+// Prove proves the knowledge of value and rand of the commitment
+// context is bound to the proof, e.g. the purpose and the time of the proof, the proof does not verify with another context
+func (wit OpeningWitness) Prove(context []byte) (*OpeningProof, error) {
+	return wit.ProveWithRandomness(context, nil)
+}
+
+// ProveWithRandomness is the same as Prove, but random numbers are read from rand
+func (wit OpeningWitness) ProveWithRandomness(context []byte, rand io.Reader) (*OpeningProof, error) {
+	if int(wit.stmt.index) >= len(crypto.PedCom.G) || wit.stmt.index == crypto.PedersenRandomnessIndex {
+		return nil, errors.New("invalid index of commitment")
+	}
+
+	// randomness
+	eValue := crypto.RandomScalarFromReader(rand)
+	eRand := crypto.RandomScalarFromReader(rand)
+	// calculate t = G[index]^eValue * H^eRand
+	t := crypto.PedCom.CommitAtIndex(eValue, eRand, wit.stmt.index)
+
+	x := generateChallenge(wit.stmt, t, context)
+
+	// calculate zValue = value * x + eValue, zRand = rand * x + eRand
+	zValue := new(crypto.Scalar).MulAdd(wit.value, x, eValue)
+	zRand := new(crypto.Scalar).MulAdd(wit.rand, x, eRand)
+
+	proof := new(OpeningProof).Init()
+	proof.Set(wit.stmt.commitment, wit.stmt.index, t, zValue, zRand)
+	return proof, nil
+}
+
+func (proof OpeningProof) Verify(context []byte) (bool, error) {
+	if proof.isNil() || !proof.ValidateSanity() {
+		return false, errors.New("invalid opening proof")
+	}
+
+	x := generateChallenge(proof.stmt, proof.t, context)
+
+	// check G[index]^zValue * H^zRand = commitment^x * t
+	leftPoint := new(crypto.Point).MultiScalarMultVartime(
+		[]*crypto.Scalar{proof.zValue, proof.zRand},
+		[]*crypto.Point{crypto.PedCom.G[proof.stmt.index], crypto.PedCom.G[crypto.PedersenRandomnessIndex]})
+	rightPoint := new(crypto.Point).ScalarMult(proof.stmt.commitment, x)
+	rightPoint.Add(rightPoint, proof.t)
+	if !crypto.IsPointEqual(leftPoint, rightPoint) {
+		return false, errors.New("verify commitment opening proof failed")
+	}
+	return true, nil
+}
@@ -0,0 +1,86 @@
+package commitmentopening
+
+import (
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOpeningProof(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		value := crypto.RandomScalar()
+		rand := crypto.RandomScalar()
+		commitment := crypto.PedCom.CommitAtIndex(value, rand, crypto.PedersenValueIndex)
+
+		witness := new(OpeningWitness)
+		witness.Set(commitment, crypto.PedersenValueIndex, value, rand)
+
+		// proving
+		proof, err := witness.Prove([]byte("context"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, proof.ValidateSanity())
+
+		// verify proof
+		res, err := proof.Verify([]byte("context"))
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		// convert proof to bytes array
+		proofBytes := proof.Bytes()
+		assert.Equal(t, utils.OpeningProofSize, len(proofBytes))
+
+		// new OpeningProof to set bytes array
+		proof2 := new(OpeningProof).Init()
+		err = proof2.SetBytes(proofBytes)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, proof, proof2)
+
+		res2, err := proof2.Verify([]byte("context"))
+		assert.Equal(t, true, res2)
+		assert.Equal(t, nil, err)
+	}
+}
+
+func TestOpeningProofInvalid(t *testing.T) {
+	value := crypto.RandomScalar()
+	rand := crypto.RandomScalar()
+	commitment := crypto.PedCom.CommitAtIndex(value, rand, crypto.PedersenValueIndex)
+
+	witness := new(OpeningWitness)
+	witness.Set(commitment, crypto.PedersenValueIndex, value, rand)
+	proof, _ := witness.Prove([]byte("context"))
+
+	// another context
+	res, err := proof.Verify([]byte("another context"))
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	// another commitment
+	proof2 := new(OpeningProof).Init()
+	proof2.Set(crypto.RandomPoint(), proof.stmt.index, proof.t, proof.zValue, proof.zRand)
+	res, err = proof2.Verify([]byte("context"))
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	// another index
+	proof2.Set(proof.stmt.commitment, crypto.PedersenSndIndex, proof.t, proof.zValue, proof.zRand)
+	res, err = proof2.Verify([]byte("context"))
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	// witness of another opening
+	witness.Set(commitment, crypto.PedersenValueIndex, value, crypto.RandomScalar())
+	proof3, _ := witness.Prove([]byte("context"))
+	res, err = proof3.Verify([]byte("context"))
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	// randomness index is not a value index
+	witness.Set(commitment, crypto.PedersenRandomnessIndex, value, rand)
+	_, err = witness.Prove([]byte("context"))
+	assert.NotEqual(t, nil, err)
+
+	err = new(OpeningProof).Init().SetBytes(proof.Bytes()[1:])
+	assert.NotEqual(t, nil, err)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
 	"github.com/pkg/errors"
+	"io"
 	"math/big"
 )
 
@@ -186,6 +187,9 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 	if len(bytes) == 0 {
 		return nil
 	}
+	if len(bytes) != utils.OneOfManyProofSize {
+		return errors.New("invalid length of one out of many proof")
+	}
 
 	n := crypto.CommitmentRingSizeExp
 
@@ -261,6 +265,11 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 
 // Prove produces a proof for the statement
 func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
+	return wit.ProveWithRandomness(nil)
+}
+
+// ProveWithRandomness is the same as Prove, but random numbers are read from rand
+func (wit OneOutOfManyWitness) ProveWithRandomness(rand io.Reader) (*OneOutOfManyProof, error) {
 	// Check the number of Commitment list's elements
 	N := len(wit.stmt.Commitments)
 	if N != crypto.CommitmentRingSize {
@@ -285,11 +294,11 @@ func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 	cd := make([]*crypto.Point, n)
 	for j := 0; j < n; j++ {
 		// Generate random numbers
-		r[j] = crypto.RandomScalar()
-		a[j] = crypto.RandomScalar()
-		s[j] = crypto.RandomScalar()
-		t[j] = crypto.RandomScalar()
-		u[j] = crypto.RandomScalar()
+		r[j] = crypto.RandomScalarFromReader(rand)
+		a[j] = crypto.RandomScalarFromReader(rand)
+		s[j] = crypto.RandomScalarFromReader(rand)
+		t[j] = crypto.RandomScalarFromReader(rand)
+		u[j] = crypto.RandomScalarFromReader(rand)
 		// convert indexIsZeroBinary[j] to crypto.Scalar
 		indexInt := new(crypto.Scalar).FromUint64(uint64(indexIsZeroBinary[j]))
 		// Calculate cl, ca, cb, cd
@@ -426,34 +435,40 @@ func (proof OneOutOfManyProof) Verify() (bool, error) {
 }
 
 // Get coefficient of x^k in the polynomial p_i(x)
+// p_i(x) is the product of n polynomials of degree 1, its coefficients are computed with scalars in constant time,
+// because a and l are secret
 func getCoefficient(iBinary []byte, k int, n int, scLs []*crypto.Scalar, l []byte) *crypto.Scalar {
+	zero := new(crypto.Scalar).FromUint64(0)
+	one := new(crypto.Scalar).FromUint64(1)
 
-	a := make([]*big.Int, len(scLs))
-	for i:=0; i< len(scLs); i++ {
-		a[i] = crypto.ScalarToBigInt(scLs[i])
+	// res[d] is coefficient of x^d
+	res := make([]*crypto.Scalar, n+1)
+	res[0] = new(crypto.Scalar).Set(one)
+	for d := 1; d <= n; d++ {
+		res[d] = new(crypto.Scalar).Set(zero)
 	}
 
-	//AP2
-	curveOrder := crypto.LInt
-	res := crypto.Poly{big.NewInt(1)}
-	var fji crypto.Poly
 	for j := n - 1; j >= 0; j-- {
-		fj := crypto.Poly{a[j], big.NewInt(int64(l[j]))}
+		// fj = a[j] + l[j]*x, fji = fj if iBinary[j] == 1, otherwise x - fj
+		c0 := new(crypto.Scalar).Set(scLs[j])
+		c1 := new(crypto.Scalar).FromUint64(uint64(l[j]))
 		if iBinary[j] == 0 {
-			fji = crypto.Poly{big.NewInt(0), big.NewInt(1)}.Sub(fj, curveOrder)
-		} else {
-			fji = fj
+			c0.Sub(zero, c0)
+			c1.Sub(one, c1)
+		}
+
+		// res = res * (c0 + c1*x)
+		for d := n; d >= 1; d-- {
+			res[d].Mul(res[d], c0)
+			res[d].MulAdd(res[d-1], c1, res[d])
 		}
-		res = res.Mul(fji, curveOrder)
+		res[0].Mul(res[0], c0)
 	}
 
-	var sc2 *crypto.Scalar
-	if res.GetDegree() < k {
-		sc2 = new(crypto.Scalar).FromUint64(0)
-	} else {
-		sc2 = crypto.BigIntToScalar(res[k])
+	if k < 0 || k > n {
+		return new(crypto.Scalar).FromUint64(0)
 	}
-	return sc2
+	return res[k]
 }
 
 func getCoefficientInt(iBinary []byte, k int, n int, a []*big.Int, l []byte) *big.Int {
@@ -477,3 +492,91 @@ func getCoefficientInt(iBinary []byte, k int, n int, a []*big.Int, l []byte) *bi
 	return res[k]
 }
 
+
+// VerifyBatchingOneOutOfManyProofs verifies many proofs at once
+// all statements of all proofs are combined with random weights into one multi-scalar multiplication
+// Statement.Commitments of each proof must be set before verifying
+// it returns the index of the first malformed proof, or -1 if the batch is well-formed but invalid
+func VerifyBatchingOneOutOfManyProofs(proofs []*OneOutOfManyProof) (bool, error, int) {
+	n := crypto.CommitmentRingSizeExp
+	N := crypto.CommitmentRingSize
+
+	points := make([]*crypto.Point, 0)
+	scalars := make([]*crypto.Scalar, 0)
+	// sum of scalars of generators G[PedersenPrivateKeyIndex] and G[PedersenRandomnessIndex]
+	scalarG := new(crypto.Scalar).FromUint64(0)
+	scalarH := new(crypto.Scalar).FromUint64(0)
+
+	for k, proof := range proofs {
+		if proof == nil || proof.Statement == nil || len(proof.Statement.Commitments) != N {
+			return false, errors.New("Invalid length of commitments list in one out of many proof"), k
+		}
+		if len(proof.cl) != n || len(proof.ca) != n || len(proof.cb) != n || len(proof.cd) != n ||
+			len(proof.f) != n || len(proof.za) != n || len(proof.zb) != n || proof.zd == nil {
+			return false, errors.New("Invalid one out of many proof"), k
+		}
+
+		//Calculate x
+		cmtsInBytes := make([][]byte, 0)
+		for _, cmts := range proof.Statement.Commitments {
+			cmtsInBytes = append(cmtsInBytes, cmts.ToBytesS())
+		}
+		x := utils.GenerateChallenge(cmtsInBytes)
+		for j := 0; j < n; j++ {
+			x = utils.GenerateChallenge([][]byte{x.ToBytesS(), proof.cl[j].ToBytesS(), proof.ca[j].ToBytesS(), proof.cb[j].ToBytesS(), proof.cd[j].ToBytesS()})
+		}
+
+		for i := 0; i < n; i++ {
+			// w1 * (cl^x * ca - Com(f, za)) = 0
+			w1 := crypto.RandomScalar()
+			points = append(points, proof.cl[i], proof.ca[i])
+			scalars = append(scalars, new(crypto.Scalar).Mul(w1, x), w1)
+			scalarG.Sub(scalarG, new(crypto.Scalar).Mul(w1, proof.f[i]))
+			scalarH.Sub(scalarH, new(crypto.Scalar).Mul(w1, proof.za[i]))
+
+			// w2 * (cl^(x-f) * cb - Com(0, zb)) = 0
+			w2 := crypto.RandomScalar()
+			xSubF := new(crypto.Scalar).Sub(x, proof.f[i])
+			points = append(points, proof.cl[i], proof.cb[i])
+			scalars = append(scalars, new(crypto.Scalar).Mul(w2, xSubF), w2)
+			scalarH.Sub(scalarH, new(crypto.Scalar).Mul(w2, proof.zb[i]))
+		}
+
+		// w3 * (prod C_i^(prod f_j,i) * prod cd_k^(-x^k) - Com(0, zd)) = 0
+		w3 := crypto.RandomScalar()
+		for i := 0; i < N; i++ {
+			iBinary := crypto.ConvertIntToBinary(i, n)
+			exp := new(crypto.Scalar).Set(w3)
+			fji := new(crypto.Scalar).FromUint64(1)
+			for j := 0; j < n; j++ {
+				if iBinary[j] == 1 {
+					fji.Set(proof.f[j])
+				} else {
+					fji.Sub(x, proof.f[j])
+				}
+				exp.Mul(exp, fji)
+			}
+			points = append(points, proof.Statement.Commitments[i])
+			scalars = append(scalars, exp)
+		}
+		tmp := new(crypto.Scalar).Set(w3)
+		for j := 0; j < n; j++ {
+			points = append(points, proof.cd[j])
+			scalars = append(scalars, new(crypto.Scalar).Sub(new(crypto.Scalar).FromUint64(0), tmp))
+			tmp.Mul(tmp, x)
+		}
+		scalarH.Sub(scalarH, new(crypto.Scalar).Mul(w3, proof.zd))
+	}
+
+	if len(proofs) == 0 {
+		return true, nil, -1
+	}
+
+	points = append(points, crypto.PedCom.G[crypto.PedersenPrivateKeyIndex], crypto.PedCom.G[crypto.PedersenRandomnessIndex])
+	scalars = append(scalars, scalarG, scalarH)
+	res := new(crypto.Point).MultiScalarMultVartime(scalars, points)
+	if !res.IsIdentity() {
+		return false, errors.New("verify batch one out of many proofs failed"), -1
+	}
+	return true, nil, -1
+}
@@ -2,7 +2,6 @@ package oneoutofmany
 
 import (
 	"fmt"
-	"github.com/0xkraken/incognito-sdk-golang/common"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
 	"github.com/stretchr/testify/assert"
@@ -17,19 +16,13 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
-var _ = func() (_ struct{}) {
-	fmt.Println("This runs before init()!")
-	crypto.Logger.Init(common.NewBackend(nil).Logger("test", true))
-	return
-}()
-
 //TestPKOneOfMany test protocol for one of many Commitment is Commitment to zero
 func TestPKOneOfMany(t *testing.T) {
 	// prepare witness for Out out of many protocol
 	for i := 0; i < 10; i++ {
 		witness := new(OneOutOfManyWitness)
 
-		//indexIsZero := int(common.RandInt() % crypto.CommitmentRingSize)
+		//indexIsZero := int(rand.Int() % crypto.CommitmentRingSize)
 		indexIsZero := 0
 
 		// list of commitments
@@ -90,3 +83,66 @@ func TestPKOneOfMany(t *testing.T) {
 	}
 }
 
+
+func TestVerifyBatchingOneOutOfManyProofs(t *testing.T) {
+	proofs := make([]*OneOutOfManyProof, 4)
+	for k := range proofs {
+		indexIsZero := k % crypto.CommitmentRingSize
+		commitments := make([]*crypto.Point, crypto.CommitmentRingSize)
+		randoms := make([]*crypto.Scalar, crypto.CommitmentRingSize)
+		for i := 0; i < crypto.CommitmentRingSize; i++ {
+			randoms[i] = crypto.RandomScalar()
+			commitments[i] = crypto.PedCom.CommitAtIndex(crypto.RandomScalar(), randoms[i], crypto.PedersenSndIndex)
+		}
+		commitments[indexIsZero] = crypto.PedCom.CommitAtIndex(new(crypto.Scalar).FromUint64(0), randoms[indexIsZero], crypto.PedersenSndIndex)
+
+		witness := new(OneOutOfManyWitness)
+		witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+		proof, err := witness.Prove()
+		assert.Equal(t, nil, err)
+		proofs[k] = proof
+	}
+
+	res, err, _ := VerifyBatchingOneOutOfManyProofs(proofs)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	res, err, _ = VerifyBatchingOneOutOfManyProofs(nil)
+	assert.Equal(t, true, res)
+
+	// proof for other commitments list
+	proofs[2].Statement.Commitments[0] = crypto.RandomPoint()
+	res, err, k := VerifyBatchingOneOutOfManyProofs(proofs)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, -1, k)
+
+	proofs[1].Statement.Commitments = proofs[1].Statement.Commitments[1:]
+	res, _, k = VerifyBatchingOneOutOfManyProofs(proofs)
+	assert.Equal(t, false, res)
+	assert.Equal(t, 1, k)
+}
+
+func FuzzOneOutOfManyProofSetBytes(f *testing.F) {
+	commitments := make([]*crypto.Point, crypto.CommitmentRingSize)
+	randoms := make([]*crypto.Scalar, crypto.CommitmentRingSize)
+	for i := 0; i < crypto.CommitmentRingSize; i++ {
+		randoms[i] = crypto.RandomScalar()
+		commitments[i] = crypto.PedCom.CommitAtIndex(crypto.RandomScalar(), randoms[i], crypto.PedersenSndIndex)
+	}
+	commitments[0] = crypto.PedCom.CommitAtIndex(new(crypto.Scalar).FromUint64(0), randoms[0], crypto.PedersenSndIndex)
+	witness := new(OneOutOfManyWitness)
+	witness.Set(commitments, randoms[0], 0)
+	proof, err := witness.Prove()
+	assert.Equal(f, nil, err)
+	f.Add(proof.Bytes())
+	f.Add(proof.Bytes()[:utils.OneOfManyProofSize-1])
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		proof := new(OneOutOfManyProof).Init()
+		if proof.SetBytes(data) != nil {
+			return
+		}
+		assert.Equal(t, data, proof.Bytes())
+	})
+}
@@ -309,6 +309,10 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *crypto.PrivacyError {
 		offset += 2
 		proof.oneOfManyProof[i] = new(oneoutofmany.OneOutOfManyProof).Init()
 
+		// SetBytes of one out of many proof accepts an empty array
+		if lenOneOfManyProof == 0 {
+			return crypto.NewPrivacyErr(crypto.SetBytesProofErr, errors.New("Empty one out of many proof"))
+		}
 		if offset+lenOneOfManyProof > len(proofbytes) {
 			return crypto.NewPrivacyErr(crypto.SetBytesProofErr, errors.New("Out of range one out of many proof"))
 		}
@@ -387,6 +391,8 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *crypto.PrivacyError {
 			return crypto.NewPrivacyErr(crypto.SetBytesProofErr, err)
 		}
 		offset += lenComOutputMultiRangeProof
+	} else if lenOneOfManyProofArray > 0 {
+		return crypto.NewPrivacyErr(crypto.SetBytesProofErr, errors.New("Missing aggregated range proof of proof with privacy"))
 	}
 
 	//InputCoins  []*crypto.InputCoin
@@ -4,6 +4,7 @@ import (
 	"github.com/0xkraken/incognito-sdk-golang/common/base58"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/0xkraken/incognito-sdk-golang/wallet"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -173,4 +174,26 @@ func TestPaymentProofToBytes(t *testing.T){
 	//witness.Init()
 
 
-}
\ No newline at end of file
+}
+
+func FuzzPaymentProofSetBytes(f *testing.F) {
+	for _, hasPrivacy := range []bool{true, false} {
+		param := newTestPaymentWitnessParam(2, crypto.NewDRBG([]byte("fuzz payment proof")))
+		param.HasPrivacy = hasPrivacy
+		proof := proveTestPayment(f, param, 1)
+		f.Add(proof.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		proof := new(PaymentProof)
+		if proof.SetBytes(data) != nil {
+			return
+		}
+
+		// bytes of a parsed proof are parsed to the same proof
+		proofBytes := proof.Bytes()
+		proof2 := new(PaymentProof)
+		assert.Equal(t, (*crypto.PrivacyError)(nil), proof2.SetBytes(proofBytes))
+		assert.Equal(t, proofBytes, proof2.Bytes())
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/oneoutofmany"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/serialnumbernoprivacy"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/serialnumberprivacy"
+	"io"
+	"runtime"
+	"sync"
 )
 
 // PaymentWitness contains all of witness for proving when spending coins
@@ -34,6 +37,9 @@ type PaymentWitness struct {
 	comInputShardID               *crypto.Point
 
 	randSecretKey *crypto.Scalar
+
+	rand           io.Reader
+	provingWorkers int
 }
 
 func (paymentWitness PaymentWitness) GetRandSecretKey() *crypto.Scalar {
@@ -50,6 +56,12 @@ type PaymentWitnessParam struct {
 	CommitmentIndices       []uint64
 	MyCommitmentIndices     []uint64
 	Fee                     uint64
+	// Rand is the source of all random numbers of witness and proofs, crypto/rand is used if it is nil
+	// proofs are the same for the same params and the same sequence of bytes of Rand, even if they are created concurrently
+	Rand io.Reader `json:"-"`
+	// ProvingWorkers is the max number of goroutines that create proofs of input coins and output values concurrently
+	// in PaymentWitness.Prove, runtime.NumCPU() is used if it is 0, proofs are created one after another if it is 1
+	ProvingWorkers int
 }
 
 // Build prepares witnesses for all protocol need to be proved when create tx
@@ -66,10 +78,15 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *crypto
 	commitmentIndices := PaymentWitnessParam.CommitmentIndices
 	myCommitmentIndices := PaymentWitnessParam.MyCommitmentIndices
 	_ = PaymentWitnessParam.Fee
+	wit.rand = PaymentWitnessParam.Rand
+	wit.provingWorkers = PaymentWitnessParam.ProvingWorkers
+	if wit.provingWorkers == 0 {
+		wit.provingWorkers = runtime.NumCPU()
+	}
 
 	if !hasPrivacy {
 		for _, outCoin := range outputCoins {
-			outCoin.CoinDetails.SetRandomness(crypto.RandomScalar())
+			outCoin.CoinDetails.SetRandomness(crypto.RandomScalarFromReader(wit.rand))
 			err := outCoin.CoinDetails.CommitAll()
 			if err != nil {
 				return crypto.NewPrivacyErr(crypto.CommitNewOutputCoinNoPrivacyErr, nil)
@@ -104,14 +121,14 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *crypto
 	numInputCoin := len(wit.inputCoins)
 	numOutputCoin := len(wit.outputCoins)
 
-	randInputSK := crypto.RandomScalar()
+	randInputSK := crypto.RandomScalarFromReader(wit.rand)
 	// set rand sk for Schnorr signature
 	wit.randSecretKey = new(crypto.Scalar).Set(randInputSK)
 
 	cmInputSK := crypto.PedCom.CommitAtIndex(wit.privateKey, randInputSK, crypto.PedersenPrivateKeyIndex)
 	wit.comInputSecretKey = new(crypto.Point).Set(cmInputSK)
 
-	//randInputShardID := crypto.RandomScalar()
+	//randInputShardID := crypto.RandomScalarFromReader(wit.rand)
 	randInputShardID := crypto.FixedRandomnessShardID
 	senderShardID := common.GetShardIDFromLastByte(publicKeyLastByteSender)
 	wit.comInputShardID = crypto.PedCom.CommitAtIndex(new(crypto.Scalar).FromUint64(uint64(senderShardID)), randInputShardID, crypto.PedersenShardIDIndex)
@@ -148,10 +165,10 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *crypto
 		if numOutputCoin == 0 {
 			randInputValue[i] = new(crypto.Scalar).FromUint64(0)
 		} else {
-			randInputValue[i] = crypto.RandomScalar()
+			randInputValue[i] = crypto.RandomScalarFromReader(wit.rand)
 		}
 		// commit each component of coin commitment
-		randInputSND[i] = crypto.RandomScalar()
+		randInputSND[i] = crypto.RandomScalarFromReader(wit.rand)
 
 		wit.comInputValue[i] = crypto.PedCom.CommitAtIndex(new(crypto.Scalar).FromUint64(inputCoin.CoinDetails.GetValue()), randInputValue[i], crypto.PedersenValueIndex)
 		wit.comInputSerialNumberDerivator[i] = crypto.PedCom.CommitAtIndex(inputCoin.CoinDetails.GetSNDerivator(), randInputSND[i], crypto.PedersenSndIndex)
@@ -224,11 +241,11 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *crypto
 		if i == len(outputCoins)-1 {
 			randOutputValue[i] = new(crypto.Scalar).Sub(randInputValueAll, randOutputValueAll)
 		} else {
-			randOutputValue[i] = crypto.RandomScalar()
+			randOutputValue[i] = crypto.RandomScalarFromReader(wit.rand)
 		}
 
-		randOutputSND[i] = crypto.RandomScalar()
-		randOutputShardID[i] = crypto.RandomScalar()
+		randOutputSND[i] = crypto.RandomScalarFromReader(wit.rand)
+		randOutputShardID[i] = crypto.RandomScalarFromReader(wit.rand)
 
 		cmOutputValue[i] = crypto.PedCom.CommitAtIndex(new(crypto.Scalar).FromUint64(outputCoin.CoinDetails.GetValue()), randOutputValue[i], crypto.PedersenValueIndex)
 		cmOutputSND[i] = crypto.PedCom.CommitAtIndex(outputCoin.CoinDetails.GetSNDerivator(), randOutputSND[i], crypto.PedersenSndIndex)
@@ -302,40 +319,58 @@ func (wit *PaymentWitness) Prove(hasPrivacy bool) (*PaymentProof, *crypto.Privac
 	// is proved by signing with spending key
 	if !hasPrivacy {
 		// Proving that serial number is derived from the committed derivator
-		for i := 0; i < len(wit.inputCoins); i++ {
-			snNoPrivacyProof, err := wit.serialNumberNoPrivacyWitness[i].Prove(nil)
+		proof.serialNumberNoPrivacyProof = make([]*serialnumbernoprivacy.SNNoPrivacyProof, len(wit.inputCoins))
+		taskRands := wit.newTaskRands(len(wit.inputCoins))
+		err := proveConcurrently(len(wit.inputCoins), wit.provingWorkers, func(i int) *crypto.PrivacyError {
+			snNoPrivacyProof, err := wit.serialNumberNoPrivacyWitness[i].ProveWithRandomness(nil, taskRands[i])
 			if err != nil {
-				return nil, crypto.NewPrivacyErr(crypto.ProveSerialNumberNoPrivacyErr, err)
+				return crypto.NewPrivacyErr(crypto.ProveSerialNumberNoPrivacyErr, err)
 			}
-			proof.serialNumberNoPrivacyProof = append(proof.serialNumberNoPrivacyProof, snNoPrivacyProof)
+			proof.serialNumberNoPrivacyProof[i] = snNoPrivacyProof
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 		return proof, nil
 	}
 
 	// if hasPrivacy == true
+	// proofs of each input coin and the aggregated range proof are independent,
+	// task i < numInputCoins proves input coin i, the last task proves output values
 	numInputCoins := len(wit.oneOfManyWitness)
+	proof.oneOfManyProof = make([]*oneoutofmany.OneOutOfManyProof, numInputCoins)
+	proof.serialNumberProof = make([]*serialnumberprivacy.SNPrivacyProof, numInputCoins)
+
+	taskRands := wit.newTaskRands(numInputCoins + 1)
+	err := proveConcurrently(numInputCoins+1, wit.provingWorkers, func(i int) *crypto.PrivacyError {
+		if i == numInputCoins {
+			// Proving that each output values and sum of them does not exceed v_max
+			aggregatedRangeProof, err := wit.aggregatedRangeWitness.ProveWithRandomness(taskRands[i])
+			if err != nil {
+				return crypto.NewPrivacyErr(crypto.ProveAggregatedRangeErr, err)
+			}
+			proof.aggregatedRangeProof = aggregatedRangeProof
+			return nil
+		}
 
-	for i := 0; i < numInputCoins; i++ {
 		// Proving one-out-of-N commitments is a commitment to the coins being spent
-		oneOfManyProof, err := wit.oneOfManyWitness[i].Prove()
+		oneOfManyProof, err := wit.oneOfManyWitness[i].ProveWithRandomness(taskRands[i])
 		if err != nil {
-			return nil, crypto.NewPrivacyErr(crypto.ProveOneOutOfManyErr, err)
+			return crypto.NewPrivacyErr(crypto.ProveOneOutOfManyErr, err)
 		}
-		proof.oneOfManyProof = append(proof.oneOfManyProof, oneOfManyProof)
+		proof.oneOfManyProof[i] = oneOfManyProof
 
 		// Proving that serial number is derived from the committed derivator
-		serialNumberProof, err := wit.serialNumberWitness[i].Prove(nil)
+		serialNumberProof, err := wit.serialNumberWitness[i].ProveWithRandomness(nil, taskRands[i])
 		if err != nil {
-			return nil, crypto.NewPrivacyErr(crypto.ProveSerialNumberPrivacyErr, err)
+			return crypto.NewPrivacyErr(crypto.ProveSerialNumberPrivacyErr, err)
 		}
-		proof.serialNumberProof = append(proof.serialNumberProof, serialNumberProof)
-	}
-	var err error
-
-	// Proving that each output values and sum of them does not exceed v_max
-	proof.aggregatedRangeProof, err = wit.aggregatedRangeWitness.Prove()
+		proof.serialNumberProof[i] = serialNumberProof
+		return nil
+	})
 	if err != nil {
-		return nil, crypto.NewPrivacyErr(crypto.ProveAggregatedRangeErr, err)
+		return nil, err
 	}
 
 	if len(proof.inputCoins) == 0 {
@@ -354,4 +389,58 @@ func (wit *PaymentWitness) Prove(hasPrivacy bool) (*PaymentProof, *crypto.Privac
 
 	//crypto.Logger.Log.Debug("Privacy log: PROVING DONE!!!")
 	return proof, nil
-}
\ No newline at end of file
+}
+
+// newTaskRands returns a source of randomness for each proving task, they are seeded from wit.rand in order of tasks
+// so that proofs do not depend on the order in which tasks are run
+// sources are nil (crypto/rand) if wit.rand is nil
+func (wit *PaymentWitness) newTaskRands(numTasks int) []io.Reader {
+	taskRands := make([]io.Reader, numTasks)
+	for i := range taskRands {
+		taskRands[i] = crypto.NewDRBGFromReader(wit.rand)
+	}
+	return taskRands
+}
+
+// proveConcurrently calls prove(i) for each task i in [0, numTasks) by at most numWorkers goroutines
+// tasks are run one after another if numWorkers is less than 2
+// it returns the error of the task with the smallest index, the same as running tasks in order
+func proveConcurrently(numTasks int, numWorkers int, prove func(i int) *crypto.PrivacyError) *crypto.PrivacyError {
+	if numWorkers > numTasks {
+		numWorkers = numTasks
+	}
+	if numWorkers < 2 {
+		for i := 0; i < numTasks; i++ {
+			err := prove(i)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]*crypto.PrivacyError, numTasks)
+	tasks := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range tasks {
+				errs[i] = prove(i)
+			}
+		}()
+	}
+	for i := 0; i < numTasks; i++ {
+		tasks <- i
+	}
+	close(tasks)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
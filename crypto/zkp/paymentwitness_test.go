@@ -0,0 +1,183 @@
+package zkp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPaymentWitnessParam returns param of privacy payment spending numInputs coins of a random key,
+// ring of input coin i is commitments at indices [8i, 8i+8)
+// key, coins and commitments are read from rand, so param is the same for the same rand
+func newTestPaymentWitnessParam(numInputs int, rand io.Reader) *PaymentWitnessParam {
+	privateKey := crypto.GeneratePrivateKey(crypto.RandBytesFromReader(rand, 32))
+	sk := new(crypto.Scalar).FromBytesS(privateKey)
+	paymentAddress := crypto.GeneratePaymentAddress(privateKey)
+	pk, _ := new(crypto.Point).FromBytesS(paymentAddress.Pk)
+
+	param := &PaymentWitnessParam{
+		HasPrivacy:              true,
+		PrivateKey:              sk,
+		PublicKeyLastByteSender: paymentAddress.Pk[len(paymentAddress.Pk)-1],
+		Fee:                     10,
+	}
+	sumValue := uint64(0)
+	for i := 0; i < numInputs; i++ {
+		coin := new(crypto.InputCoin).Init()
+		coin.CoinDetails.SetPublicKey(pk)
+		coin.CoinDetails.SetValue(uint64(1000 + i))
+		coin.CoinDetails.SetSNDerivator(crypto.RandomScalarFromReader(rand))
+		coin.CoinDetails.SetRandomness(crypto.RandomScalarFromReader(rand))
+		coin.CoinDetails.SetSerialNumber(new(crypto.Point).Derive(crypto.PedCom.G[crypto.PedersenPrivateKeyIndex], sk, coin.CoinDetails.GetSNDerivator()))
+		_ = coin.CoinDetails.CommitAll()
+		param.InputCoins = append(param.InputCoins, coin)
+		sumValue += coin.CoinDetails.GetValue()
+
+		myIndex := uint64(i*crypto.CommitmentRingSize + i%crypto.CommitmentRingSize)
+		for j := 0; j < crypto.CommitmentRingSize; j++ {
+			cmIndex := uint64(i*crypto.CommitmentRingSize + j)
+			commitment := crypto.RandomPointFromReader(rand)
+			if cmIndex == myIndex {
+				commitment = coin.CoinDetails.GetCoinCommitment()
+			}
+			param.Commitments = append(param.Commitments, commitment)
+			param.CommitmentIndices = append(param.CommitmentIndices, cmIndex)
+		}
+		param.MyCommitmentIndices = append(param.MyCommitmentIndices, myIndex)
+	}
+
+	for _, value := range []uint64{sumValue - param.Fee - 100, 100} {
+		coin := new(crypto.OutputCoin).Init()
+		coin.CoinDetails.SetPublicKey(pk)
+		coin.CoinDetails.SetValue(value)
+		coin.CoinDetails.SetSNDerivator(crypto.RandomScalarFromReader(rand))
+		param.OutputCoins = append(param.OutputCoins, coin)
+	}
+	return param
+}
+
+func proveTestPayment(t testing.TB, param *PaymentWitnessParam, numWorkers int) *PaymentProof {
+	workersParam := *param
+	workersParam.ProvingWorkers = numWorkers
+
+	witness := new(PaymentWitness)
+	err := witness.Init(workersParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := witness.Prove(param.HasPrivacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+func TestPaymentWitnessProveConcurrently(t *testing.T) {
+	param := newTestPaymentWitnessParam(5, nil)
+	for _, numWorkers := range []int{1, 2, 16} {
+		proof := proveTestPayment(t, param, numWorkers)
+		assert.Equal(t, len(param.InputCoins), len(proof.GetOneOfManyProof()))
+		assert.Equal(t, len(param.InputCoins), len(proof.GetSerialNumberProof()))
+
+		for i, oneOfManyProof := range proof.GetOneOfManyProof() {
+			// proofs of input coin i are at index i
+			snProof := proof.GetSerialNumberProof()[i]
+			assert.Equal(t, param.InputCoins[i].CoinDetails.GetSerialNumber().ToBytesS(), snProof.Bytes()[:crypto.Ed25519KeySize])
+			valid, err := snProof.Verify(nil)
+			assert.Equal(t, true, valid)
+			assert.Equal(t, nil, err)
+
+			valid, err = oneOfManyProof.Verify()
+			assert.Equal(t, true, valid, err)
+		}
+		valid, err := proof.GetAggregatedRangeProof().Verify()
+		assert.Equal(t, true, valid, err)
+
+		// proof is serialized the same as proof created one after another
+		proof2 := new(PaymentProof)
+		assert.Nil(t, proof2.SetBytes(proof.Bytes()))
+		assert.Equal(t, proof.Bytes(), proof2.Bytes())
+	}
+
+	// no privacy
+	param.HasPrivacy = false
+	for _, numWorkers := range []int{1, 16} {
+		proof := proveTestPayment(t, param, numWorkers)
+		assert.Equal(t, len(param.InputCoins), len(proof.GetSerialNumberNoPrivacyProof()))
+		for _, snProof := range proof.GetSerialNumberNoPrivacyProof() {
+			valid, err := snProof.Verify(nil)
+			assert.Equal(t, true, valid)
+			assert.Equal(t, nil, err)
+		}
+	}
+}
+
+func TestPaymentWitnessProveGolden(t *testing.T) {
+	testcases := []struct {
+		hasPrivacy bool
+		proofHash  string // SHA256 of proof bytes
+	}{
+		{true, "238a82b153d6b15c0f9a21b34de467ff04fd0f0d5cca143e6118b1e7c474b3bc"},
+		{false, "79475e325f0269123c7a410b49d892ce48ca671cacfeae60e391fd35a50383af"},
+	}
+	for _, tc := range testcases {
+		param := newTestPaymentWitnessParam(3, crypto.NewDRBG([]byte("payment witness param")))
+		param.HasPrivacy = tc.hasPrivacy
+
+		// proofs are the same for any number of workers
+		for _, numWorkers := range []int{1, 4} {
+			param.Rand = crypto.NewDRBG([]byte("payment proof"))
+			proof := proveTestPayment(t, param, numWorkers)
+			proofHash := sha256.Sum256(proof.Bytes())
+			assert.Equal(t, tc.proofHash, hex.EncodeToString(proofHash[:]), "workers %v", numWorkers)
+		}
+	}
+}
+
+func TestProveConcurrently(t *testing.T) {
+	for _, numWorkers := range []int{0, 1, 3, 100} {
+		done := make([]int32, 10)
+		err := proveConcurrently(len(done), numWorkers, func(i int) *crypto.PrivacyError {
+			atomic.AddInt32(&done[i], 1)
+			return nil
+		})
+		assert.Equal(t, (*crypto.PrivacyError)(nil), err)
+		for _, d := range done {
+			assert.Equal(t, int32(1), d)
+		}
+
+		// error of the smallest task is returned
+		err = proveConcurrently(10, numWorkers, func(i int) *crypto.PrivacyError {
+			if i == 4 || i == 7 {
+				return crypto.NewPrivacyErr(crypto.ProveOneOutOfManyErr, fmt.Errorf("task %v", i))
+			}
+			return nil
+		})
+		assert.Contains(t, err.Error(), "task 4")
+	}
+
+	err := proveConcurrently(0, 4, func(i int) *crypto.PrivacyError {
+		return crypto.NewPrivacyErr(crypto.UnexpectedErr, errors.New("no task"))
+	})
+	assert.Equal(t, (*crypto.PrivacyError)(nil), err)
+}
+
+func BenchmarkPaymentWitnessProve(b *testing.B) {
+	for _, numInputs := range []int{1, 8, 32} {
+		param := newTestPaymentWitnessParam(numInputs, nil)
+		for _, numWorkers := range []int{1, 4} {
+			b.Run(fmt.Sprintf("inputs=%v/workers=%v", numInputs, numWorkers), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					proveTestPayment(b, param, numWorkers)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,443 @@
+// Package reserves proves that an account holds at least an amount of unspent coins without revealing the balance,
+// the values of its coins or which coins on chain are its coins, e.g. for an exchange to show its reserves to auditors
+//
+// the commitment of a coin is PK * G[value]^value * G[snd]^snd * G[shardID]^shardID * H^randomness, PK = G[sk]^sk.
+// like input coins of payment proofs, each coin is hidden in a ring of coin commitments on chain,
+// the proof contains
+//   - a commitment to the private key comSK, and commitments to the value and the snd of each coin
+//   - a one-out-of-many proof of each coin that one commitment of its ring is the sum of these commitments
+//     and of G[shardID]^shardID, up to a power of H
+//   - the serial number of each coin and a serial number privacy proof that it is derived from
+//     the private key and the snd committed in comSK and the commitment to snd, as for input coins of payment proofs
+//   - a range proof that the sum of the values of the coins minus the amount is in [0, 2^64)
+//   - a Schnorr signature of the proof and its context by the key of comSK, which proves the ownership of the coins
+//
+// the verifier checks that the serial numbers are not on chain (e.g. by RPC hasserialnumbers) to know that the coins
+// are unspent. serial numbers are revealed to the verifier, it can link them to the txs that spend the coins later
+package reserves
+
+import (
+	"errors"
+	"fmt"
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/aggregaterange"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/oneoutofmany"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/serialnumberprivacy"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/toolkit"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
+	"io"
+)
+
+// size of a Schnorr signature by a key with randomness: e, z1, z2
+const signatureSize = 3 * crypto.Ed25519KeySize
+
+// size of a coin in a proof of reserves: commitment indices + commitment to value + proofs
+const reserveCoinSize = crypto.CommitmentRingSize*common.Uint64Size + crypto.Ed25519KeySize + utils.OneOfManyProofSize + utils.SnPrivacyProofSize
+
+// GetCommitmentFn returns the coin commitment at index cmIndex of shard shardID in the token of the proof
+type GetCommitmentFn func(cmIndex uint64, shardID byte) (*crypto.Point, error)
+
+// ReserveCoin is a coin of a proof of reserves, it reveals the serial number of the coin, not the coin
+// the commitment to snd of the coin is the commitment to input of its serial number proof
+type ReserveCoin struct {
+	commitmentIndices []uint64 // indices of the ring of coin commitments that contains the coin
+	comValue          *crypto.Point
+	oneOfManyProof    *oneoutofmany.OneOutOfManyProof
+	serialNumberProof *serialnumberprivacy.SNPrivacyProof
+}
+
+// GetCommitmentIndices returns the indices of the ring of coin commitments that contains the coin
+func (coin ReserveCoin) GetCommitmentIndices() []uint64 {
+	return coin.commitmentIndices
+}
+
+// GetSerialNumber returns the serial number of the coin, the coin is unspent if it is not on chain
+func (coin ReserveCoin) GetSerialNumber() *crypto.Point {
+	return coin.serialNumberProof.GetSerialNumber()
+}
+
+// ReservesWitness includes Witness: private key and unspent coins of an account, and the rings of the coins
+type ReservesWitness struct {
+	privateKey        *crypto.Scalar
+	coins             []*crypto.OutputCoin
+	minAmount         uint64
+	commitments       []*crypto.Point
+	commitmentIndices []uint64
+}
+
+// ReservesProof proves that the sum of the values of coins is at least minAmount
+type ReservesProof struct {
+	minAmount  uint64
+	shardID    byte
+	comSK      *crypto.Point
+	coins      []*ReserveCoin
+	signature  *crypto.SchnSignature
+	rangeProof *aggregaterange.AggregatedRangeProof
+}
+
+func (proof ReservesProof) GetMinAmount() uint64 {
+	return proof.minAmount
+}
+
+// GetShardID returns the shard of the account, the rings of coins are commitments of this shard
+func (proof ReservesProof) GetShardID() byte {
+	return proof.shardID
+}
+
+func (proof ReservesProof) GetCoins() []*ReserveCoin {
+	return proof.coins
+}
+
+// GetSerialNumbers returns the serial numbers of coins in order, the verifier checks that they are not on chain
+func (proof ReservesProof) GetSerialNumbers() []*crypto.Point {
+	serialNumbers := make([]*crypto.Point, len(proof.coins))
+	for i, coin := range proof.coins {
+		serialNumbers[i] = coin.GetSerialNumber()
+	}
+	return serialNumbers
+}
+
+func (proof ReservesProof) isNil() bool {
+	if len(proof.coins) == 0 || proof.comSK == nil || proof.signature == nil || proof.rangeProof == nil || proof.rangeProof.IsNil() {
+		return true
+	}
+	for _, coin := range proof.coins {
+		if coin == nil || len(coin.commitmentIndices) != crypto.CommitmentRingSize || coin.comValue == nil ||
+			coin.oneOfManyProof == nil || coin.serialNumberProof == nil || len(coin.serialNumberProof.Bytes()) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Set sets Witness
+// commitments are the rings of coins, CommitmentRingSize commitments of the shard of the account for each coin in order,
+// at commitmentIndices on chain. the ring of a coin contains its commitment, the others must be random coin commitments
+// of the same token, like the rings of input coins of txs
+func (wit *ReservesWitness) Set(privateKey *crypto.Scalar, coins []*crypto.OutputCoin, minAmount uint64,
+	commitments []*crypto.Point, commitmentIndices []uint64) {
+	wit.privateKey = privateKey
+	wit.coins = coins
+	wit.minAmount = minAmount
+	wit.commitments = commitments
+	wit.commitmentIndices = commitmentIndices
+}
+
+// bytes returns commitment indices || comValue || one out of many proof || serial number proof
+func (coin ReserveCoin) bytes() []byte {
+	var bytes []byte
+	for _, cmIndex := range coin.commitmentIndices {
+		bytes = append(bytes, common.Uint64ToBytes(cmIndex)...)
+	}
+	bytes = append(bytes, coin.comValue.ToBytesS()...)
+	bytes = append(bytes, coin.oneOfManyProof.Bytes()...)
+	bytes = append(bytes, coin.serialNumberProof.Bytes()...)
+	return bytes
+}
+
+// Bytes returns minAmount || shardID || comSK || number of coins || coins || signature || range proof
+func (proof ReservesProof) Bytes() []byte {
+	// if proof is nil, return an empty array
+	if proof.isNil() {
+		return []byte{}
+	}
+
+	var bytes []byte
+	bytes = append(bytes, common.Uint64ToBytes(proof.minAmount)...)
+	bytes = append(bytes, proof.shardID)
+	bytes = append(bytes, proof.comSK.ToBytesS()...)
+	bytes = append(bytes, common.Uint32ToBytes(uint32(len(proof.coins)))...)
+	for _, coin := range proof.coins {
+		bytes = append(bytes, coin.bytes()...)
+	}
+	bytes = append(bytes, proof.signature.Bytes()...)
+	bytes = append(bytes, proof.rangeProof.Bytes()...)
+	return bytes
+}
+
+func (proof *ReservesProof) SetBytes(bytes []byte) error {
+	if len(bytes) == 0 {
+		return errors.New("Bytes array is empty")
+	}
+	if len(bytes) < common.Uint64Size+1+crypto.Ed25519KeySize+common.Uint32Size {
+		return errors.New("invalid length of reserves proof")
+	}
+
+	var err error
+	offset := 0
+	proof.minAmount, _ = common.BytesToUint64(bytes[offset : offset+common.Uint64Size])
+	offset += common.Uint64Size
+	proof.shardID = bytes[offset]
+	offset++
+	proof.comSK, err = new(crypto.Point).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
+	if err != nil {
+		return err
+	}
+	offset += crypto.Ed25519KeySize
+	numCoins, _ := common.BytesToUint32(bytes[offset : offset+common.Uint32Size])
+	offset += common.Uint32Size
+	if numCoins == 0 || uint64(numCoins)*reserveCoinSize+signatureSize > uint64(len(bytes)-offset) {
+		return errors.New("invalid number of coins of reserves proof")
+	}
+
+	proof.coins = make([]*ReserveCoin, numCoins)
+	for i := range proof.coins {
+		coin := &ReserveCoin{
+			commitmentIndices: make([]uint64, crypto.CommitmentRingSize),
+			oneOfManyProof:    new(oneoutofmany.OneOutOfManyProof).Init(),
+			serialNumberProof: new(serialnumberprivacy.SNPrivacyProof).Init(),
+		}
+		for j := range coin.commitmentIndices {
+			coin.commitmentIndices[j], _ = common.BytesToUint64(bytes[offset : offset+common.Uint64Size])
+			offset += common.Uint64Size
+		}
+		coin.comValue, err = new(crypto.Point).FromBytesS(bytes[offset : offset+crypto.Ed25519KeySize])
+		if err != nil {
+			return err
+		}
+		offset += crypto.Ed25519KeySize
+
+		err = coin.oneOfManyProof.SetBytes(bytes[offset : offset+utils.OneOfManyProofSize])
+		if err != nil {
+			return err
+		}
+		offset += utils.OneOfManyProofSize
+
+		err = coin.serialNumberProof.SetBytes(bytes[offset : offset+utils.SnPrivacyProofSize])
+		if err != nil {
+			return err
+		}
+		offset += utils.SnPrivacyProofSize
+		proof.coins[i] = coin
+	}
+
+	proof.signature = new(crypto.SchnSignature)
+	err = proof.signature.SetBytes(bytes[offset : offset+signatureSize])
+	if err != nil {
+		return err
+	}
+	offset += signatureSize
+
+	// SetBytes of range proof accepts an empty array
+	if offset == len(bytes) {
+		return errors.New("range proof of reserves proof is missing")
+	}
+	proof.rangeProof = new(aggregaterange.AggregatedRangeProof)
+	return proof.rangeProof.SetBytes(bytes[offset:])
+}
+
+// valueCommitment removes public key, snd and shardID from coinCommitment
+// it returns G[value]^value * H^randomness
+func valueCommitment(coinCommitment *crypto.Point, publicKey *crypto.Point, snd *crypto.Scalar) *crypto.Point {
+	shardID := common.GetShardIDFromLastByte(publicKey.ToBytesS()[crypto.Ed25519KeySize-1])
+	others := new(crypto.Point).MultiScalarMultVartime(
+		[]*crypto.Scalar{snd, new(crypto.Scalar).FromUint64(uint64(shardID))},
+		[]*crypto.Point{crypto.PedCom.G[crypto.PedersenSndIndex], crypto.PedCom.G[crypto.PedersenShardIDIndex]})
+	res := new(crypto.Point).Sub(coinCommitment, publicKey)
+	return res.Sub(res, others)
+}
+
+// ringStatement returns the commitments of ring minus comSK, comValue, comSND and G[shardID]^shardID,
+// the commitment of the coin becomes a power of H
+func ringStatement(ring []*crypto.Point, comSK, comValue, comSND *crypto.Point, shardID byte) []*crypto.Point {
+	sum := new(crypto.Point).Add(comSK, comValue)
+	sum.Add(sum, comSND)
+	sum.Add(sum, new(crypto.Point).ScalarMult(crypto.PedCom.G[crypto.PedersenShardIDIndex], new(crypto.Scalar).FromUint64(uint64(shardID))))
+	commitments := make([]*crypto.Point, len(ring))
+	for i, commitment := range ring {
+		commitments[i] = new(crypto.Point).Sub(commitment, sum)
+	}
+	return commitments
+}
+
+// message signed by the key of comSK
+// hash(hash(context) || minAmount || shardID || comSK || coins || range proof)
+func messageToSign(proof *ReservesProof, context []byte) []byte {
+	var msg []byte
+	msg = append(msg, common.HashB(context)...)
+	msg = append(msg, common.Uint64ToBytes(proof.minAmount)...)
+	msg = append(msg, proof.shardID)
+	msg = append(msg, proof.comSK.ToBytesS()...)
+	for _, coin := range proof.coins {
+		msg = append(msg, coin.bytes()...)
+	}
+	msg = append(msg, proof.rangeProof.Bytes()...)
+	return common.HashB(msg)
+}
+
+// Prove proves that the coins of the witness hold at least minAmount
+// context is bound to the proof, e.g. the auditor and the time of the proof, the proof does not verify with another context
+func (wit ReservesWitness) Prove(context []byte) (*ReservesProof, error) {
+	return wit.ProveWithRandomness(context, nil)
+}
+
+// ProveWithRandomness is the same as Prove, but random numbers are read from rand
+func (wit ReservesWitness) ProveWithRandomness(context []byte, rand io.Reader) (*ReservesProof, error) {
+	if wit.privateKey == nil || len(wit.coins) == 0 {
+		return nil, errors.New("no coins to prove")
+	}
+	if len(wit.commitments) != len(wit.coins)*crypto.CommitmentRingSize || len(wit.commitmentIndices) != len(wit.commitments) {
+		return nil, errors.New("number of commitments of rings of coins is invalid")
+	}
+	publicKey := new(crypto.Point).ScalarMultBase(wit.privateKey)
+	shardID := common.GetShardIDFromLastByte(publicKey.ToBytesS()[crypto.Ed25519KeySize-1])
+
+	rSK := crypto.RandomScalarFromReader(rand)
+	proof := &ReservesProof{
+		minAmount: wit.minAmount,
+		shardID:   shardID,
+		comSK:     crypto.PedCom.CommitAtIndex(wit.privateKey, rSK, crypto.PedersenPrivateKeyIndex),
+		coins:     make([]*ReserveCoin, len(wit.coins)),
+	}
+	valueOpenings := make([]*toolkit.Opening, len(wit.coins))
+	for i, outputCoin := range wit.coins {
+		coin := outputCoin.CoinDetails
+		if coin == nil || coin.GetPublicKey() == nil || coin.GetCoinCommitment() == nil || coin.GetSNDerivator() == nil || coin.GetRandomness() == nil {
+			return nil, errors.New("coin details are missing")
+		}
+		if !crypto.IsPointEqual(coin.GetPublicKey(), publicKey) {
+			return nil, errors.New("coin is not owned by the private key")
+		}
+		if !toolkit.CheckOpening(valueCommitment(coin.GetCoinCommitment(), publicKey, coin.GetSNDerivator()),
+			&toolkit.Opening{Value: coin.GetValue(), Blind: coin.GetRandomness()}) {
+			return nil, errors.New("coin commitment does not match coin details")
+		}
+
+		ring := wit.commitments[i*crypto.CommitmentRingSize : (i+1)*crypto.CommitmentRingSize]
+		myIndex := -1
+		for j, commitment := range ring {
+			if crypto.IsPointEqual(commitment, coin.GetCoinCommitment()) {
+				myIndex = j
+				break
+			}
+		}
+		if myIndex < 0 {
+			return nil, fmt.Errorf("ring of coin %v does not contain its commitment", i)
+		}
+
+		valueOpenings[i] = toolkit.NewOpeningFromReader(coin.GetValue(), rand)
+		rSND := crypto.RandomScalarFromReader(rand)
+		comSND := crypto.PedCom.CommitAtIndex(coin.GetSNDerivator(), rSND, crypto.PedersenSndIndex)
+		reserveCoin := &ReserveCoin{
+			commitmentIndices: append([]uint64{}, wit.commitmentIndices[i*crypto.CommitmentRingSize:(i+1)*crypto.CommitmentRingSize]...),
+			comValue:          valueOpenings[i].Commitment(),
+		}
+
+		// the commitment of the coin minus the commitments is H^(randomness - rSK - rValue - rSND)
+		randSum := new(crypto.Scalar).Sub(coin.GetRandomness(), rSK)
+		randSum.Sub(randSum, valueOpenings[i].Blind)
+		randSum.Sub(randSum, rSND)
+		oneOfManyWit := new(oneoutofmany.OneOutOfManyWitness)
+		oneOfManyWit.Set(ringStatement(ring, proof.comSK, reserveCoin.comValue, comSND, shardID), randSum, uint64(myIndex))
+		var err error
+		reserveCoin.oneOfManyProof, err = oneOfManyWit.ProveWithRandomness(rand)
+		if err != nil {
+			return nil, err
+		}
+
+		// serial number is derived from the committed private key and snd, as the serial number of an input coin
+		serialNumber := new(crypto.Point).Derive(crypto.PedCom.G[crypto.PedersenPrivateKeyIndex], wit.privateKey, coin.GetSNDerivator())
+		stmt := new(serialnumberprivacy.SerialNumberPrivacyStatement)
+		stmt.Set(serialNumber, proof.comSK, comSND)
+		serialNumberWit := new(serialnumberprivacy.SNPrivacyWitness)
+		serialNumberWit.Set(stmt, wit.privateKey, rSK, coin.GetSNDerivator(), rSND)
+		reserveCoin.serialNumberProof, err = serialNumberWit.ProveWithRandomness(nil, rand)
+		if err != nil {
+			return nil, err
+		}
+		proof.coins[i] = reserveCoin
+	}
+
+	// sum - minAmount with the sum of blinding factors opens the sum of value commitments divided by G[value]^minAmount
+	sum, err := toolkit.AddOpenings(valueOpenings)
+	if err != nil {
+		return nil, err
+	}
+	if sum.Value < wit.minAmount {
+		return nil, errors.New("sum of values of coins is less than the amount")
+	}
+	sum.Value -= wit.minAmount
+	proof.rangeProof, err = toolkit.ProveRangeWithRandomness([]*toolkit.Opening{sum}, rand)
+	if err != nil {
+		return nil, err
+	}
+
+	// the public key of (sk, rSK) is comSK
+	sigKey := new(crypto.SchnorrPrivateKey)
+	sigKey.Set(wit.privateKey, rSK)
+	proof.signature, err = sigKey.SignWithRandomness(messageToSign(proof, context), rand)
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// Verify verifies the proof with context
+// getCommitmentFn returns the coin commitments of the rings of coins, from the token that is audited
+// the coins are unspent only if the serial numbers of the proof (GetSerialNumbers) are not on chain, Verify does not check it
+func (proof ReservesProof) Verify(context []byte, getCommitmentFn GetCommitmentFn) (bool, error) {
+	if proof.isNil() {
+		return false, errors.New("invalid reserves proof")
+	}
+	if getCommitmentFn == nil {
+		return false, errors.New("commitments of rings are required")
+	}
+	if !proof.comSK.PointValid() {
+		return false, errors.New("invalid commitment to private key of reserves proof")
+	}
+
+	// the proof is signed by the owner of the coins
+	verifyKey := new(crypto.SchnorrPublicKey)
+	verifyKey.Set(proof.comSK)
+	if !verifyKey.Verify(proof.signature, messageToSign(&proof, context)) {
+		return false, errors.New("invalid signature of reserves proof")
+	}
+
+	comValues := make([]*crypto.Point, len(proof.coins))
+	oneOfManyProofs := make([]*oneoutofmany.OneOutOfManyProof, len(proof.coins))
+	seenSerialNumbers := make(map[string]bool)
+	for i, coin := range proof.coins {
+		if !coin.comValue.PointValid() || !coin.oneOfManyProof.ValidateSanity() || !coin.serialNumberProof.ValidateSanity() {
+			return false, errors.New("invalid coin of reserves proof")
+		}
+		if !crypto.IsPointEqual(coin.serialNumberProof.GetComSK(), proof.comSK) {
+			return false, errors.New("serial number proof is not of the committed private key")
+		}
+		// a coin is counted only once
+		snKey := string(coin.GetSerialNumber().ToBytesS())
+		if seenSerialNumbers[snKey] {
+			return false, errors.New("duplicate coin in reserves proof")
+		}
+		seenSerialNumbers[snKey] = true
+
+		valid, err := coin.serialNumberProof.Verify(nil)
+		if !valid {
+			return false, fmt.Errorf("invalid serial number of coin: %v", err)
+		}
+
+		ring := make([]*crypto.Point, crypto.CommitmentRingSize)
+		for j, cmIndex := range coin.commitmentIndices {
+			ring[j], err = getCommitmentFn(cmIndex, proof.shardID)
+			if err != nil {
+				return false, fmt.Errorf("can not get commitment from index=%d shardID=%+v: %v", cmIndex, proof.shardID, err)
+			}
+		}
+		// statement is set on a copy to keep the proof unchanged
+		oneOfManyProof := *coin.oneOfManyProof
+		oneOfManyProof.Statement = &oneoutofmany.OneOutOfManyStatement{
+			Commitments: ringStatement(ring, proof.comSK, coin.comValue, coin.serialNumberProof.GetComInput(), proof.shardID),
+		}
+		oneOfManyProofs[i] = &oneOfManyProof
+		comValues[i] = coin.comValue
+	}
+
+	valid, err, _ := oneoutofmany.VerifyBatchingOneOutOfManyProofs(oneOfManyProofs)
+	if !valid {
+		return false, fmt.Errorf("coin is not in its ring: %v", err)
+	}
+
+	// commitment of sum - minAmount
+	lower := new(crypto.Point).Sub(toolkit.AddCommitments(comValues), toolkit.Commit(proof.minAmount, new(crypto.Scalar).FromUint64(0)))
+	return toolkit.VerifyRange([]*crypto.Point{lower}, proof.rangeProof)
+}
@@ -0,0 +1,240 @@
+package reserves
+
+import (
+	"errors"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newCoin(privateKey *crypto.Scalar, value uint64) *crypto.OutputCoin {
+	coin := new(crypto.OutputCoin).Init()
+	coin.CoinDetails.SetPublicKey(new(crypto.Point).ScalarMultBase(privateKey))
+	coin.CoinDetails.SetValue(value)
+	coin.CoinDetails.SetSNDerivator(crypto.RandomScalar())
+	coin.CoinDetails.SetRandomness(crypto.RandomScalar())
+	coin.CoinDetails.CommitAll()
+	return coin
+}
+
+// testChain is a list of coin commitments on chain, the index of a commitment is its position
+type testChain struct {
+	commitments []*crypto.Point
+}
+
+// addRings adds rings of coins to chain, each ring contains the commitment of its coin at a random position
+func (chain *testChain) addRings(coins []*crypto.OutputCoin) ([]*crypto.Point, []uint64) {
+	var commitments []*crypto.Point
+	var commitmentIndices []uint64
+	for _, coin := range coins {
+		myIndex := int(crypto.RandomScalar().ToBytesS()[0]) % crypto.CommitmentRingSize
+		for j := 0; j < crypto.CommitmentRingSize; j++ {
+			commitment := crypto.RandomPoint()
+			if j == myIndex {
+				commitment = coin.CoinDetails.GetCoinCommitment()
+			}
+			commitmentIndices = append(commitmentIndices, uint64(len(chain.commitments)))
+			commitments = append(commitments, commitment)
+			chain.commitments = append(chain.commitments, commitment)
+		}
+	}
+	return commitments, commitmentIndices
+}
+
+func (chain *testChain) getCommitment(cmIndex uint64, shardID byte) (*crypto.Point, error) {
+	if cmIndex >= uint64(len(chain.commitments)) {
+		return nil, errors.New("commitment not found")
+	}
+	return chain.commitments[cmIndex], nil
+}
+
+func prove(t *testing.T, chain *testChain, privateKey *crypto.Scalar, coins []*crypto.OutputCoin, minAmount uint64, context []byte) *ReservesProof {
+	commitments, commitmentIndices := chain.addRings(coins)
+	wit := new(ReservesWitness)
+	wit.Set(privateKey, coins, minAmount, commitments, commitmentIndices)
+	proof, err := wit.Prove(context)
+	assert.Equal(t, nil, err)
+	return proof
+}
+
+func TestReservesProof(t *testing.T) {
+	chain := new(testChain)
+	privateKey := crypto.RandomScalar()
+	coins := []*crypto.OutputCoin{newCoin(privateKey, 100), newCoin(privateKey, 200), newCoin(privateKey, 0)}
+	context := []byte("audit 2026-10")
+
+	for _, minAmount := range []uint64{0, 150, 300} {
+		proof := prove(t, chain, privateKey, coins, minAmount, context)
+		res, err := proof.Verify(context, chain.getCommitment)
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, minAmount, proof.GetMinAmount())
+		assert.Equal(t, len(coins), len(proof.GetCoins()))
+
+		// the proof is bound to its context
+		res, err = proof.Verify([]byte("another audit"), chain.getCommitment)
+		assert.Equal(t, false, res)
+		assert.NotEqual(t, nil, err)
+
+		// bytes
+		proof2 := new(ReservesProof)
+		err = proof2.SetBytes(proof.Bytes())
+		assert.Equal(t, nil, err)
+		assert.Equal(t, proof.Bytes(), proof2.Bytes())
+		res, err = proof2.Verify(context, chain.getCommitment)
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+	}
+
+	// serial numbers are the serial numbers of coins on chain, in proofs of all contexts
+	proof := prove(t, chain, privateKey, coins, 0, context)
+	proof2 := prove(t, chain, privateKey, coins, 0, []byte("another audit"))
+	assert.Equal(t, len(coins), len(proof.GetSerialNumbers()))
+	for i, coin := range coins {
+		sn := new(crypto.Point).Derive(crypto.PedCom.G[crypto.PedersenPrivateKeyIndex], privateKey, coin.CoinDetails.GetSNDerivator())
+		assert.Equal(t, true, crypto.IsPointEqual(sn, proof.GetSerialNumbers()[i]))
+		assert.Equal(t, true, crypto.IsPointEqual(sn, proof2.GetCoins()[i].GetSerialNumber()))
+		assert.Equal(t, crypto.CommitmentRingSize, len(proof.GetCoins()[i].GetCommitmentIndices()))
+	}
+}
+
+func TestReservesProofFailed(t *testing.T) {
+	chain := new(testChain)
+	privateKey := crypto.RandomScalar()
+	coins := []*crypto.OutputCoin{newCoin(privateKey, 100), newCoin(privateKey, 200)}
+	commitments, commitmentIndices := chain.addRings(coins)
+	context := []byte("audit")
+
+	wit := new(ReservesWitness)
+	wit.Set(privateKey, coins, 301, commitments, commitmentIndices)
+	_, err := wit.Prove(context)
+	assert.NotEqual(t, nil, err)
+
+	wit.Set(privateKey, nil, 0, nil, nil)
+	_, err = wit.Prove(context)
+	assert.NotEqual(t, nil, err)
+
+	// coins of another account
+	otherCoins := append([]*crypto.OutputCoin{newCoin(crypto.RandomScalar(), 1)}, coins...)
+	otherCommitments, otherCommitmentIndices := chain.addRings(otherCoins)
+	wit.Set(privateKey, otherCoins, 0, otherCommitments, otherCommitmentIndices)
+	_, err = wit.Prove(context)
+	assert.NotEqual(t, nil, err)
+
+	// coin details do not match the coin commitment
+	wrongCoin := newCoin(privateKey, 1000)
+	wrongCommitments, wrongCommitmentIndices := chain.addRings([]*crypto.OutputCoin{wrongCoin})
+	wrongCoin.CoinDetails.SetValue(2000)
+	wit.Set(privateKey, []*crypto.OutputCoin{wrongCoin}, 0, wrongCommitments, wrongCommitmentIndices)
+	_, err = wit.Prove(context)
+	assert.NotEqual(t, nil, err)
+
+	// rings do not contain the coins
+	wit.Set(privateKey, coins, 0, otherCommitments[:len(commitments)], commitmentIndices)
+	_, err = wit.Prove(context)
+	assert.NotEqual(t, nil, err)
+
+	// rings of wrong size
+	wit.Set(privateKey, coins, 0, commitments[1:], commitmentIndices[1:])
+	_, err = wit.Prove(context)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReservesProofTampered(t *testing.T) {
+	chain := new(testChain)
+	privateKey := crypto.RandomScalar()
+	coins := []*crypto.OutputCoin{newCoin(privateKey, 100), newCoin(privateKey, 200)}
+	context := []byte("audit")
+	commitments, commitmentIndices := chain.addRings(coins)
+	wit := new(ReservesWitness)
+	wit.Set(privateKey, coins, 300, commitments, commitmentIndices)
+	seed := []byte("reserves")
+	proof, err := wit.ProveWithRandomness(context, crypto.NewDRBG(seed))
+	assert.Equal(t, nil, err)
+
+	// the randomness of comSK is the first random number of the proof,
+	// tampered proofs are signed again to check the other parts of the proof
+	sigKey := new(crypto.SchnorrPrivateKey)
+	sigKey.Set(privateKey, crypto.RandomScalarFromReader(crypto.NewDRBG(seed)))
+	assert.Equal(t, true, crypto.IsPointEqual(proof.comSK, sigKey.GetPublicKey().GetPublicKey()))
+	sign := func(proof *ReservesProof) *ReservesProof {
+		var err error
+		proof.signature, err = sigKey.Sign(messageToSign(proof, context))
+		assert.Equal(t, nil, err)
+		return proof
+	}
+	verifyTampered := func(proof *ReservesProof) {
+		res, err := proof.Verify(context, chain.getCommitment)
+		assert.Equal(t, false, res)
+		assert.NotEqual(t, nil, err)
+		res, err = sign(proof).Verify(context, chain.getCommitment)
+		assert.Equal(t, false, res)
+		assert.NotEqual(t, nil, err)
+	}
+
+	// a larger amount
+	tampered := *proof
+	tampered.minAmount = 301
+	verifyTampered(&tampered)
+
+	// a coin counted twice has a duplicate serial number
+	tampered = *proof
+	tampered.coins = []*ReserveCoin{proof.coins[0], proof.coins[1], proof.coins[1]}
+	verifyTampered(&tampered)
+
+	// a coin proved twice in another proof
+	proof2 := prove(t, chain, privateKey, []*crypto.OutputCoin{coins[1], coins[1]}, 0, context)
+	res, err := proof2.Verify(context, chain.getCommitment)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	// serial numbers of coins are swapped
+	coin0, coin1 := *proof.coins[0], *proof.coins[1]
+	coin0.serialNumberProof, coin1.serialNumberProof = coin1.serialNumberProof, coin0.serialNumberProof
+	tampered = *proof
+	tampered.coins = []*ReserveCoin{&coin0, &coin1}
+	verifyTampered(&tampered)
+
+	// a coin of another proof, its serial number proof is of another commitment to private key
+	otherProof := prove(t, chain, privateKey, []*crypto.OutputCoin{newCoin(privateKey, 1000)}, 0, context)
+	tampered = *proof
+	tampered.coins = []*ReserveCoin{proof.coins[0], otherProof.coins[0]}
+	verifyTampered(&tampered)
+
+	// a ring that does not contain the coin
+	coin := *proof.coins[0]
+	coin.commitmentIndices = otherProof.coins[0].commitmentIndices
+	tampered = *proof
+	tampered.coins = []*ReserveCoin{&coin, proof.coins[1]}
+	verifyTampered(&tampered)
+
+	// a proof signed again is valid
+	tampered = *proof
+	res, err = sign(&tampered).Verify(context, chain.getCommitment)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	// commitments of rings are not found
+	res, err = proof.Verify(context, new(testChain).getCommitment)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+	res, err = proof.Verify(context, nil)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	// bytes
+	proofBytes := proof.Bytes()
+	for _, i := range []int{0, 8, 20, 50, 200, 1000, 2000, len(proofBytes) - 1} {
+		changed := append([]byte{}, proofBytes...)
+		changed[i] ^= 1
+		proof2 := new(ReservesProof)
+		if proof2.SetBytes(changed) != nil {
+			continue
+		}
+		res, _ := proof2.Verify(context, chain.getCommitment)
+		assert.Equal(t, false, res)
+	}
+	assert.NotEqual(t, nil, new(ReservesProof).SetBytes(proofBytes[:20]))
+	assert.NotEqual(t, nil, new(ReservesProof).SetBytes(proofBytes[:100]))
+	assert.NotEqual(t, nil, new(ReservesProof).SetBytes(proofBytes[:45+2*reserveCoinSize+signatureSize]))
+}
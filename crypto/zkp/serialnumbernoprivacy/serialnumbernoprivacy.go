@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
+	"io"
 )
 
 type SerialNumberNoPrivacyStatement struct {
@@ -186,8 +187,13 @@ func (pro *SNNoPrivacyProof) SetBytes(bytes []byte) error {
 }
 
 func (wit SNNoPrivacyWitness) Prove(mess []byte) (*SNNoPrivacyProof, error) {
+	return wit.ProveWithRandomness(mess, nil)
+}
+
+// ProveWithRandomness is the same as Prove, but random numbers are read from rand
+func (wit SNNoPrivacyWitness) ProveWithRandomness(mess []byte, rand io.Reader) (*SNNoPrivacyProof, error) {
 	// randomness
-	eSK := crypto.RandomScalar()
+	eSK := crypto.RandomScalarFromReader(rand)
 	// calculate tSeed = g_SK^eSK
 	tSK := new(crypto.Point).ScalarMult(crypto.PedCom.G[crypto.PedersenPrivateKeyIndex], eSK)
 	// calculate tOutput = sn^eSK
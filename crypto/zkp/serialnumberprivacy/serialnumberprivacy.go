@@ -6,6 +6,7 @@ import (
 	"github.com/0xkraken/incognito-sdk-golang/common"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/utils"
+	"io"
 )
 
 type SerialNumberPrivacyStatement struct {
@@ -36,6 +37,19 @@ type SNPrivacyProof struct {
 	zRInput *crypto.Scalar // second challenge-dependent information to open the commitment to input
 }
 
+// GetSerialNumber returns the serial number that is proved to be derived from the committed private key and input
+func (proof SNPrivacyProof) GetSerialNumber() *crypto.Point {
+	return proof.stmt.sn
+}
+
+func (proof SNPrivacyProof) GetComSK() *crypto.Point {
+	return proof.stmt.comSK
+}
+
+func (proof SNPrivacyProof) GetComInput() *crypto.Point {
+	return proof.stmt.comInput
+}
+
 // ValidateSanity validates sanity of proof
 func (proof SNPrivacyProof) ValidateSanity() bool {
 	if !proof.stmt.sn.PointValid() {
@@ -252,10 +266,15 @@ func (proof *SNPrivacyProof) SetBytes(bytes []byte) error {
 }
 
 func (wit SNPrivacyWitness) Prove(mess []byte) (*SNPrivacyProof, error) {
-	eSK := crypto.RandomScalar()
-	eSND := crypto.RandomScalar()
-	dSK := crypto.RandomScalar()
-	dSND := crypto.RandomScalar()
+	return wit.ProveWithRandomness(mess, nil)
+}
+
+// ProveWithRandomness is the same as Prove, but random numbers are read from rand
+func (wit SNPrivacyWitness) ProveWithRandomness(mess []byte, rand io.Reader) (*SNPrivacyProof, error) {
+	eSK := crypto.RandomScalarFromReader(rand)
+	eSND := crypto.RandomScalarFromReader(rand)
+	dSK := crypto.RandomScalarFromReader(rand)
+	dSND := crypto.RandomScalarFromReader(rand)
 	// calculate tSeed = g_SK^eSK * h^dSK
 	tSeed := crypto.PedCom.CommitAtIndex(eSK, dSK, crypto.PedersenPrivateKeyIndex)
 	// calculate tSND = g_SND^eSND * h^dSND
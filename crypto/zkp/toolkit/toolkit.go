@@ -0,0 +1,182 @@
+// Package toolkit proves statements about Pedersen commitments without revealing the committed values,
+// e.g. for proofs of reserves and solvency off chain:
+//   - commitments to values and sums of commitments
+//   - range proofs that committed values are in [0, 2^64) or in [min, max]
+//   - proofs of knowledge of the opening of a commitment
+//
+// commitments are the same as commitments of coin values, G[PedersenValueIndex]^value * H^blind
+package toolkit
+
+import (
+	"errors"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/aggregaterange"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/commitmentopening"
+	"io"
+	"math"
+)
+
+// Opening is the value and the blinding factor of a commitment, it must be kept secret
+type Opening struct {
+	Value uint64
+	Blind *crypto.Scalar
+}
+
+// NewOpening returns an opening of value with a random blinding factor
+func NewOpening(value uint64) *Opening {
+	return NewOpeningFromReader(value, nil)
+}
+
+// NewOpeningFromReader is the same as NewOpening, but the blinding factor is read from rand
+func NewOpeningFromReader(value uint64, rand io.Reader) *Opening {
+	return &Opening{
+		Value: value,
+		Blind: crypto.RandomScalarFromReader(rand),
+	}
+}
+
+// Commit returns G[PedersenValueIndex]^value * H^blind
+func Commit(value uint64, blind *crypto.Scalar) *crypto.Point {
+	return crypto.PedCom.CommitAtIndex(new(crypto.Scalar).FromUint64(value), blind, crypto.PedersenValueIndex)
+}
+
+// Commitment returns the commitment of opening
+func (opening Opening) Commitment() *crypto.Point {
+	return Commit(opening.Value, opening.Blind)
+}
+
+// CheckOpening returns true if opening is the opening of commitment, it reveals the value to the verifier
+func CheckOpening(commitment *crypto.Point, opening *Opening) bool {
+	if commitment == nil || opening == nil || opening.Blind == nil {
+		return false
+	}
+	return crypto.IsPointEqual(commitment, opening.Commitment())
+}
+
+// AddCommitments returns the commitment of the sum of the values of commitments
+func AddCommitments(commitments []*crypto.Point) *crypto.Point {
+	sum := new(crypto.Point).Identity()
+	for _, commitment := range commitments {
+		sum.Add(sum, commitment)
+	}
+	return sum
+}
+
+// AddOpenings returns the opening of AddCommitments of the commitments of openings
+// it returns an error if the sum of values overflows uint64
+func AddOpenings(openings []*Opening) (*Opening, error) {
+	sum := &Opening{Blind: new(crypto.Scalar).FromUint64(0)}
+	for _, opening := range openings {
+		if sum.Value > math.MaxUint64-opening.Value {
+			return nil, errors.New("sum of values overflows")
+		}
+		sum.Value += opening.Value
+		sum.Blind.Add(sum.Blind, opening.Blind)
+	}
+	return sum, nil
+}
+
+// ProveRange proves that the values of openings are in [0, 2^64)
+// the proof contains the commitments of openings in the same order
+func ProveRange(openings []*Opening) (*aggregaterange.AggregatedRangeProof, error) {
+	return ProveRangeWithRandomness(openings, nil)
+}
+
+// ProveRangeWithRandomness is the same as ProveRange, but random numbers are read from rand
+func ProveRangeWithRandomness(openings []*Opening, rand io.Reader) (*aggregaterange.AggregatedRangeProof, error) {
+	if len(openings) == 0 {
+		return nil, errors.New("no openings to prove")
+	}
+	values := make([]uint64, len(openings))
+	blinds := make([]*crypto.Scalar, len(openings))
+	for i, opening := range openings {
+		values[i] = opening.Value
+		blinds[i] = opening.Blind
+	}
+	wit := new(aggregaterange.AggregatedRangeWitness)
+	wit.Set(values, blinds)
+	return wit.ProveWithRandomness(rand)
+}
+
+// VerifyRange verifies that proof is a range proof of commitments
+func VerifyRange(commitments []*crypto.Point, proof *aggregaterange.AggregatedRangeProof) (bool, error) {
+	if proof == nil || proof.IsNil() {
+		return false, errors.New("range proof is nil")
+	}
+	if !equalCommitments(commitments, proof.GetCommitments()) {
+		return false, errors.New("range proof is not a proof of the commitments")
+	}
+	if !proof.ValidateSanity() {
+		return false, errors.New("invalid range proof")
+	}
+	return proof.Verify()
+}
+
+// ProveRangeBetween proves that the value of opening is in [min, max]
+// it proves that value - min and max - value are in [0, 2^64)
+func ProveRangeBetween(opening *Opening, min uint64, max uint64) (*aggregaterange.AggregatedRangeProof, error) {
+	return ProveRangeBetweenWithRandomness(opening, min, max, nil)
+}
+
+// ProveRangeBetweenWithRandomness is the same as ProveRangeBetween, but random numbers are read from rand
+func ProveRangeBetweenWithRandomness(opening *Opening, min uint64, max uint64, rand io.Reader) (*aggregaterange.AggregatedRangeProof, error) {
+	if opening.Value < min || opening.Value > max {
+		return nil, errors.New("value is out of range")
+	}
+	lower := &Opening{
+		Value: opening.Value - min,
+		Blind: opening.Blind,
+	}
+	upper := &Opening{
+		Value: max - opening.Value,
+		Blind: new(crypto.Scalar).Sub(new(crypto.Scalar).FromUint64(0), opening.Blind),
+	}
+	return ProveRangeWithRandomness([]*Opening{lower, upper}, rand)
+}
+
+// VerifyRangeBetween verifies that proof is a proof of ProveRangeBetween of commitment
+func VerifyRangeBetween(commitment *crypto.Point, min uint64, max uint64, proof *aggregaterange.AggregatedRangeProof) (bool, error) {
+	if commitment == nil {
+		return false, errors.New("commitment is nil")
+	}
+	// commitments of value - min and max - value, they are computed from commitment by the verifier
+	lower := new(crypto.Point).Sub(commitment, Commit(min, new(crypto.Scalar).FromUint64(0)))
+	upper := new(crypto.Point).Sub(Commit(max, new(crypto.Scalar).FromUint64(0)), commitment)
+	return VerifyRange([]*crypto.Point{lower, upper}, proof)
+}
+
+// ProveOpeningKnowledge proves the knowledge of opening of its commitment without revealing it
+// context is bound to the proof, e.g. the purpose and the time of the proof, the proof does not verify with another context
+func ProveOpeningKnowledge(opening *Opening, context []byte) (*commitmentopening.OpeningProof, error) {
+	return ProveOpeningKnowledgeWithRandomness(opening, context, nil)
+}
+
+// ProveOpeningKnowledgeWithRandomness is the same as ProveOpeningKnowledge, but random numbers are read from rand
+func ProveOpeningKnowledgeWithRandomness(opening *Opening, context []byte, rand io.Reader) (*commitmentopening.OpeningProof, error) {
+	wit := new(commitmentopening.OpeningWitness)
+	wit.Set(opening.Commitment(), crypto.PedersenValueIndex, new(crypto.Scalar).FromUint64(opening.Value), opening.Blind)
+	return wit.ProveWithRandomness(context, rand)
+}
+
+// VerifyOpeningKnowledge verifies that proof is a proof of ProveOpeningKnowledge of commitment with context
+func VerifyOpeningKnowledge(commitment *crypto.Point, context []byte, proof *commitmentopening.OpeningProof) (bool, error) {
+	if commitment == nil || proof == nil || proof.GetCommitment() == nil {
+		return false, errors.New("commitment or proof is nil")
+	}
+	if proof.GetIndex() != crypto.PedersenValueIndex || !crypto.IsPointEqual(commitment, proof.GetCommitment()) {
+		return false, errors.New("opening proof is not a proof of the commitment")
+	}
+	return proof.Verify(context)
+}
+
+func equalCommitments(a []*crypto.Point, b []*crypto.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] == nil || b[i] == nil || !crypto.IsPointEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package toolkit
+
+import (
+	"fmt"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCommitments(t *testing.T) {
+	openings := []*Opening{NewOpening(10), NewOpening(20), NewOpening(30)}
+	commitments := make([]*crypto.Point, len(openings))
+	for i, opening := range openings {
+		commitments[i] = opening.Commitment()
+		assert.Equal(t, true, CheckOpening(commitments[i], opening))
+	}
+	assert.Equal(t, false, CheckOpening(commitments[0], openings[1]))
+	assert.Equal(t, false, CheckOpening(commitments[0], &Opening{Value: 10, Blind: crypto.RandomScalar()}))
+
+	sum, err := AddOpenings(openings)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(60), sum.Value)
+	assert.Equal(t, true, CheckOpening(AddCommitments(commitments), sum))
+
+	_, err = AddOpenings([]*Opening{NewOpening(math.MaxUint64), NewOpening(1)})
+	assert.NotEqual(t, nil, err)
+}
+
+func TestRange(t *testing.T) {
+	openings := []*Opening{NewOpening(0), NewOpening(12345), NewOpening(math.MaxUint64)}
+	commitments := []*crypto.Point{openings[0].Commitment(), openings[1].Commitment(), openings[2].Commitment()}
+
+	proof, err := ProveRange(openings)
+	assert.Equal(t, nil, err)
+	res, err := VerifyRange(commitments, proof)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	// the proof is not a proof of other commitments
+	res, err = VerifyRange([]*crypto.Point{commitments[1], commitments[0], commitments[2]}, proof)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+	res, err = VerifyRange(commitments[:2], proof)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	_, err = ProveRange(nil)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestRangeBetween(t *testing.T) {
+	opening := NewOpening(100)
+	commitment := opening.Commitment()
+
+	for _, bounds := range [][2]uint64{{100, 100}, {0, 100}, {100, math.MaxUint64}, {50, 150}} {
+		proof, err := ProveRangeBetween(opening, bounds[0], bounds[1])
+		assert.Equal(t, nil, err)
+		res, err := VerifyRangeBetween(commitment, bounds[0], bounds[1], proof)
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		// the proof is not a proof of other bounds
+		res, _ = VerifyRangeBetween(commitment, bounds[0]+1, bounds[1], proof)
+		assert.Equal(t, false, res)
+		res, _ = VerifyRangeBetween(commitment, bounds[0], bounds[1]-1, proof)
+		assert.Equal(t, false, res)
+	}
+
+	_, err := ProveRangeBetween(opening, 101, 200)
+	assert.NotEqual(t, nil, err)
+	_, err = ProveRangeBetween(opening, 0, 99)
+	assert.NotEqual(t, nil, err)
+
+	// a proof of another value does not verify
+	other := &Opening{Value: 99, Blind: opening.Blind}
+	proof, _ := ProveRangeBetween(other, 0, 99)
+	res, _ := VerifyRangeBetween(commitment, 0, 99, proof)
+	assert.Equal(t, false, res)
+}
+
+func TestOpeningKnowledge(t *testing.T) {
+	opening := NewOpening(100)
+	commitment := opening.Commitment()
+
+	proof, err := ProveOpeningKnowledge(opening, []byte("context"))
+	assert.Equal(t, nil, err)
+	res, err := VerifyOpeningKnowledge(commitment, []byte("context"), proof)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	res, _ = VerifyOpeningKnowledge(commitment, []byte("another context"), proof)
+	assert.Equal(t, false, res)
+	res, _ = VerifyOpeningKnowledge(NewOpening(100).Commitment(), []byte("context"), proof)
+	assert.Equal(t, false, res)
+	res, _ = VerifyOpeningKnowledge(commitment, []byte("context"), nil)
+	assert.Equal(t, false, res)
+}
+
+// proof of solvency: reserves are at least liabilities, neither of them is revealed
+func Example() {
+	reserves := []*Opening{NewOpening(700), NewOpening(500)}
+	liabilities := []*Opening{NewOpening(400), NewOpening(600)}
+
+	// the prover publishes the commitments of reserves and liabilities
+	reserveCommitments := []*crypto.Point{reserves[0].Commitment(), reserves[1].Commitment()}
+	liabilityCommitments := []*crypto.Point{liabilities[0].Commitment(), liabilities[1].Commitment()}
+
+	// and proves that total reserves - total liabilities is in [0, 2^64)
+	totalReserves, _ := AddOpenings(reserves)
+	totalLiabilities, _ := AddOpenings(liabilities)
+	surplus := &Opening{
+		Value: totalReserves.Value - totalLiabilities.Value,
+		Blind: new(crypto.Scalar).Sub(totalReserves.Blind, totalLiabilities.Blind),
+	}
+	proof, _ := ProveRange([]*Opening{surplus})
+
+	// the verifier computes the commitment of the surplus from the published commitments
+	surplusCommitment := new(crypto.Point).Sub(AddCommitments(reserveCommitments), AddCommitments(liabilityCommitments))
+	solvent, _ := VerifyRange([]*crypto.Point{surplusCommitment}, proof)
+	fmt.Println(solvent)
+	// Output: true
+}
@@ -6,6 +6,9 @@ const (
 	SnPrivacyProofSize   = 320
 	SnNoPrivacyProofSize = 192
 
+	// size of proof of knowledge of a commitment opening
+	OpeningProofSize = 129
+
 	inputCoinsPrivacySize    = 39  // serial number + 7 for flag
 	outputCoinsPrivacySize   = 221 // PublicKey + coin commitment + SND + Ciphertext (122 bytes) + 9 bytes flag
 	inputCoinsNoPrivacySize  = 175 // PublicKey + coin commitment + SND + Serial number + Randomness + Value + 7 flag
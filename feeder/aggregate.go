@@ -0,0 +1,64 @@
+package feeder
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// USDTUnit is the unit of rates in PortalExchangeRates metadata (amount * 10^6 USDT)
+const USDTUnit = 1e6
+
+// median returns median of prices, prices must not be empty
+func median(prices []float64) float64 {
+	sorted := make([]float64, len(prices))
+	copy(sorted, prices)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// AggregatePrices returns median of prices after rejecting outliers
+// a price is an outlier if it deviates from median of all prices more than maxDeviation (0.1 means 10%)
+// it returns error if there are less than minSources prices left
+func AggregatePrices(prices []float64, maxDeviation float64, minSources int) (float64, error) {
+	validPrices := make([]float64, 0, len(prices))
+	for _, price := range prices {
+		if price > 0 && !math.IsInf(price, 0) && !math.IsNaN(price) {
+			validPrices = append(validPrices, price)
+		}
+	}
+	if len(validPrices) == 0 || len(validPrices) < minSources {
+		return 0, errors.New("not enough prices to aggregate")
+	}
+
+	m := median(validPrices)
+	if maxDeviation <= 0 {
+		return m, nil
+	}
+
+	inliers := make([]float64, 0, len(validPrices))
+	for _, price := range validPrices {
+		if math.Abs(price-m)/m <= maxDeviation {
+			inliers = append(inliers, price)
+		}
+	}
+	if len(inliers) == 0 || len(inliers) < minSources {
+		return 0, errors.New("not enough prices to aggregate after rejecting outliers")
+	}
+
+	return median(inliers), nil
+}
+
+// ConvertToUSDTUnit converts price in USD to the unit of PortalExchangeRates metadata
+func ConvertToUSDTUnit(price float64) (uint64, error) {
+	rate := math.Round(price * USDTUnit)
+	if rate <= 0 || rate >= math.MaxUint64 {
+		return 0, errors.New("price is out of range")
+	}
+	return uint64(rate), nil
+}
@@ -0,0 +1,170 @@
+package feeder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/transaction"
+)
+
+const (
+	defaultSubmitInterval     = 1 * time.Hour
+	defaultDeviationThreshold = 0.01
+	defaultMaxDeviation       = 0.1
+	defaultMinSources         = 1
+)
+
+// ExchangeRateFeeder pulls prices from PriceSources and submits PortalExchangeRates txs
+// Rates are submitted every SubmitInterval or when a rate changes more than DeviationThreshold
+type ExchangeRateFeeder struct {
+	TokenIDs []string
+	Sources  []PriceSource
+
+	// SubmitInterval is the maximum time between two submissions
+	SubmitInterval time.Duration
+	// DeviationThreshold triggers a submission when a rate changes more than it from the last submitted rate (0.01 means 1%)
+	DeviationThreshold float64
+	// MaxDeviation is used to reject outliers among prices from sources (0.1 means 10%)
+	MaxDeviation float64
+	// MinSources is the minimum number of prices of a token needed to aggregate
+	MinSources int
+
+	lastRates      map[string]uint64
+	lastSubmitTime time.Time
+
+	submitFn func(rates map[string]uint64) (string, error)
+}
+
+func NewExchangeRateFeeder(
+	rpcClient *rpcclient.HttpClient,
+	privateKeyStr string,
+	tokenIDs []string,
+	sources []PriceSource,
+	fee uint64,
+) (*ExchangeRateFeeder, error) {
+	if len(tokenIDs) == 0 {
+		return nil, errors.New("token ids are empty")
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("price sources are empty")
+	}
+
+	f := newExchangeRateFeeder(tokenIDs, sources)
+	f.submitFn = func(rates map[string]uint64) (string, error) {
+		return transaction.CreateAndSendTxPortalExchangeRate(rpcClient, privateKeyStr, rates, fee)
+	}
+	return f, nil
+}
+
+func newExchangeRateFeeder(tokenIDs []string, sources []PriceSource) *ExchangeRateFeeder {
+	sortedTokenIDs := make([]string, len(tokenIDs))
+	copy(sortedTokenIDs, tokenIDs)
+	sort.Strings(sortedTokenIDs)
+
+	return &ExchangeRateFeeder{
+		TokenIDs:           sortedTokenIDs,
+		Sources:            sources,
+		SubmitInterval:     defaultSubmitInterval,
+		DeviationThreshold: defaultDeviationThreshold,
+		MaxDeviation:       defaultMaxDeviation,
+		MinSources:         defaultMinSources,
+		lastRates:          map[string]uint64{},
+	}
+}
+
+// GetRates pulls prices from all sources and returns aggregated rates in 10^6 USDT unit
+// tokens that don't have enough prices are omitted, it returns error when there isn't any rate
+func (f *ExchangeRateFeeder) GetRates() (map[string]uint64, error) {
+	prices := map[string][]float64{}
+	for _, source := range f.Sources {
+		sourcePrices, err := source.GetPrices(f.TokenIDs)
+		if err != nil {
+			fmt.Printf("Can not get prices from source %v: %v\n", source.Name(), err)
+			continue
+		}
+		for tokenID, price := range sourcePrices {
+			prices[tokenID] = append(prices[tokenID], price)
+		}
+	}
+
+	rates := map[string]uint64{}
+	for _, tokenID := range f.TokenIDs {
+		price, err := AggregatePrices(prices[tokenID], f.MaxDeviation, f.MinSources)
+		if err != nil {
+			fmt.Printf("Can not aggregate prices of token %v: %v\n", tokenID, err)
+			continue
+		}
+		rate, err := ConvertToUSDTUnit(price)
+		if err != nil {
+			fmt.Printf("Can not convert price of token %v: %v\n", tokenID, err)
+			continue
+		}
+		rates[tokenID] = rate
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("there isn't any exchange rate")
+	}
+	return rates, nil
+}
+
+// shouldSubmit returns true if it's time to submit by schedule or a rate deviates more than threshold
+func (f *ExchangeRateFeeder) shouldSubmit(rates map[string]uint64, now time.Time) bool {
+	if f.lastSubmitTime.IsZero() || now.Sub(f.lastSubmitTime) >= f.SubmitInterval {
+		return true
+	}
+	for tokenID, rate := range rates {
+		lastRate, ok := f.lastRates[tokenID]
+		if !ok || lastRate == 0 {
+			return true
+		}
+		deviation := math.Abs(float64(rate)-float64(lastRate)) / float64(lastRate)
+		if deviation > f.DeviationThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedOnce gets the current rates and submits them if needed
+// it returns the txID, or empty string if rates weren't submitted
+func (f *ExchangeRateFeeder) FeedOnce(now time.Time) (string, error) {
+	rates, err := f.GetRates()
+	if err != nil {
+		return "", err
+	}
+	if !f.shouldSubmit(rates, now) {
+		return "", nil
+	}
+
+	txID, err := f.submitFn(rates)
+	if err != nil {
+		return "", fmt.Errorf("Can not submit exchange rates %v\n", err)
+	}
+	for tokenID, rate := range rates {
+		f.lastRates[tokenID] = rate
+	}
+	f.lastSubmitTime = now
+	return txID, nil
+}
+
+// Run feeds rates until stopCh is closed, it checks prices every pollInterval
+func (f *ExchangeRateFeeder) Run(stopCh <-chan struct{}, pollInterval time.Duration) {
+	for {
+		txID, err := f.FeedOnce(time.Now())
+		if err != nil {
+			fmt.Printf("Feed exchange rates error: %v\n", err)
+		} else if txID != "" {
+			fmt.Printf("Submitted exchange rates - TxID %v\n", txID)
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
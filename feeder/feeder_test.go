@@ -0,0 +1,119 @@
+package feeder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+type failedPriceSource struct{}
+
+func (s *failedPriceSource) Name() string { return "failed" }
+
+func (s *failedPriceSource) GetPrices(tokenIDs []string) (map[string]float64, error) {
+	return nil, errors.New("source is down")
+}
+
+func TestAggregatePrices(t *testing.T) {
+	price, err := AggregatePrices([]float64{100, 101, 99, 1000}, 0.1, 1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, float64(100), price)
+
+	price, err = AggregatePrices([]float64{100, 102}, 0.1, 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, float64(101), price)
+
+	// 2 of 3 prices are outliers
+	_, err = AggregatePrices([]float64{100, 200, 400}, 0.1, 2)
+	assert.NotEqual(t, nil, err)
+
+	_, err = AggregatePrices([]float64{}, 0.1, 1)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestConvertToUSDTUnit(t *testing.T) {
+	rate, err := ConvertToUSDTUnit(8000.1234567)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(8000123457), rate)
+
+	_, err = ConvertToUSDTUnit(0)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParsePriceField(t *testing.T) {
+	price, err := parsePriceField([]byte(`{"symbol":"BTCUSDT","price":"8000.50"}`), "price")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 8000.5, price)
+
+	price, err = parsePriceField([]byte(`{"usd":0.5}`), "usd")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0.5, price)
+
+	_, err = parsePriceField([]byte(`{"usd":0.5}`), "price")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestExchangeRateFeeder(t *testing.T) {
+	source1 := &StaticPriceSource{SourceName: "s1", Prices: map[string]float64{common.PortalBTCIDStr: 8000, common.PRVIDStr: 0.5}}
+	source2 := &StaticPriceSource{SourceName: "s2", Prices: map[string]float64{common.PortalBTCIDStr: 8010, common.PRVIDStr: 0.6}}
+	source3 := &StaticPriceSource{SourceName: "s3", Prices: map[string]float64{common.PortalBTCIDStr: 7990, common.PRVIDStr: 0.5}}
+
+	f := newExchangeRateFeeder(
+		[]string{common.PRVIDStr, common.PortalBTCIDStr, common.PortalBNBIDStr},
+		[]PriceSource{source1, source2, source3, &failedPriceSource{}})
+	f.SubmitInterval = time.Hour
+
+	var submitted []map[string]uint64
+	f.submitFn = func(rates map[string]uint64) (string, error) {
+		submitted = append(submitted, rates)
+		return "txID", nil
+	}
+
+	now := time.Now()
+	txID, err := f.FeedOnce(now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "txID", txID)
+	assert.Equal(t, 1, len(submitted))
+	assert.Equal(t, map[string]uint64{
+		common.PortalBTCIDStr: 8000000000,
+		common.PRVIDStr:       500000,
+	}, submitted[0])
+
+	// rates don't change, not submit until the next schedule
+	txID, err = f.FeedOnce(now.Add(time.Minute))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", txID)
+
+	// deviation threshold
+	source1.Prices[common.PortalBTCIDStr] = 9000
+	source2.Prices[common.PortalBTCIDStr] = 9000
+	txID, err = f.FeedOnce(now.Add(2 * time.Minute))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "txID", txID)
+	assert.Equal(t, uint64(9000000000), submitted[1][common.PortalBTCIDStr])
+
+	// schedule
+	txID, err = f.FeedOnce(now.Add(2*time.Minute + time.Hour))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "txID", txID)
+	assert.Equal(t, 3, len(submitted))
+}
+
+func TestNewExchangeRateFromParamOrder(t *testing.T) {
+	params := map[string]uint64{
+		common.PortalBTCIDStr: 8000000000,
+		common.PortalBNBIDStr: 40000000,
+		common.PRVIDStr:       500000,
+	}
+	for i := 0; i < 10; i++ {
+		rates, err := transaction.NewExchangeRateFromParam(params)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, common.PRVIDStr, rates[0].PTokenID)
+		assert.Equal(t, common.PortalBNBIDStr, rates[1].PTokenID)
+		assert.Equal(t, common.PortalBTCIDStr, rates[2].PTokenID)
+	}
+}
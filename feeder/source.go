@@ -0,0 +1,114 @@
+package feeder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// PriceSource provides prices in USD of tokens
+type PriceSource interface {
+	// Name returns the name of source, it's used in error messages
+	Name() string
+	// GetPrices returns price in USD of tokenIDs, tokens that the source doesn't support are omitted
+	GetPrices(tokenIDs []string) (map[string]float64, error)
+}
+
+// StaticPriceSource returns fixed prices, it's useful for testing and for tokens that have fixed price
+type StaticPriceSource struct {
+	SourceName string
+	Prices     map[string]float64
+}
+
+func (s *StaticPriceSource) Name() string {
+	return s.SourceName
+}
+
+func (s *StaticPriceSource) GetPrices(tokenIDs []string) (map[string]float64, error) {
+	result := map[string]float64{}
+	for _, tokenID := range tokenIDs {
+		if price, ok := s.Prices[tokenID]; ok {
+			result[tokenID] = price
+		}
+	}
+	return result, nil
+}
+
+// HTTPPriceSource gets prices from a HTTP API that returns json
+// Each token is mapped to an URL and a field name of the price in the response (eg: "price" for {"price": "8000.12"})
+type HTTPPriceSource struct {
+	SourceName string
+	URLs       map[string]string // tokenID : url
+	PriceField string
+	client     *http.Client
+}
+
+func NewHTTPPriceSource(name string, urls map[string]string, priceField string) *HTTPPriceSource {
+	return &HTTPPriceSource{
+		SourceName: name,
+		URLs:       urls,
+		PriceField: priceField,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *HTTPPriceSource) Name() string {
+	return s.SourceName
+}
+
+func (s *HTTPPriceSource) GetPrices(tokenIDs []string) (map[string]float64, error) {
+	result := map[string]float64{}
+	for _, tokenID := range tokenIDs {
+		url, ok := s.URLs[tokenID]
+		if !ok {
+			continue
+		}
+		price, err := s.getPrice(url)
+		if err != nil {
+			return nil, fmt.Errorf("Can not get price of token %v from %v: %v", tokenID, s.SourceName, err)
+		}
+		result[tokenID] = price
+	}
+	return result, nil
+}
+
+func (s *HTTPPriceSource) getPrice(url string) (float64, error) {
+	resp, err := s.client.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return parsePriceField(body, s.PriceField)
+}
+
+// parsePriceField parses price in field of json object, price can be a number or a string
+func parsePriceField(data []byte, field string) (float64, error) {
+	var obj map[string]interface{}
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := obj[field]
+	if !ok {
+		return 0, fmt.Errorf("field %v not found", field)
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, errors.New("price is not a number")
+	}
+}
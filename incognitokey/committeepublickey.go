@@ -0,0 +1,107 @@
+package incognitokey
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/google"
+)
+
+const (
+	// BlsConsensus is the key of BLS public key in MiningPubKey
+	BlsConsensus = "bls"
+	// BridgeConsensus is the key of ECDSA public key in MiningPubKey
+	BridgeConsensus = "dsa"
+)
+
+// CommitteePublicKey is public key of a validator candidate
+// - IncPubKey is ed25519 public key of candidate account (public key in payment address)
+// - MiningPubKey contains public keys that are used to sign blocks (BLS) and bridge instructions (ECDSA)
+type CommitteePublicKey struct {
+	IncPubKey    []byte
+	MiningPubKey map[string][]byte
+}
+
+// NewCommitteeKeyFromSeed generates committee public key from mining seed and ed25519 public key of candidate
+func NewCommitteeKeyFromSeed(seed, incPubKey []byte) (*CommitteePublicKey, error) {
+	if len(seed) == 0 {
+		return nil, errors.New("mining seed is empty")
+	}
+	if len(incPubKey) != common.PublicKeySize {
+		return nil, errors.New("invalid size of public key")
+	}
+
+	_, blsPubKey := blsKeyGen(seed)
+	briPrivKey, err := briKeyGen(seed)
+	if err != nil {
+		return nil, err
+	}
+	committeePubKey := &CommitteePublicKey{
+		IncPubKey: incPubKey,
+		MiningPubKey: map[string][]byte{
+			BlsConsensus:    blsPubKey.Marshal(),
+			BridgeConsensus: ethcrypto.CompressPubkey(&briPrivKey.PublicKey),
+		},
+	}
+	return committeePubKey, nil
+}
+
+// ToBase58 returns committee public key in base58 check encoding of its json data
+func (pubKey *CommitteePublicKey) ToBase58() (string, error) {
+	if pubKey == nil {
+		return "", errors.New("committee public key is nil")
+	}
+	result, err := json.Marshal(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return base58.Base58Check{}.Encode(result, common.Base58Version), nil
+}
+
+// FromBase58 parses committee public key from base58 check encoding string
+func (pubKey *CommitteePublicKey) FromBase58(keyStr string) error {
+	data, _, err := base58.Base58Check{}.Decode(keyStr)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, pubKey)
+}
+
+// GetMiningSeed returns the seed that is used to generate mining keys of the account
+// this is the mining key that validator node runs with (in base58 check encoding)
+func (keySet *KeySet) GetMiningSeed() ([]byte, error) {
+	if len(keySet.PrivateKey) != common.PrivateKeySize {
+		return nil, errors.New("invalid size of private key")
+	}
+	return common.HashB(common.HashB(keySet.PrivateKey)), nil
+}
+
+// GetCommitteePublicKey returns committee public key of the account,
+// it is used to stake the account as a validator
+func (keySet *KeySet) GetCommitteePublicKey() (*CommitteePublicKey, error) {
+	miningSeed, err := keySet.GetMiningSeed()
+	if err != nil {
+		return nil, err
+	}
+	return NewCommitteeKeyFromSeed(miningSeed, keySet.PaymentAddress.Pk)
+}
+
+// blsKeyGen generates BLS secret key and public key from seed as validator nodes do,
+// the public key is in G2 group of alt_bn128, the curve of BLS signatures of the chain
+func blsKeyGen(seed []byte) (*big.Int, *bn256.G2) {
+	sk := new(big.Int).SetBytes(common.HashB(seed))
+	for sk.Cmp(bn256.Order) >= 0 {
+		sk.SetBytes(common.Hash4Bls(sk.Bytes()))
+	}
+	return sk, new(bn256.G2).ScalarBaseMult(sk)
+}
+
+// briKeyGen generates ECDSA key (on secp256k1) that signs bridge instructions from seed as validator nodes do
+func briKeyGen(seed []byte) (*ecdsa.PrivateKey, error) {
+	return ethcrypto.ToECDSA(common.HashB(seed))
+}
@@ -0,0 +1,71 @@
+package incognitokey
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/google"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCommitteePublicKey(t *testing.T) {
+	keySet := new(KeySet).GenerateKey([]byte("committee public key seed"))
+
+	committeePubKey, err := keySet.GetCommitteePublicKey()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte(keySet.PaymentAddress.Pk), committeePubKey.IncPubKey)
+	assert.Equal(t, common.BLSPublicKeySize, len(committeePubKey.MiningPubKey[BlsConsensus]))
+	assert.Equal(t, common.BriPublicKeySize, len(committeePubKey.MiningPubKey[BridgeConsensus]))
+
+	// committee public key is deterministic
+	committeePubKey2, err := keySet.GetCommitteePublicKey()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, committeePubKey, committeePubKey2)
+
+	keyStr, err := committeePubKey.ToBase58()
+	assert.Equal(t, nil, err)
+
+	parsedKey := new(CommitteePublicKey)
+	err = parsedKey.FromBase58(keyStr)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, committeePubKey, parsedKey)
+
+	// different accounts have different mining keys
+	otherKeySet := new(KeySet).GenerateKey([]byte("other seed"))
+	otherCommitteePubKey, err := otherKeySet.GetCommitteePublicKey()
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, committeePubKey.MiningPubKey[BlsConsensus], otherCommitteePubKey.MiningPubKey[BlsConsensus])
+}
+
+func TestNewCommitteeKeyFromSeedInvalid(t *testing.T) {
+	_, err := NewCommitteeKeyFromSeed([]byte{}, make([]byte, common.PublicKeySize))
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCommitteeKeyFromSeed([]byte{1}, make([]byte, 10))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCommitteeKeyCurves(t *testing.T) {
+	// generator of G2 of alt_bn128 in EIP-197, x and y are in Fp2 with imaginary part first
+	g2Generator, _ := hex.DecodeString(
+		"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" +
+			"1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" +
+			"12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa")
+	assert.Equal(t, g2Generator, new(bn256.G2).ScalarBaseMult(big.NewInt(1)).Marshal())
+
+	// generator of secp256k1 in SEC 2, compressed
+	briPrivKey, err := ethcrypto.ToECDSA(common.AddPaddingBigInt(big.NewInt(1), common.BigIntSize))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		hex.EncodeToString(ethcrypto.CompressPubkey(&briPrivKey.PublicKey)))
+
+	// BLS secret key is less than the order of alt_bn128
+	for _, seed := range [][]byte{{}, []byte("mining seed"), common.HashB([]byte("mining seed"))} {
+		sk, _ := blsKeyGen(seed)
+		assert.Equal(t, -1, sk.Cmp(bn256.Order))
+	}
+}
@@ -51,4 +51,23 @@ func (keySet *KeySet) InitFromPrivateKey(privateKey *crypto.PrivateKey) error {
 	keySet.ReadonlyKey = crypto.GenerateViewingKey(keySet.PrivateKey[:])
 
 	return nil
-}
\ No newline at end of file
+}
+
+// InitFromSecureKey copies private key held by key into key set,
+// and regenerates payment address and readonly key
+// the copy is zeroed by Zero
+func (keySet *KeySet) InitFromSecureKey(key *crypto.SecureKey) error {
+	return key.Use(func(privateKey []byte) error {
+		if len(privateKey) != common.PrivateKeySize {
+			return errors.New("invalid size of private key")
+		}
+		return keySet.InitFromPrivateKeyByte(append(crypto.PrivateKey{}, privateKey...))
+	})
+}
+
+// Zero overwrites private key and receiving key with zeros
+// key set can not be used to spend or decrypt coins afterward
+func (keySet *KeySet) Zero() {
+	keySet.PrivateKey.Zero()
+	keySet.ReadonlyKey.Rk.Zero()
+}
@@ -0,0 +1,85 @@
+package metadata
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+)
+
+// ethAddressLength is length of Ethereum address in bytes
+const ethAddressLength = 20
+
+// BurningRequest - burn pTokens to withdraw corresponding tokens on Ethereum
+// metadata - burner creates a privacy token tx burning tokens with this metadata
+type BurningRequest struct {
+	BurnerAddress crypto.PaymentAddress
+	BurningAmount uint64 // must be equal to vout value
+	TokenID       common.Hash
+	TokenName     string
+	RemoteAddress string // Ethereum address in hex without 0x prefix
+	MetadataBase
+}
+
+func NewBurningRequest(
+	burnerAddress crypto.PaymentAddress,
+	burningAmount uint64,
+	tokenID common.Hash,
+	tokenName string,
+	remoteAddress string,
+	metaType int,
+) (*BurningRequest, error) {
+	if metaType != BurningRequestMeta && metaType != BurningForDepositToSCRequestMeta {
+		return nil, errors.New("invalid burning request type")
+	}
+	if burningAmount == 0 {
+		return nil, errors.New("burning amount must be greater than zero")
+	}
+	remoteAddress, err := NormalizeETHAddress(remoteAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	burningReq := &BurningRequest{
+		BurnerAddress: burnerAddress,
+		BurningAmount: burningAmount,
+		TokenID:       tokenID,
+		TokenName:     tokenName,
+		RemoteAddress: remoteAddress,
+	}
+	burningReq.MetadataBase = metadataBase
+	return burningReq, nil
+}
+
+// NormalizeETHAddress validates Ethereum address and returns it in hex without 0x prefix
+func NormalizeETHAddress(address string) (string, error) {
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+	addressBytes, err := hex.DecodeString(address)
+	if err != nil || len(addressBytes) != ethAddressLength {
+		return "", errors.New("invalid Ethereum address")
+	}
+	return address, nil
+}
+
+func (bReq BurningRequest) Hash() *common.Hash {
+	record := bReq.MetadataBase.Hash().String()
+	record += bReq.BurnerAddress.String()
+	record += bReq.TokenID.String()
+	// burning amount is appended as a rune to be the same as Incognito chain
+	record += uintToRuneString(bReq.BurningAmount)
+	record += bReq.TokenName
+	record += bReq.RemoteAddress
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (bReq *BurningRequest) CalculateSize() uint64 {
+	return calculateSize(bReq)
+}
@@ -0,0 +1,31 @@
+package metadata
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// hashes are computed by the hash of burning requests of Incognito chain,
+// which appends string(BurningAmount) to the record
+func TestBurningRequestHash(t *testing.T) {
+	burnerAddress := crypto.PaymentAddress{Pk: bytes.Repeat([]byte{1}, 32), Tk: bytes.Repeat([]byte{2}, 32)}
+	data := []struct {
+		amount uint64
+		hash   string
+	}{
+		{1000000, "4106b67cec0b7ccd0b086559d101239b1a074328f578b25d99bc1419a4f0598b"},
+		// larger than max rune
+		{5000000000, "fc1658c0dd046fdc83def78bf2e00bab86531de8bf8f4ab5e612c35f764f420a"},
+	}
+
+	for _, item := range data {
+		meta, err := NewBurningRequest(burnerAddress, item.amount, common.Hash{3}, "pETH", "0x"+strings.Repeat("ab", 20), BurningRequestMeta)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, item.hash, meta.Hash().String())
+	}
+}
@@ -1,6 +1,9 @@
 package metadata
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
 
 func calculateSize(meta Metadata) uint64 {
 	metaBytes, err := json.Marshal(meta)
@@ -10,6 +13,15 @@ func calculateSize(meta Metadata) uint64 {
 	return uint64(len(metaBytes))
 }
 
+// uintToRuneString returns the same result as string(v) conversion that Incognito chain uses in metadata hashes
+// values that are not valid runes are converted to "\uFFFD"
+func uintToRuneString(v uint64) string {
+	if v > utf8.MaxRune {
+		return string(utf8.RuneError)
+	}
+	return string(rune(v))
+}
+
 //func ParseMetadata(meta interface{}) (Metadata, error) {
 //	if meta == nil {
 //		return nil, nil
@@ -3,12 +3,12 @@ package metadata
 const (
 	InvalidMeta = 1
 
-	//IssuingRequestMeta     = 24
-	//IssuingResponseMeta    = 25
+	IssuingRequestMeta     = 24
+	IssuingResponseMeta    = 25
 	//ContractingRequestMeta = 26
-	//BurningRequestMeta     = 27
-	//IssuingETHRequestMeta  = 80
-	//IssuingETHResponseMeta = 81
+	BurningRequestMeta     = 27
+	IssuingETHRequestMeta  = 80
+	IssuingETHResponseMeta = 81
 	//
 	//ShardBlockReward             = 36
 	//AcceptedBlockRewardInfoMeta  = 37
@@ -18,18 +18,18 @@ const (
 	//ReturnStakingMeta            = 41
 	//IncDAORewardRequestMeta      = 42
 	//ShardBlockRewardRequestMeta  = 43
-	//WithDrawRewardRequestMeta    = 44
-	//WithDrawRewardResponseMeta   = 45
+	WithDrawRewardRequestMeta    = 44
+	WithDrawRewardResponseMeta   = 45
 	//
-	////statking
-	//ShardStakingMeta    = 63
-	//StopAutoStakingMeta = 127
-	//BeaconStakingMeta   = 64
+	// staking
+	ShardStakingMeta    = 63
+	StopAutoStakingMeta = 127
+	BeaconStakingMeta   = 64
 	//
 	//// Incognito -> Ethereum bridge
 	//BeaconSwapConfirmMeta = 70
 	//BridgeSwapConfirmMeta = 71
-	//BurningConfirmMeta    = 72
+	BurningConfirmMeta    = 72
 	//
 	//// pde
 	//PDEContributionMeta         = 90
@@ -76,9 +76,9 @@ const (
 	RelayingBNBHeaderMeta = 200
 	RelayingBTCHeaderMeta = 201
 
-	//// incognito mode for smart contract
-	//BurningForDepositToSCRequestMeta = 96
-	//BurningConfirmForDepositToSCMeta = 97
+	// incognito mode for smart contract
+	BurningForDepositToSCRequestMeta = 96
+	BurningConfirmForDepositToSCMeta = 97
 )
 
 //var minerCreatedMetaTypes = []int{
@@ -120,9 +120,12 @@ const (
 //	EthereumLightNodeProtocol = "http"
 //	EthereumLightNodePort     = "8545"
 //)
-//const (
-//	StopAutoStakingAmount = 0
-//	ETHConfirmationBlocks = 15
-//)
+const (
+	StopAutoStakingAmount = 0
+	ETHConfirmationBlocks = 15
+)
+
+// ShardStakingAmount is the amount of PRV (in nano) need to be burned to stake a shard validator
+const ShardStakingAmount = 1750 * 1e9
 
-//var AcceptedWithdrawRewardRequestVersion = []int{0, 1}
+var AcceptedWithdrawRewardRequestVersion = []int{0, 1}
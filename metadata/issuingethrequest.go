@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+)
+
+// ETHHashLength is length of Ethereum hash in bytes
+const ETHHashLength = 32
+
+// ETHHash is hash of Ethereum block or tx, it is encoded in hex with 0x prefix
+// the same as common.Hash of go-ethereum
+type ETHHash [ETHHashLength]byte
+
+// NewETHHashFromHex parses Ethereum hash from hex string (with or without 0x prefix)
+func NewETHHashFromHex(hashStr string) (ETHHash, error) {
+	var h ETHHash
+	hashStr = strings.TrimPrefix(strings.TrimPrefix(hashStr, "0x"), "0X")
+	b, err := hex.DecodeString(hashStr)
+	if err != nil || len(b) != ETHHashLength {
+		return h, errors.New("invalid Ethereum hash")
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+func (h ETHHash) String() string {
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+func (h ETHHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *ETHHash) UnmarshalText(text []byte) error {
+	hash, err := NewETHHashFromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*h = hash
+	return nil
+}
+
+// IssuingETHRequest - shield tokens deposited to Incognito Vault contract on Ethereum
+// metadata - depositor creates a normal tx with this metadata
+// ProofStrs are nodes (in base64) of Merkle-Patricia proof of deposit tx receipt in block BlockHash at TxIndex
+type IssuingETHRequest struct {
+	BlockHash  ETHHash
+	TxIndex    uint
+	ProofStrs  []string
+	IncTokenID common.Hash
+	MetadataBase
+}
+
+func NewIssuingETHRequest(
+	blockHash ETHHash,
+	txIndex uint,
+	proofStrs []string,
+	incTokenID common.Hash,
+	metaType int,
+) (*IssuingETHRequest, error) {
+	if metaType != IssuingETHRequestMeta {
+		return nil, errors.New("invalid issuing eth request type")
+	}
+	if len(proofStrs) == 0 {
+		return nil, errors.New("receipt proof is empty")
+	}
+	for _, proofStr := range proofStrs {
+		_, err := base64.StdEncoding.DecodeString(proofStr)
+		if err != nil {
+			return nil, errors.New("receipt proof must be encoded in base64")
+		}
+	}
+
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	issuingETHReq := &IssuingETHRequest{
+		BlockHash:  blockHash,
+		TxIndex:    txIndex,
+		ProofStrs:  proofStrs,
+		IncTokenID: incTokenID,
+	}
+	issuingETHReq.MetadataBase = metadataBase
+	return issuingETHReq, nil
+}
+
+// NewIssuingETHRequestFromProofNodes creates IssuingETHRequest from raw nodes of receipt proof
+// that are produced by a Merkle-Patricia proof generator
+func NewIssuingETHRequestFromProofNodes(
+	blockHash ETHHash,
+	txIndex uint,
+	proofNodes [][]byte,
+	incTokenID common.Hash,
+	metaType int,
+) (*IssuingETHRequest, error) {
+	proofStrs := make([]string, len(proofNodes))
+	for i, node := range proofNodes {
+		proofStrs[i] = base64.StdEncoding.EncodeToString(node)
+	}
+	return NewIssuingETHRequest(blockHash, txIndex, proofStrs, incTokenID, metaType)
+}
+
+func (iReq IssuingETHRequest) Hash() *common.Hash {
+	record := iReq.BlockHash.String()
+	// tx index is appended as a rune to be the same as Incognito chain
+	record += uintToRuneString(uint64(iReq.TxIndex))
+	for _, proofStr := range iReq.ProofStrs {
+		record += proofStr
+	}
+	record += iReq.MetadataBase.Hash().String()
+	record += iReq.IncTokenID.String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iReq *IssuingETHRequest) CalculateSize() uint64 {
+	return calculateSize(iReq)
+}
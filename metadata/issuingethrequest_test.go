@@ -0,0 +1,56 @@
+package metadata
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETHHashJSON(t *testing.T) {
+	hashStr := "0x" + strings.Repeat("ab", 32)
+	h, err := NewETHHashFromHex(hashStr)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, hashStr, h.String())
+
+	data, err := json.Marshal(h)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `"`+hashStr+`"`, string(data))
+
+	var h2 ETHHash
+	err = json.Unmarshal(data, &h2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, h, h2)
+
+	_, err = NewETHHashFromHex("0x1234")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestNewIssuingETHRequest(t *testing.T) {
+	blockHash, _ := NewETHHashFromHex(strings.Repeat("01", 32))
+	tokenID := common.Hash{1}
+
+	meta, err := NewIssuingETHRequestFromProofNodes(blockHash, 5, [][]byte{{1, 2, 3}, {4, 5}}, tokenID, IssuingETHRequestMeta)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"AQID", "BAU="}, meta.ProofStrs)
+	assert.Equal(t, IssuingETHRequestMeta, meta.GetType())
+
+	// hash changes with tx index
+	meta2, _ := NewIssuingETHRequest(blockHash, 6, meta.ProofStrs, tokenID, IssuingETHRequestMeta)
+	assert.NotEqual(t, meta.Hash(), meta2.Hash())
+
+	_, err = NewIssuingETHRequest(blockHash, 5, []string{"not base64!"}, tokenID, IssuingETHRequestMeta)
+	assert.NotEqual(t, nil, err)
+	_, err = NewIssuingETHRequest(blockHash, 5, []string{}, tokenID, IssuingETHRequestMeta)
+	assert.NotEqual(t, nil, err)
+	_, err = NewIssuingETHRequest(blockHash, 5, meta.ProofStrs, tokenID, IssuingRequestMeta)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestUintToRuneString(t *testing.T) {
+	assert.Equal(t, "A", uintToRuneString(65))
+	assert.Equal(t, "�", uintToRuneString(1e9))
+	assert.Equal(t, "�", uintToRuneString(1<<32+65))
+}
@@ -0,0 +1,62 @@
+package metadata
+
+import (
+	"errors"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+)
+
+// IssuingRequest - shield tokens deposited to a centralized bridge (eg: BNB)
+// metadata - centralized bridge creates a normal tx with this metadata to mint pTokens for receiver
+type IssuingRequest struct {
+	ReceiverAddress crypto.PaymentAddress
+	DepositedAmount uint64
+	TokenID         common.Hash
+	TokenName       string
+	MetadataBase
+}
+
+func NewIssuingRequest(
+	receiverAddress crypto.PaymentAddress,
+	depositedAmount uint64,
+	tokenID common.Hash,
+	tokenName string,
+	metaType int,
+) (*IssuingRequest, error) {
+	if metaType != IssuingRequestMeta {
+		return nil, errors.New("invalid issuing request type")
+	}
+	if depositedAmount == 0 {
+		return nil, errors.New("deposited amount must be greater than zero")
+	}
+
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	issuingReq := &IssuingRequest{
+		ReceiverAddress: receiverAddress,
+		DepositedAmount: depositedAmount,
+		TokenID:         tokenID,
+		TokenName:       tokenName,
+	}
+	issuingReq.MetadataBase = metadataBase
+	return issuingReq, nil
+}
+
+func (iReq IssuingRequest) Hash() *common.Hash {
+	record := iReq.ReceiverAddress.String()
+	record += iReq.TokenID.String()
+	// deposited amount is appended as a rune to be the same as Incognito chain
+	record += uintToRuneString(iReq.DepositedAmount)
+	record += iReq.TokenName
+	record += iReq.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iReq *IssuingRequest) CalculateSize() uint64 {
+	return calculateSize(iReq)
+}
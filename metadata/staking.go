@@ -0,0 +1,91 @@
+package metadata
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+)
+
+// StakingMetadata - stake a candidate to become a shard or beacon validator
+// metadata - funder creates a tx burning staking amount with this metadata
+type StakingMetadata struct {
+	MetadataBase
+	FunderPaymentAddress         string
+	RewardReceiverPaymentAddress string
+	StakingAmountShard           uint64
+	AutoReStaking                bool
+	CommitteePublicKey           string
+	// CommitteePublicKey PublicKeys of a candidate who join consensus, base58CheckEncode
+	// CommitteePublicKey string <= encode byte <= mashal struct
+}
+
+func NewStakingMetadata(
+	stakingType int,
+	funderPaymentAddress string,
+	rewardReceiverPaymentAddress string,
+	stakingAmountShard uint64,
+	committeePublicKey string,
+	autoReStaking bool,
+) (*StakingMetadata, error) {
+	if stakingType != ShardStakingMeta && stakingType != BeaconStakingMeta {
+		return nil, errors.New("invalid staking type")
+	}
+	metadataBase := NewMetadataBase(stakingType)
+	return &StakingMetadata{
+		MetadataBase:                 *metadataBase,
+		FunderPaymentAddress:         funderPaymentAddress,
+		RewardReceiverPaymentAddress: rewardReceiverPaymentAddress,
+		StakingAmountShard:           stakingAmountShard,
+		CommitteePublicKey:           committeePublicKey,
+		AutoReStaking:                autoReStaking,
+	}, nil
+}
+
+func (sm StakingMetadata) Hash() *common.Hash {
+	record := sm.MetadataBase.Hash().String()
+	record += sm.FunderPaymentAddress
+	record += sm.RewardReceiverPaymentAddress
+	record += strconv.FormatUint(sm.StakingAmountShard, 10)
+	record += strconv.FormatBool(sm.AutoReStaking)
+	record += sm.CommitteePublicKey
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sm *StakingMetadata) CalculateSize() uint64 {
+	return calculateSize(sm)
+}
+
+// StopAutoStakingMetadata - stop auto re-staking of a candidate
+// metadata - candidate's funder creates a tx burning StopAutoStakingAmount with this metadata
+type StopAutoStakingMetadata struct {
+	MetadataBase
+	CommitteePublicKey string
+}
+
+func NewStopAutoStakingMetadata(stopStakingType int, committeePublicKey string) (*StopAutoStakingMetadata, error) {
+	if stopStakingType != StopAutoStakingMeta {
+		return nil, errors.New("invalid stop staking type")
+	}
+	metadataBase := NewMetadataBase(stopStakingType)
+	return &StopAutoStakingMetadata{
+		MetadataBase:       *metadataBase,
+		CommitteePublicKey: committeePublicKey,
+	}, nil
+}
+
+func (sm StopAutoStakingMetadata) Hash() *common.Hash {
+	record := sm.MetadataBase.Hash().String()
+	record += sm.CommitteePublicKey
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sm *StopAutoStakingMetadata) CalculateSize() uint64 {
+	return calculateSize(sm)
+}
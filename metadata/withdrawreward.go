@@ -0,0 +1,60 @@
+package metadata
+
+import (
+	"errors"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+)
+
+// WithDrawRewardRequest - withdraw block rewards of a payment address
+// metadata - reward receiver creates a tx with this metadata
+type WithDrawRewardRequest struct {
+	MetadataBase
+	PaymentAddress crypto.PaymentAddress
+	TokenID        common.Hash
+	Version        int
+}
+
+func NewWithDrawRewardRequest(
+	paymentAddress crypto.PaymentAddress,
+	tokenIDStr string,
+	version int,
+	metaType int,
+) (*WithDrawRewardRequest, error) {
+	tokenID, err := new(common.Hash).NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, errors.New("token ID is invalid")
+	}
+	isAccepted := false
+	for _, v := range AcceptedWithdrawRewardRequestVersion {
+		if version == v {
+			isAccepted = true
+			break
+		}
+	}
+	if !isAccepted {
+		return nil, errors.New("version of withdraw reward request is invalid")
+	}
+
+	metadataBase := NewMetadataBase(metaType)
+	return &WithDrawRewardRequest{
+		MetadataBase:   *metadataBase,
+		PaymentAddress: paymentAddress,
+		TokenID:        *tokenID,
+		Version:        version,
+	}, nil
+}
+
+func (withDrawRewardRequest WithDrawRewardRequest) Hash() *common.Hash {
+	if withDrawRewardRequest.Version == 1 {
+		bArr := append(withDrawRewardRequest.PaymentAddress.Bytes(), withDrawRewardRequest.TokenID.GetBytes()...)
+		txReqHash := common.HashH(bArr)
+		return &txReqHash
+	}
+	return withDrawRewardRequest.MetadataBase.Hash()
+}
+
+func (withDrawRewardRequest *WithDrawRewardRequest) CalculateSize() uint64 {
+	return calculateSize(withDrawRewardRequest)
+}
@@ -0,0 +1,267 @@
+package relayer
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xkraken/incognito-sdk-golang/metadata"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/transaction"
+)
+
+const (
+	defaultNumParallel   = 1
+	defaultMaxRetries    = 3
+	defaultRetryInterval = 10 * time.Second
+)
+
+// HeaderRelayer gets headers from a HeaderSource and relays them to Incognito chain in height order
+// Heights that were relayed are skipped, rejected headers are re-sent up to MaxRetries times
+type HeaderRelayer struct {
+	// NumParallel is the maximum number of relaying txs that are sent at the same time
+	// the relayer splits utxos of the relayer account to have enough utxos for these txs
+	NumParallel   int
+	MaxRetries    int
+	RetryInterval time.Duration
+
+	source     HeaderSource
+	nextHeight int64
+	relayed    map[int64]string // height : txID
+	mux        sync.Mutex
+
+	relayFn      func(header *Header) (string, error)
+	splitUTXOsFn func(minNumUTXOs int) error
+}
+
+// NewHeaderRelayer returns a relayer that relays headers from source with metaType RelayingBNBHeaderMeta or RelayingBTCHeaderMeta
+// startHeight is the first height need to be relayed, if it is zero, the relayer starts from the lowest height in source
+func NewHeaderRelayer(
+	rpcClient *rpcclient.HttpClient,
+	privateKeyStr string,
+	metaType int,
+	source HeaderSource,
+	startHeight int64,
+	fee uint64,
+) (*HeaderRelayer, error) {
+	var createAndSendTx func(*rpcclient.HttpClient, string, string, int64, uint64) (string, error)
+	switch metaType {
+	case metadata.RelayingBNBHeaderMeta:
+		createAndSendTx = transaction.CreateAndSendTxRelayBNBHeader
+	case metadata.RelayingBTCHeaderMeta:
+		createAndSendTx = transaction.CreateAndSendTxRelayBTCHeader
+	default:
+		return nil, fmt.Errorf("Metadata type %v is not relaying header type", metaType)
+	}
+	if source == nil {
+		return nil, errors.New("header source is nil")
+	}
+
+	r := newHeaderRelayer(source, startHeight)
+	r.relayFn = func(header *Header) (string, error) {
+		headerStr := base64.StdEncoding.EncodeToString(header.Data)
+		return createAndSendTx(rpcClient, privateKeyStr, headerStr, header.Height, fee)
+	}
+	r.splitUTXOsFn = func(minNumUTXOs int) error {
+		return transaction.SplitUTXOs(rpcClient, privateKeyStr, minNumUTXOs, fee)
+	}
+	return r, nil
+}
+
+func newHeaderRelayer(source HeaderSource, startHeight int64) *HeaderRelayer {
+	return &HeaderRelayer{
+		NumParallel:   defaultNumParallel,
+		MaxRetries:    defaultMaxRetries,
+		RetryInterval: defaultRetryInterval,
+		source:        source,
+		nextHeight:    startHeight,
+		relayed:       map[int64]string{},
+	}
+}
+
+// NextHeight returns the next height will be relayed
+func (r *HeaderRelayer) NextHeight() int64 {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.nextHeight
+}
+
+// GetRelayedTxID returns txID that relayed header at height
+func (r *HeaderRelayer) GetRelayedTxID(height int64) (string, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	txID, ok := r.relayed[height]
+	return txID, ok
+}
+
+// MarkRelayed marks heights that were relayed by other ways, they will be skipped by the relayer
+func (r *HeaderRelayer) MarkRelayed(heights ...int64) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, height := range heights {
+		if _, ok := r.relayed[height]; !ok {
+			r.relayed[height] = ""
+		}
+	}
+	r.advanceNextHeight()
+}
+
+// advanceNextHeight moves nextHeight over relayed heights, caller must hold the lock
+func (r *HeaderRelayer) advanceNextHeight() {
+	if r.nextHeight == 0 {
+		return
+	}
+	for {
+		if _, ok := r.relayed[r.nextHeight]; !ok {
+			return
+		}
+		r.nextHeight++
+	}
+}
+
+// nextBatch returns consecutive headers from nextHeight that haven't been relayed
+func (r *HeaderRelayer) nextBatch() ([]*Header, error) {
+	numParallel := r.NumParallel
+	if numParallel <= 0 {
+		numParallel = defaultNumParallel
+	}
+
+	r.mux.Lock()
+	fromHeight := r.nextHeight
+	r.mux.Unlock()
+
+	// get more headers than needed in case some heights were relayed
+	headers, err := r.source.GetHeaders(fromHeight, numParallel*2)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Height < headers[j].Height
+	})
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	batch := []*Header{}
+	expectedHeight := r.nextHeight
+	for _, header := range headers {
+		if header == nil || header.Height < r.nextHeight {
+			continue
+		}
+		if expectedHeight == 0 {
+			expectedHeight = header.Height
+		}
+		if _, ok := r.relayed[header.Height]; ok {
+			if header.Height == expectedHeight {
+				expectedHeight++
+			}
+			continue
+		}
+		if header.Height < expectedHeight {
+			// duplicated height
+			continue
+		}
+		if header.Height > expectedHeight {
+			// missing height, stop at the gap
+			break
+		}
+		batch = append(batch, header)
+		expectedHeight++
+		if len(batch) == numParallel {
+			break
+		}
+	}
+	return batch, nil
+}
+
+// RelayOnce relays the next batch of headers
+// it returns the number of headers were relayed successfully
+func (r *HeaderRelayer) RelayOnce() (int, error) {
+	batch, err := r.nextBatch()
+	if err != nil {
+		return 0, fmt.Errorf("Can not get headers from source %v\n", err)
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	// make sure the relayer account has enough utxos to send txs in parallel
+	if len(batch) > 1 && r.splitUTXOsFn != nil {
+		err = r.splitUTXOsFn(len(batch))
+		if err != nil {
+			return 0, fmt.Errorf("Can not split utxos %v\n", err)
+		}
+	}
+
+	pending := batch
+	errs := map[int64]error{}
+	for attempt := 0; attempt <= r.MaxRetries && len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			time.Sleep(r.RetryInterval)
+		}
+
+		txIDs := make([]string, len(pending))
+		sendErrs := make([]error, len(pending))
+		var wg sync.WaitGroup
+		for i, header := range pending {
+			wg.Add(1)
+			go func(i int, header *Header) {
+				defer wg.Done()
+				txIDs[i], sendErrs[i] = r.relayFn(header)
+			}(i, header)
+		}
+		wg.Wait()
+
+		rejected := []*Header{}
+		r.mux.Lock()
+		for i, header := range pending {
+			if sendErrs[i] != nil {
+				errs[header.Height] = sendErrs[i]
+				rejected = append(rejected, header)
+				continue
+			}
+			delete(errs, header.Height)
+			r.relayed[header.Height] = txIDs[i]
+		}
+		if r.nextHeight == 0 {
+			r.nextHeight = batch[0].Height
+		}
+		r.advanceNextHeight()
+		r.mux.Unlock()
+
+		pending = rejected
+	}
+
+	numRelayed := len(batch) - len(pending)
+	if len(pending) > 0 {
+		return numRelayed, fmt.Errorf("Can not relay header at height %v: %v\n", pending[0].Height, errs[pending[0].Height])
+	}
+	return numRelayed, nil
+}
+
+// Run relays headers until stopCh is closed, it waits interval when there is no new header
+func (r *HeaderRelayer) Run(stopCh <-chan struct{}, interval time.Duration) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		numRelayed, err := r.RelayOnce()
+		if err != nil {
+			fmt.Printf("Relay header error: %v\n", err)
+		} else if numRelayed > 0 {
+			fmt.Printf("Relayed %v headers, next height %v\n", numRelayed, r.NextHeight())
+			continue
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
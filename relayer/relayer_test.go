@@ -0,0 +1,138 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeHeaderFiles(t *testing.T, heights ...int64) string {
+	dir, err := ioutil.TempDir("", "headers")
+	assert.Equal(t, nil, err)
+	for _, height := range heights {
+		name := filepath.Join(dir, fmt.Sprintf("%v.json", height))
+		err = ioutil.WriteFile(name, []byte(fmt.Sprintf("header-%v", height)), 0644)
+		assert.Equal(t, nil, err)
+	}
+	return dir
+}
+
+func TestFileHeaderSource(t *testing.T) {
+	dir := writeHeaderFiles(t, 103, 101, 102, 100)
+	defer os.RemoveAll(dir)
+	err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a header"), 0644)
+	assert.Equal(t, nil, err)
+
+	source := NewFileHeaderSource(dir)
+	headers, err := source.GetHeaders(101, 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(headers))
+	assert.Equal(t, int64(101), headers[0].Height)
+	assert.Equal(t, []byte("header-101"), headers[0].Data)
+	assert.Equal(t, int64(102), headers[1].Height)
+
+	_, err = source.GetHeaders(0, 0)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestHeaderRelayerRelayOnce(t *testing.T) {
+	dir := writeHeaderFiles(t, 100, 101, 102, 103, 105)
+	defer os.RemoveAll(dir)
+
+	r := newHeaderRelayer(NewFileHeaderSource(dir), 0)
+	r.NumParallel = 2
+	r.RetryInterval = 0
+
+	var mux sync.Mutex
+	sent := map[int64]int{}
+	numSplits := 0
+	r.relayFn = func(header *Header) (string, error) {
+		mux.Lock()
+		defer mux.Unlock()
+		sent[header.Height]++
+		// reject the first time header 102 is sent
+		if header.Height == 102 && sent[header.Height] == 1 {
+			return "", errors.New("rejected")
+		}
+		return fmt.Sprintf("tx-%v", header.Height), nil
+	}
+	r.splitUTXOsFn = func(minNumUTXOs int) error {
+		numSplits++
+		assert.Equal(t, 2, minNumUTXOs)
+		return nil
+	}
+
+	// skip height 101, it was relayed before
+	r.MarkRelayed(101)
+
+	numRelayed, err := r.RelayOnce()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, numRelayed)
+	assert.Equal(t, int64(103), r.NextHeight())
+	assert.Equal(t, 2, sent[102])
+	assert.Equal(t, 0, sent[101])
+	assert.Equal(t, 1, numSplits)
+
+	numRelayed, err = r.RelayOnce()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, numRelayed)
+	assert.Equal(t, int64(104), r.NextHeight())
+	assert.Equal(t, 1, numSplits)
+
+	txID, ok := r.GetRelayedTxID(103)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "tx-103", txID)
+
+	// height 104 is missing, so 105 can not be relayed
+	numRelayed, err = r.RelayOnce()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, numRelayed)
+	assert.Equal(t, 0, sent[105])
+}
+
+func TestHeaderRelayerRetryFailed(t *testing.T) {
+	dir := writeHeaderFiles(t, 10, 11)
+	defer os.RemoveAll(dir)
+
+	r := newHeaderRelayer(NewFileHeaderSource(dir), 10)
+	r.NumParallel = 2
+	r.MaxRetries = 2
+	r.RetryInterval = 0
+
+	var mux sync.Mutex
+	numSent := 0
+	r.relayFn = func(header *Header) (string, error) {
+		mux.Lock()
+		defer mux.Unlock()
+		if header.Height == 10 {
+			numSent++
+			return "", errors.New("rejected")
+		}
+		return "tx-11", nil
+	}
+
+	numRelayed, err := r.RelayOnce()
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 1, numRelayed)
+	assert.Equal(t, 3, numSent)
+	assert.Equal(t, int64(10), r.NextHeight())
+
+	// header 11 won't be sent again
+	_, ok := r.GetRelayedTxID(11)
+	assert.Equal(t, true, ok)
+	batch, err := r.nextBatch()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(batch))
+	assert.Equal(t, int64(10), batch[0].Height)
+}
+
+func TestNewHeaderRelayerInvalidMetaType(t *testing.T) {
+	_, err := NewHeaderRelayer(nil, "", 0, NewFileHeaderSource(""), 0, 10)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,84 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Header is a raw header block of an external chain (BNB, BTC) at a height
+type Header struct {
+	Height int64
+	Data   []byte
+}
+
+// HeaderSource provides header blocks to relay
+type HeaderSource interface {
+	// GetHeaders returns at most limit headers that have height greater than or equal to fromHeight
+	// the returned headers don't need to be sorted
+	GetHeaders(fromHeight int64, limit int) ([]*Header, error)
+}
+
+// FileHeaderSource reads headers from a directory
+// each file in directory contains raw data of one header and is named by its height (eg: 74694445.json)
+type FileHeaderSource struct {
+	Dir string
+}
+
+func NewFileHeaderSource(dir string) *FileHeaderSource {
+	return &FileHeaderSource{Dir: dir}
+}
+
+func (s *FileHeaderSource) GetHeaders(fromHeight int64, limit int) ([]*Header, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("Can not read header directory %v\n", err)
+	}
+
+	type headerFile struct {
+		height int64
+		name   string
+	}
+	headerFiles := []headerFile{}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		name := f.Name()
+		height, err := strconv.ParseInt(strings.TrimSuffix(name, filepath.Ext(name)), 10, 64)
+		if err != nil {
+			// skip files that are not named by height
+			continue
+		}
+		if height < fromHeight {
+			continue
+		}
+		headerFiles = append(headerFiles, headerFile{height: height, name: name})
+	}
+
+	sort.Slice(headerFiles, func(i, j int) bool {
+		return headerFiles[i].height < headerFiles[j].height
+	})
+	if len(headerFiles) > limit {
+		headerFiles = headerFiles[:limit]
+	}
+
+	headers := make([]*Header, 0, len(headerFiles))
+	for _, f := range headerFiles {
+		data, err := ioutil.ReadFile(filepath.Join(s.Dir, f.name))
+		if err != nil {
+			return nil, fmt.Errorf("Can not read header file %v: %v\n", f.name, err)
+		}
+		headers = append(headers, &Header{Height: f.height, Data: data})
+	}
+
+	return headers, nil
+}
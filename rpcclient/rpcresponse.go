@@ -39,4 +39,23 @@ type SendRawTxRes struct {
 type GetTxByHashRes struct {
 	RPCBaseRes
 	Result *TransactionDetail
-}
\ No newline at end of file
+}
+type GetRewardAmountRes struct {
+	RPCBaseRes
+	Result map[string]uint64
+}
+
+type SendRawTokenTxRes struct {
+	RPCBaseRes
+	Result *CreateTransactionTokenResult
+}
+
+type GetInstructionProofRes struct {
+	RPCBaseRes
+	Result *InstructionProof
+}
+
+type GetBridgeReqWithStatusRes struct {
+	RPCBaseRes
+	Result byte
+}
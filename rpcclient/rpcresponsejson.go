@@ -88,3 +88,25 @@ type TransactionDetail struct {
 
 	Info string `json:"Info"`
 }
+
+// InstructionProof is the proof of an instruction in beacon and bridge blocks (eg: getburnproof)
+// all fields are encoded in hex
+type InstructionProof struct {
+	Instruction  string // Hex-encoded swap inst
+	BeaconHeight string // Hex encoded height of the block contains the inst
+	BridgeHeight string
+
+	BeaconInstPath       []string // Hex encoded path of the inst in merkle tree
+	BeaconInstPathIsLeft []bool   // Indicate if it is the left or right node
+	BeaconInstRoot       string   // Hex encoded root of the inst merkle tree
+	BeaconBlkData        string   // Hex encoded hash of the block meta
+	BeaconSigs           []string // Hex encoded signature of beacon committee
+	BeaconSigIdxs        []int    // Idxs of signer
+
+	BridgeInstPath       []string
+	BridgeInstPathIsLeft []bool
+	BridgeInstRoot       string
+	BridgeBlkData        string
+	BridgeSigs           []string
+	BridgeSigIdxs        []int
+}
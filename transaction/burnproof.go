@@ -0,0 +1,184 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+)
+
+const (
+	hashLength   = 32
+	ethSigLength = 65 // r (32 bytes) || s (32 bytes) || v (1 byte)
+)
+
+// VaultWithdrawProof is the burn proof in the form of params of withdraw function in Incognito Vault contract on Ethereum
+// index 0 is for beacon committee, index 1 is for bridge committee
+type VaultWithdrawProof struct {
+	Instruction     []byte
+	Heights         [2]*big.Int
+	InstPaths       [2][][32]byte
+	InstPathIsLefts [2][]bool
+	InstRoots       [2][32]byte
+	BlkData         [2][32]byte
+	SigIdxs         [2][]*big.Int
+	SigVs           [2][]uint8
+	SigRs           [2][][32]byte
+	SigSs           [2][][32]byte
+}
+
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	return hex.DecodeString(s)
+}
+
+func decodeHash(s string) ([32]byte, error) {
+	var result [32]byte
+	b, err := decodeHex(s)
+	if err != nil {
+		return result, err
+	}
+	if len(b) != hashLength {
+		return result, fmt.Errorf("invalid hash length %v", len(b))
+	}
+	copy(result[:], b)
+	return result, nil
+}
+
+// decodeCommitteeProof decodes proof of an instruction in blocks of a committee (beacon or bridge)
+// and sets it to index idx of result
+func decodeCommitteeProof(
+	result *VaultWithdrawProof,
+	idx int,
+	heightStr string,
+	instPathStrs []string,
+	instPathIsLeft []bool,
+	instRootStr string,
+	blkDataStr string,
+	sigStrs []string,
+	sigIdxs []int,
+) error {
+	heightBytes, err := decodeHex(heightStr)
+	if err != nil {
+		return fmt.Errorf("invalid height: %v", err)
+	}
+	result.Heights[idx] = new(big.Int).SetBytes(heightBytes)
+
+	if len(instPathStrs) != len(instPathIsLeft) {
+		return errors.New("length of inst path and inst path is left are different")
+	}
+	result.InstPaths[idx] = make([][32]byte, len(instPathStrs))
+	for i, p := range instPathStrs {
+		result.InstPaths[idx][i], err = decodeHash(p)
+		if err != nil {
+			return fmt.Errorf("invalid inst path: %v", err)
+		}
+	}
+	result.InstPathIsLefts[idx] = instPathIsLeft
+
+	result.InstRoots[idx], err = decodeHash(instRootStr)
+	if err != nil {
+		return fmt.Errorf("invalid inst root: %v", err)
+	}
+	result.BlkData[idx], err = decodeHash(blkDataStr)
+	if err != nil {
+		return fmt.Errorf("invalid block data: %v", err)
+	}
+
+	if len(sigStrs) != len(sigIdxs) {
+		return errors.New("length of sigs and sig indices are different")
+	}
+	result.SigIdxs[idx] = make([]*big.Int, len(sigIdxs))
+	result.SigVs[idx] = make([]uint8, len(sigStrs))
+	result.SigRs[idx] = make([][32]byte, len(sigStrs))
+	result.SigSs[idx] = make([][32]byte, len(sigStrs))
+	for i, sigStr := range sigStrs {
+		sig, err := decodeHex(sigStr)
+		if err != nil || len(sig) != ethSigLength {
+			return fmt.Errorf("invalid signature at index %v", i)
+		}
+		copy(result.SigRs[idx][i][:], sig[:32])
+		copy(result.SigSs[idx][i][:], sig[32:64])
+		v := sig[64]
+		if v < 27 {
+			v += 27
+		}
+		result.SigVs[idx][i] = v
+		result.SigIdxs[idx][i] = big.NewInt(int64(sigIdxs[i]))
+	}
+
+	return nil
+}
+
+// DecodeInstructionProof converts the proof returned by Incognito RPC to params of Vault contract
+func DecodeInstructionProof(proof *rpcclient.InstructionProof) (*VaultWithdrawProof, error) {
+	if proof == nil || proof.Instruction == "" {
+		return nil, errors.New("instruction proof is empty")
+	}
+
+	result := new(VaultWithdrawProof)
+	var err error
+	result.Instruction, err = decodeHex(proof.Instruction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid instruction: %v", err)
+	}
+
+	err = decodeCommitteeProof(result, 0,
+		proof.BeaconHeight, proof.BeaconInstPath, proof.BeaconInstPathIsLeft, proof.BeaconInstRoot,
+		proof.BeaconBlkData, proof.BeaconSigs, proof.BeaconSigIdxs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid beacon proof: %v", err)
+	}
+
+	err = decodeCommitteeProof(result, 1,
+		proof.BridgeHeight, proof.BridgeInstPath, proof.BridgeInstPathIsLeft, proof.BridgeInstRoot,
+		proof.BridgeBlkData, proof.BridgeSigs, proof.BridgeSigIdxs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bridge proof: %v", err)
+	}
+
+	return result, nil
+}
+
+// GetBurnProof calls Incognito RPC to get proof of burning tx
+func GetBurnProof(rpcClient *rpcclient.HttpClient, txID string) (*rpcclient.InstructionProof, error) {
+	var burnProofRes rpcclient.GetInstructionProofRes
+	params := []interface{}{
+		txID,
+	}
+	err := rpcClient.RPCCall("getburnproof", params, &burnProofRes)
+	if err != nil {
+		return nil, err
+	}
+	if burnProofRes.RPCError != nil {
+		return nil, errors.New(burnProofRes.RPCError.Message)
+	}
+	return burnProofRes.Result, nil
+}
+
+// WaitBurnProof polls burn proof of burning tx every interval until it's available or timeout
+// it returns the proof that is ready to submit to Vault contract
+func WaitBurnProof(rpcClient *rpcclient.HttpClient, txID string, interval time.Duration, timeout time.Duration) (*VaultWithdrawProof, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		proof, err := GetBurnProof(rpcClient, txID)
+		if err == nil && proof != nil && proof.Instruction != "" {
+			return DecodeInstructionProof(proof)
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			if err == nil {
+				err = errors.New("burn proof is not available")
+			}
+			return nil, fmt.Errorf("Timeout when waiting burn proof of tx %v: %v\n", txID, err)
+		}
+		time.Sleep(interval)
+	}
+}
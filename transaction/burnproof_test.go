@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstructionProof(t *testing.T) {
+	hash1 := strings.Repeat("11", 32)
+	hash2 := strings.Repeat("22", 32)
+	sig := strings.Repeat("aa", 32) + strings.Repeat("bb", 32) + "01"
+
+	proof := &rpcclient.InstructionProof{
+		Instruction:          "48010203",
+		BeaconHeight:         "0x0186a0",
+		BridgeHeight:         "c350",
+		BeaconInstPath:       []string{hash1, hash2},
+		BeaconInstPathIsLeft: []bool{true, false},
+		BeaconInstRoot:       hash1,
+		BeaconBlkData:        hash2,
+		BeaconSigs:           []string{sig},
+		BeaconSigIdxs:        []int{3},
+		BridgeInstPath:       []string{hash2},
+		BridgeInstPathIsLeft: []bool{false},
+		BridgeInstRoot:       hash2,
+		BridgeBlkData:        hash1,
+		BridgeSigs:           []string{sig, sig},
+		BridgeSigIdxs:        []int{0, 1},
+	}
+
+	result, err := DecodeInstructionProof(proof)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0x48, 0x01, 0x02, 0x03}, result.Instruction)
+	assert.Equal(t, big.NewInt(100000), result.Heights[0])
+	assert.Equal(t, big.NewInt(50000), result.Heights[1])
+	assert.Equal(t, 2, len(result.InstPaths[0]))
+	assert.Equal(t, hash2, hex.EncodeToString(result.InstPaths[0][1][:]))
+	assert.Equal(t, []bool{true, false}, result.InstPathIsLefts[0])
+	assert.Equal(t, hash1, hex.EncodeToString(result.InstRoots[0][:]))
+	assert.Equal(t, hash1, hex.EncodeToString(result.BlkData[1][:]))
+	assert.Equal(t, []*big.Int{big.NewInt(3)}, result.SigIdxs[0])
+	assert.Equal(t, []uint8{28}, result.SigVs[0])
+	assert.Equal(t, strings.Repeat("aa", 32), hex.EncodeToString(result.SigRs[0][0][:]))
+	assert.Equal(t, strings.Repeat("bb", 32), hex.EncodeToString(result.SigSs[0][0][:]))
+	assert.Equal(t, 2, len(result.SigVs[1]))
+
+	// invalid signature
+	proof.BridgeSigs = []string{sig[:10], sig}
+	_, err = DecodeInstructionProof(proof)
+	assert.NotEqual(t, nil, err)
+
+	_, err = DecodeInstructionProof(&rpcclient.InstructionProof{})
+	assert.NotEqual(t, nil, err)
+}
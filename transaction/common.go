@@ -136,6 +136,24 @@ func NewPaymentInfoFromParam(paymentInfoParam map[string]uint64) ([]*crypto.Paym
 	return result, nil
 }
 
+// NewPaymentInfoWithMemo returns payment info of amount to paymentAddrStr with memo encrypted to the receiver,
+// the receiver reads memo from info of the output coin by crypto.DecryptMemo
+func NewPaymentInfoWithMemo(paymentAddrStr string, amount uint64, memo []byte) (*crypto.PaymentInfo, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddrStr)
+	if err != nil {
+		return nil, err
+	}
+	message, err := crypto.EncryptMemo(memo, keyWallet.KeySet.PaymentAddress.Tk)
+	if err != nil {
+		return nil, err
+	}
+	return &crypto.PaymentInfo{
+		PaymentAddress: keyWallet.KeySet.PaymentAddress,
+		Amount:         amount,
+		Message:        message,
+	}, nil
+}
+
 func RandomCommitmentsProcess(rpcClient *rpcclient.HttpClient, inputCoins []*crypto.InputCoin, shardID byte, tokenID *common.Hash) ([]uint64, []uint64, error) {
 	// Todo: call RPC to random commitments
 	return []uint64{}, []uint64{}, nil
@@ -196,6 +214,11 @@ func NewOutputCoinsFromResponse(outCoins []rpcclient.OutCoin) ([]*crypto.OutputC
 
 // GetListOutputCoins calls Incognito RPC to get all output coins of the account
 func GetListOutputCoins(rpcClient *rpcclient.HttpClient, paymentAddress string, viewingKey string) ([]*crypto.OutputCoin, error) {
+	return GetListOutputCoinsByToken(rpcClient, paymentAddress, viewingKey, common.PRVIDStr)
+}
+
+// GetListOutputCoinsByToken calls Incognito RPC to get all output coins in tokenIDStr of the account
+func GetListOutputCoinsByToken(rpcClient *rpcclient.HttpClient, paymentAddress string, viewingKey string, tokenIDStr string) ([]*crypto.OutputCoin, error) {
 	var outputCoinsRes rpcclient.ListOutputCoinsRes
 	params := []interface{}{
 		0,
@@ -207,6 +230,9 @@ func GetListOutputCoins(rpcClient *rpcclient.HttpClient, paymentAddress string,
 			},
 		},
 	}
+	if tokenIDStr != common.PRVIDStr {
+		params = append(params, tokenIDStr)
+	}
 	err := rpcClient.RPCCall("listoutputcoins", params, &outputCoinsRes)
 	if err != nil {
 		return nil, err
@@ -223,8 +249,8 @@ func GetListOutputCoins(rpcClient *rpcclient.HttpClient, paymentAddress string,
 }
 
 // CheckExistenceSerialNumber calls Incognito RPC to check existence serial number on network
-// to check output coins is spent or unspent
-func CheckExistenceSerialNumber(rpcClient *rpcclient.HttpClient, paymentAddressStr string, sns []*crypto.Point) ([]bool, error) {
+// to check output coins in tokenIDStr is spent or unspent
+func CheckExistenceSerialNumber(rpcClient *rpcclient.HttpClient, paymentAddressStr string, sns []*crypto.Point, tokenIDStr string) ([]bool, error) {
 	var hasSerialNumberRes rpcclient.HasSerialNumberRes
 	result := make([]bool, 0)
 	snStrs := make([]interface{}, len(sns))
@@ -274,6 +300,7 @@ func CheckExistenceSerialNumber(rpcClient *rpcclient.HttpClient, paymentAddressS
 	params := []interface{}{
 		paymentAddressStr,
 		snStrs,
+		tokenIDStr,
 	}
 	err := rpcClient.RPCCall("hasserialnumbers", params, &hasSerialNumberRes)
 	if err != nil {
@@ -305,21 +332,36 @@ func DeriveSerialNumbers(privateKey *crypto.PrivateKey, outputCoins []*crypto.Ou
 
 // GetUnspentOutputCoins return utxos of an account
 func GetUnspentOutputCoins(rpcClient *rpcclient.HttpClient, keyWallet *wallet.KeyWallet) ([]*crypto.OutputCoin, error) {
-	privateKey := &keyWallet.KeySet.PrivateKey
+	return GetUnspentOutputCoinsByToken(rpcClient, keyWallet, common.PRVIDStr)
+}
+
+// GetUnspentOutputCoinsByToken return utxos in tokenIDStr of an account
+func GetUnspentOutputCoinsByToken(rpcClient *rpcclient.HttpClient, keyWallet *wallet.KeyWallet, tokenIDStr string) ([]*crypto.OutputCoin, error) {
+	signer, err := NewLocalSigner(keyWallet)
+	if err != nil {
+		return nil, err
+	}
+	defer signer.Destroy()
+	return GetUnspentOutputCoinsBySigner(rpcClient, signer, tokenIDStr)
+}
+
+// GetUnspentOutputCoinsBySigner return utxos in tokenIDStr of the account of signer
+func GetUnspentOutputCoinsBySigner(rpcClient *rpcclient.HttpClient, signer Signer, tokenIDStr string) ([]*crypto.OutputCoin, error) {
+	keyWallet := signerKeyWallet(signer)
 	paymentAddressStr := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
 	viewingKeyStr := keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType)
 
-	outputCoins, err := GetListOutputCoins(rpcClient, paymentAddressStr, viewingKeyStr)
+	outputCoins, err := GetListOutputCoinsByToken(rpcClient, paymentAddressStr, viewingKeyStr, tokenIDStr)
 	if err != nil {
 		return nil, err
 	}
 
-	serialNumbers, err := DeriveSerialNumbers(privateKey, outputCoins)
+	serialNumbers, err := deriveSerialNumbersBySigner(signer, outputCoins)
 	if err != nil {
 		return nil, err
 	}
 
-	isExisted, err := CheckExistenceSerialNumber(rpcClient, paymentAddressStr, serialNumbers)
+	isExisted, err := CheckExistenceSerialNumber(rpcClient, paymentAddressStr, serialNumbers, tokenIDStr)
 	if err != nil {
 		return nil, err
 	}
@@ -336,14 +378,29 @@ func GetUnspentOutputCoins(rpcClient *rpcclient.HttpClient, keyWallet *wallet.Ke
 
 // GetUnspentOutputCoins return utxos of an account
 func GetUnspentOutputCoinsExceptSpendingUTXO(rpcClient *rpcclient.HttpClient, keyWallet *wallet.KeyWallet) ([]*crypto.InputCoin, error) {
-	publicKey := keyWallet.KeySet.PaymentAddress.Pk
+	return GetUnspentOutputCoinsExceptSpendingUTXOByToken(rpcClient, keyWallet, common.PRVIDStr)
+}
+
+// GetUnspentOutputCoinsExceptSpendingUTXOByToken return utxos in tokenIDStr of an account except utxos are spending in other txs
+func GetUnspentOutputCoinsExceptSpendingUTXOByToken(rpcClient *rpcclient.HttpClient, keyWallet *wallet.KeyWallet, tokenIDStr string) ([]*crypto.InputCoin, error) {
+	signer, err := NewLocalSigner(keyWallet)
+	if err != nil {
+		return nil, err
+	}
+	defer signer.Destroy()
+	return GetUnspentOutputCoinsExceptSpendingUTXOBySigner(rpcClient, signer, tokenIDStr)
+}
+
+// GetUnspentOutputCoinsExceptSpendingUTXOBySigner return utxos in tokenIDStr of the account of signer except utxos are spending in other txs
+func GetUnspentOutputCoinsExceptSpendingUTXOBySigner(rpcClient *rpcclient.HttpClient, signer Signer, tokenIDStr string) ([]*crypto.InputCoin, error) {
+	publicKey := signer.PaymentAddress().Pk
 
 	// check and remove utxo cache (these utxos in txs that were confirmed)
 	//CheckAndRemoveUTXOFromCache(keyWallet.KeySet.PaymentAddress.Pk, inputCoins)
-	CheckAndRemoveUTXOFromCacheV2(keyWallet.KeySet.PaymentAddress.Pk, rpcClient)
+	CheckAndRemoveUTXOFromCacheV2(publicKey, rpcClient)
 
 	// get unspent output coins from network
-	utxos, err := GetUnspentOutputCoins(rpcClient, keyWallet)
+	utxos, err := GetUnspentOutputCoinsBySigner(rpcClient, signer, tokenIDStr)
 	if err != nil {
 		return nil, err
 	}
@@ -419,14 +476,40 @@ func GetInputCoinsToCreateNormalTx(
 	paymentInfos []*crypto.PaymentInfo,
 	fee uint64,
 ) ([]*crypto.InputCoin, uint64, error) {
-	// get unspent output coins (UTXOs)
+	return GetInputCoinsToCreateTxByToken(rpcClient, senderPrivateKey, paymentInfos, fee, common.PRVIDStr)
+}
+
+// GetInputCoinsToCreateTxByToken returns utxos in tokenIDStr to spend for paymentInfos and fee
+func GetInputCoinsToCreateTxByToken(
+	rpcClient *rpcclient.HttpClient,
+	senderPrivateKey *crypto.PrivateKey,
+	paymentInfos []*crypto.PaymentInfo,
+	fee uint64,
+	tokenIDStr string,
+) ([]*crypto.InputCoin, uint64, error) {
 	keyWallet := new(wallet.KeyWallet)
 	err := keyWallet.KeySet.InitFromPrivateKey(senderPrivateKey)
 	if err != nil {
 		return nil, uint64(0), err
 	}
+	signer, err := NewLocalSigner(keyWallet)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	defer signer.Destroy()
+	return GetInputCoinsToCreateTxBySigner(rpcClient, signer, paymentInfos, fee, tokenIDStr)
+}
 
-	utxos, err := GetUnspentOutputCoinsExceptSpendingUTXO(rpcClient, keyWallet)
+// GetInputCoinsToCreateTxBySigner returns utxos in tokenIDStr of the account of signer to spend for paymentInfos and fee
+func GetInputCoinsToCreateTxBySigner(
+	rpcClient *rpcclient.HttpClient,
+	signer Signer,
+	paymentInfos []*crypto.PaymentInfo,
+	fee uint64,
+	tokenIDStr string,
+) ([]*crypto.InputCoin, uint64, error) {
+	// get unspent output coins (UTXOs)
+	utxos, err := GetUnspentOutputCoinsExceptSpendingUTXOBySigner(rpcClient, signer, tokenIDStr)
 	if err != nil {
 		return nil, uint64(0), err
 	}
@@ -451,7 +534,7 @@ func GetInputCoinsToCreateNormalTx(
 	overBalanceAmount := candidateOutputCoinAmount - totalAmount
 	if overBalanceAmount > 0 {
 		paymentInfos = append(paymentInfos, &crypto.PaymentInfo{
-			PaymentAddress: keyWallet.KeySet.PaymentAddress,
+			PaymentAddress: signer.PaymentAddress(),
 			Amount:         overBalanceAmount,
 		})
 	}
@@ -459,13 +542,21 @@ func GetInputCoinsToCreateNormalTx(
 	return candidateOutputCoins, candidateOutputCoinAmount, nil
 }
 
+// NewExchangeRateFromParam returns exchange rates sorted by token id
+// so that the metadata hash doesn't depend on map iteration order
 func NewExchangeRateFromParam(params map[string]uint64) ([]*metadata.ExchangeRateInfo, error){
-	result := make([]*metadata.ExchangeRateInfo, 0)
-	for tokenID, amount := range params {
+	tokenIDs := make([]string, 0, len(params))
+	for tokenID := range params {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+
+	result := make([]*metadata.ExchangeRateInfo, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
 		result = append(result,
 			&metadata.ExchangeRateInfo{
 				PTokenID: tokenID,
-				Rate:     amount,
+				Rate:     params[tokenID],
 			})
 	}
 
@@ -0,0 +1,105 @@
+package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRPCHandler returns the result of an RPC call with params
+type testRPCHandler func(params []json.RawMessage) interface{}
+
+// newTestNode returns a client of a fake Incognito node that answers RPC calls by handlers of methods,
+// methods of calls are appended to calls
+func newTestNode(t *testing.T, handlers map[string]testRPCHandler) (*rpcclient.HttpClient, *[]string, func()) {
+	calls := new([]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string
+			Params []json.RawMessage
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid RPC request %v", err)
+			return
+		}
+		*calls = append(*calls, req.Method)
+
+		res := map[string]interface{}{}
+		if handler, ok := handlers[req.Method]; ok {
+			res["Result"] = handler(req.Params)
+		} else {
+			res["error"] = rpcclient.RPCError{Code: -1, Message: "method not found " + req.Method}
+		}
+		json.NewEncoder(w).Encode(res)
+	}))
+	return rpcclient.NewHttpClient(server.URL, "", "", 0), calls, server.Close
+}
+
+func newTestOutputCoins(values []uint64) []*crypto.OutputCoin {
+	outputCoins := make([]*crypto.OutputCoin, len(values))
+	for i, value := range values {
+		outputCoins[i] = new(crypto.OutputCoin).Init()
+		outputCoins[i].CoinDetails.SetSNDerivator(crypto.RandomScalar())
+		outputCoins[i].CoinDetails.SetValue(value)
+	}
+	return outputCoins
+}
+
+// newTestOutCoins returns output coins in RPC responses
+func newTestOutCoins(publicKey []byte, outputCoins []*crypto.OutputCoin) []rpcclient.OutCoin {
+	outCoins := make([]rpcclient.OutCoin, len(outputCoins))
+	for i, coin := range outputCoins {
+		outCoins[i] = rpcclient.OutCoin{
+			PublicKey:      base58.Base58Check{}.Encode(publicKey, common.ZeroByte),
+			CoinCommitment: base58.Base58Check{}.Encode(crypto.RandomPoint().ToBytesS(), common.ZeroByte),
+			SNDerivator:    base58.Base58Check{}.Encode(coin.CoinDetails.GetSNDerivator().ToBytesS(), common.ZeroByte),
+			Randomness:     base58.Base58Check{}.Encode(crypto.RandomScalar().ToBytesS(), common.ZeroByte),
+			Value:          strconv.FormatUint(coin.CoinDetails.GetValue(), 10),
+		}
+	}
+	return outCoins
+}
+
+func unmarshalTestParam(t *testing.T, param json.RawMessage) string {
+	var res string
+	assert.Equal(t, nil, json.Unmarshal(param, &res))
+	return res
+}
+
+func TestGetUnspentOutputCoinsByToken(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	readonlyKeyStr := keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType)
+	tokenIDStr := common.Hash{5}.String()
+	outputCoins := newTestOutputCoins([]uint64{100, 200})
+
+	rpcClient, calls, closeNode := newTestNode(t, map[string]testRPCHandler{
+		"listoutputcoins": func(params []json.RawMessage) interface{} {
+			assert.Equal(t, tokenIDStr, unmarshalTestParam(t, params[3]))
+			return rpcclient.ListOutputCoins{Outputs: map[string][]rpcclient.OutCoin{
+				readonlyKeyStr: newTestOutCoins(keyWallet.KeySet.PaymentAddress.Pk, outputCoins),
+			}}
+		},
+		// serial numbers are checked in the token
+		"hasserialnumbers": func(params []json.RawMessage) interface{} {
+			assert.Equal(t, 3, len(params))
+			assert.Equal(t, tokenIDStr, unmarshalTestParam(t, params[2]))
+			return []bool{true, false}
+		},
+	})
+	defer closeNode()
+
+	utxos, err := GetUnspentOutputCoinsByToken(rpcClient, keyWallet, tokenIDStr)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"listoutputcoins", "hasserialnumbers"}, *calls)
+	assert.Equal(t, 1, len(utxos))
+	assert.Equal(t, uint64(200), utxos[0].CoinDetails.GetValue())
+}
@@ -13,84 +13,114 @@ import (
 
 // TODO: need to support privacy mode
 func CreateAndSendNormalTx(rpcClient *rpcclient.HttpClient, privateKeyStr string, paymentInfoParam map[string]uint64, fee uint64, isPrivacy bool) (string, error) {
-	// create sender private key from private key string
-	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	// create sender signer from private key string
+	signer, err := NewLocalSignerFromPrivateKeyStr(privateKeyStr)
 	if err != nil {
 		return "", fmt.Errorf("Can not deserialize priavte key %v\n", err)
 	}
-	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	defer signer.Destroy()
+
+	return CreateAndSendNormalTxBySigner(rpcClient, signer, paymentInfoParam, fee, isPrivacy)
+}
+
+// CreateAndSendNormalTxWithSecureKey is the same as CreateAndSendNormalTx,
+// but serialized private key is held by privateKey and never converted to a string
+func CreateAndSendNormalTxWithSecureKey(rpcClient *rpcclient.HttpClient, privateKey *crypto.SecureKey, paymentInfoParam map[string]uint64, fee uint64, isPrivacy bool) (string, error) {
+	signer, err := NewLocalSignerFromSecureKey(privateKey)
 	if err != nil {
-		return "", errors.New("sender private key is invalid")
+		return "", fmt.Errorf("Can not deserialize priavte key %v\n", err)
 	}
+	defer signer.Destroy()
+
+	return CreateAndSendNormalTxBySigner(rpcClient, signer, paymentInfoParam, fee, isPrivacy)
+}
 
+// CreateAndSendNormalTxBySigner creates and sends a normal tx, private key of sender is only accessed through signer
+func CreateAndSendNormalTxBySigner(rpcClient *rpcclient.HttpClient, signer Signer, paymentInfoParam map[string]uint64, fee uint64, isPrivacy bool) (string, error) {
 	// create payment infos from param
 	paymentInfos, err := NewPaymentInfoFromParam(paymentInfoParam)
 	if err != nil {
 		return "", errors.New("Payment info param is invalid")
 	}
 
-	// create tx
-	tx := new(Tx)
-	tx, err = tx.Init(
-		rpcClient, keyWallet, paymentInfos, fee, false, nil, nil, txVersion)
-	if err != nil {
-		return "", err
-	}
+	_, txID, err := createAndSendNormalTx(rpcClient, signer, paymentInfos, fee, isPrivacy)
+	return txID, err
+}
 
-	// send tx
-	txID, err := tx.Send(rpcClient)
-	if err != nil {
-		tx.UnCacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk)
-		return "", err
+// CreateAndSendNormalTxWithPaymentProofs is the same as CreateAndSendNormalTxBySigner,
+// it also returns payment proofs of output coins by payment addresses of receivers in paymentInfoParam,
+// the sender keeps them to prove the payments later (see TxPaymentProof)
+func CreateAndSendNormalTxWithPaymentProofs(rpcClient *rpcclient.HttpClient, signer Signer, paymentInfoParam map[string]uint64, fee uint64, isPrivacy bool) (string, map[string]*TxPaymentProof, error) {
+	// create payment infos from param, in the order of paymentAddrStrs
+	paymentAddrStrs := make([]string, 0, len(paymentInfoParam))
+	paymentInfos := make([]*crypto.PaymentInfo, 0, len(paymentInfoParam))
+	for paymentAddrStr, amount := range paymentInfoParam {
+		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddrStr)
+		if err != nil {
+			return "", nil, errors.New("Payment info param is invalid")
+		}
+		paymentAddrStrs = append(paymentAddrStrs, paymentAddrStr)
+		paymentInfos = append(paymentInfos, &crypto.PaymentInfo{
+			PaymentAddress: keyWallet.KeySet.PaymentAddress,
+			Amount:         amount,
+		})
 	}
 
-	tx.UpdateCacheUTXOsWithTxID(keyWallet.KeySet.PaymentAddress.Pk, tx.Proof.GetInputCoins())
-
-	return txID, nil
-}
-
-func CreateAndSendTxRelayBNBHeader(rpcClient *rpcclient.HttpClient, privateKeyStr string, bnbHeaderStr string, bnbHeaderBlockHeight int64, fee uint64) (string, error) {
-	// create sender private key from private key string
-	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	tx, txID, err := createAndSendNormalTx(rpcClient, signer, paymentInfos, fee, isPrivacy)
 	if err != nil {
-		return "", fmt.Errorf("Can not deserialize priavte key %v\n", err)
+		return "", nil, err
 	}
-	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	proofs, err := tx.getTxPaymentProofs(paymentAddrStrs)
 	if err != nil {
-		return "", errors.New("sender private key is invalid")
+		return txID, nil, err
 	}
-	paymentAddrStr := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	return txID, proofs, nil
+}
 
-	// create metadata
-	meta, _ := metadata.NewRelayingHeader(
-		metadata.RelayingBNBHeaderMeta, paymentAddrStr, bnbHeaderStr, uint64(bnbHeaderBlockHeight))
+// createAndSendNormalTx creates and sends a normal tx to paymentInfos, it returns the tx and its ID
+// privacy mode is not supported yet, random commitments of input coins can not be got (see RandomCommitmentsProcess)
+func createAndSendNormalTx(rpcClient *rpcclient.HttpClient, signer Signer, paymentInfos []*crypto.PaymentInfo, fee uint64, isPrivacy bool) (*Tx, string, error) {
+	if isPrivacy {
+		return nil, "", errors.New("Privacy mode is not supported")
+	}
 
 	// create tx
 	tx := new(Tx)
-	tx, err = tx.Init(
-		rpcClient, keyWallet, []*crypto.PaymentInfo{}, fee, false, meta, nil, txVersion)
+	tx, err := tx.InitWithSigner(
+		rpcClient, signer, paymentInfos, fee, isPrivacy, nil, nil, txVersion)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	// send tx
 	txID, err := tx.Send(rpcClient)
 	if err != nil {
-		tx.UnCacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk)
-		return "", err
+		tx.UnCacheUTXOs(signer.PaymentAddress().Pk)
+		return nil, "", err
 	}
 
-	tx.UpdateCacheUTXOsWithTxID(keyWallet.KeySet.PaymentAddress.Pk, tx.Proof.GetInputCoins())
+	tx.UpdateCacheUTXOsWithTxID(signer.PaymentAddress().Pk, tx.Proof.GetInputCoins())
 
-	return txID, nil
+	return tx, txID, nil
+}
+
+func CreateAndSendTxRelayBNBHeader(rpcClient *rpcclient.HttpClient, privateKeyStr string, bnbHeaderStr string, bnbHeaderBlockHeight int64, fee uint64) (string, error) {
+	return createAndSendTxRelayHeader(rpcClient, privateKeyStr, metadata.RelayingBNBHeaderMeta, bnbHeaderStr, bnbHeaderBlockHeight, fee)
 }
 
 func CreateAndSendTxRelayBTCHeader(rpcClient *rpcclient.HttpClient, privateKeyStr string, btcHeaderStr string, btcHeaderBlockHeight int64, fee uint64) (string, error) {
+	return createAndSendTxRelayHeader(rpcClient, privateKeyStr, metadata.RelayingBTCHeaderMeta, btcHeaderStr, btcHeaderBlockHeight, fee)
+}
+
+// createAndSendTxRelayHeader creates and sends a normal tx with relaying header metadata
+// headerStr is the header encoded in base64
+func createAndSendTxRelayHeader(rpcClient *rpcclient.HttpClient, privateKeyStr string, metaType int, headerStr string, blockHeight int64, fee uint64) (string, error) {
 	// create sender private key from private key string
 	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
 	if err != nil {
 		return "", fmt.Errorf("Can not deserialize priavte key %v\n", err)
 	}
+	defer keyWallet.Zero()
 	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
 	if err != nil {
 		return "", errors.New("sender private key is invalid")
@@ -99,7 +129,7 @@ func CreateAndSendTxRelayBTCHeader(rpcClient *rpcclient.HttpClient, privateKeySt
 
 	// create metadata
 	meta, _ := metadata.NewRelayingHeader(
-		metadata.RelayingBTCHeaderMeta, paymentAddrStr, btcHeaderStr, uint64(btcHeaderBlockHeight))
+		metaType, paymentAddrStr, headerStr, uint64(blockHeight))
 
 	// create tx
 	tx := new(Tx)
@@ -127,6 +157,7 @@ func CreateAndSendTxPortalExchangeRate(rpcClient *rpcclient.HttpClient, privateK
 	if err != nil {
 		return "", fmt.Errorf("Can not deserialize private key %v\n", err)
 	}
+	defer keyWallet.Zero()
 	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
 	if err != nil {
 		return "", errors.New("sender private key is invalid")
@@ -167,6 +198,7 @@ func GetBalancePRV(rpcClient *rpcclient.HttpClient, privateKeyStr string) (uint6
 	if err != nil {
 		return 0, fmt.Errorf("Can not deserialize private key %v\n", err)
 	}
+	defer keyWallet.Zero()
 	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
 	if err != nil {
 		return 0, errors.New("sender private key is invalid")
@@ -189,6 +221,7 @@ func SplitUTXOs(rpcClient *rpcclient.HttpClient, privateKeyStr string, minNumUTX
 	if err != nil {
 		return fmt.Errorf("Can not deserialize private key %v\n", err)
 	}
+	defer keyWallet.Zero()
 	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
 	if err != nil {
 		return errors.New("sender private key is invalid")
@@ -264,6 +297,7 @@ func CreateAndSendTxPRVCrossPoolTrade(
 	if err != nil {
 		return "", fmt.Errorf("Can not deserialize priavte key %v\n", err)
 	}
+	defer keyWallet.Zero()
 	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
 	if err != nil {
 		return "", errors.New("sender private key is invalid")
@@ -306,3 +340,367 @@ func CreateAndSendTxPRVCrossPoolTrade(
 
 	return txID, nil
 }
+
+// getCommitteePublicKeyStr returns committee public key of candidate in base58 check encoding
+func getCommitteePublicKeyStr(candidatePrivateKeyStr string) (string, error) {
+	candidateKeyWallet, err := wallet.Base58CheckDeserialize(candidatePrivateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize candidate private key %v\n", err)
+	}
+	defer candidateKeyWallet.Zero()
+	err = candidateKeyWallet.KeySet.InitFromPrivateKey(&candidateKeyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return "", errors.New("candidate private key is invalid")
+	}
+	committeePubKey, err := candidateKeyWallet.KeySet.GetCommitteePublicKey()
+	if err != nil {
+		return "", err
+	}
+	return committeePubKey.ToBase58()
+}
+
+// CreateAndSendTxShardStaking burns ShardStakingAmount from funder account to stake a shard validator candidate
+// candidatePrivateKeyStr can be empty if the funder is also the candidate
+// rewardReceiverPaymentAddrStr can be empty if the funder receives rewards
+func CreateAndSendTxShardStaking(
+	rpcClient *rpcclient.HttpClient,
+	privateKeyStr string,
+	candidatePrivateKeyStr string,
+	rewardReceiverPaymentAddrStr string,
+	autoReStaking bool,
+	fee uint64,
+) (string, error) {
+	// create sender private key from private key string
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize private key %v\n", err)
+	}
+	defer keyWallet.Zero()
+	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return "", errors.New("sender private key is invalid")
+	}
+	paymentAddrStr := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+
+	if candidatePrivateKeyStr == "" {
+		candidatePrivateKeyStr = privateKeyStr
+	}
+	if rewardReceiverPaymentAddrStr == "" {
+		rewardReceiverPaymentAddrStr = paymentAddrStr
+	}
+	committeePubKeyStr, err := getCommitteePublicKeyStr(candidatePrivateKeyStr)
+	if err != nil {
+		return "", err
+	}
+
+	// create metadata
+	meta, err := metadata.NewStakingMetadata(
+		metadata.ShardStakingMeta, paymentAddrStr, rewardReceiverPaymentAddrStr,
+		metadata.ShardStakingAmount, committeePubKeyStr, autoReStaking)
+	if err != nil {
+		return "", err
+	}
+
+	return createAndSendTxBurningPRV(rpcClient, keyWallet, metadata.ShardStakingAmount, meta, fee)
+}
+
+// CreateAndSendTxStopAutoStaking stops auto re-staking of a candidate that was staked by the funder
+// candidatePrivateKeyStr can be empty if the funder is also the candidate
+func CreateAndSendTxStopAutoStaking(rpcClient *rpcclient.HttpClient, privateKeyStr string, candidatePrivateKeyStr string, fee uint64) (string, error) {
+	// create sender private key from private key string
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize private key %v\n", err)
+	}
+	defer keyWallet.Zero()
+	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return "", errors.New("sender private key is invalid")
+	}
+
+	if candidatePrivateKeyStr == "" {
+		candidatePrivateKeyStr = privateKeyStr
+	}
+	committeePubKeyStr, err := getCommitteePublicKeyStr(candidatePrivateKeyStr)
+	if err != nil {
+		return "", err
+	}
+
+	// create metadata
+	meta, err := metadata.NewStopAutoStakingMetadata(metadata.StopAutoStakingMeta, committeePubKeyStr)
+	if err != nil {
+		return "", err
+	}
+
+	return createAndSendTxBurningPRV(rpcClient, keyWallet, metadata.StopAutoStakingAmount, meta, fee)
+}
+
+// createAndSendTxBurningPRV creates and sends a tx that burns amount of PRV to burning address with metadata
+func createAndSendTxBurningPRV(rpcClient *rpcclient.HttpClient, keyWallet *wallet.KeyWallet, amount uint64, meta metadata.Metadata, fee uint64) (string, error) {
+	burnKeyWallet, err := wallet.Base58CheckDeserialize(common.BurningAddress)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize burning address %v\n", err)
+	}
+	paymentInfos := []*crypto.PaymentInfo{
+		{
+			PaymentAddress: burnKeyWallet.KeySet.PaymentAddress,
+			Amount:         amount,
+		},
+	}
+
+	// create tx
+	tx := new(Tx)
+	tx, err = tx.Init(
+		rpcClient, keyWallet, paymentInfos, fee, false, meta, nil, txVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// send tx
+	txID, err := tx.Send(rpcClient)
+	if err != nil {
+		tx.UnCacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk)
+		return "", err
+	}
+
+	tx.UpdateCacheUTXOsWithTxID(keyWallet.KeySet.PaymentAddress.Pk, tx.Proof.GetInputCoins())
+
+	return txID, nil
+}
+
+// GetRewardAmount returns rewards of payment address that haven't been withdrawn (tokenID : amount)
+func GetRewardAmount(rpcClient *rpcclient.HttpClient, paymentAddrStr string) (map[string]uint64, error) {
+	var rewardAmountRes rpcclient.GetRewardAmountRes
+	params := []interface{}{
+		paymentAddrStr,
+	}
+	err := rpcClient.RPCCall("getrewardamount", params, &rewardAmountRes)
+	if err != nil {
+		return nil, err
+	}
+	if rewardAmountRes.RPCError != nil {
+		return nil, errors.New(rewardAmountRes.RPCError.Message)
+	}
+	return rewardAmountRes.Result, nil
+}
+
+// CreateAndSendTxWithdrawReward withdraws rewards in tokenIDStr of the account
+func CreateAndSendTxWithdrawReward(rpcClient *rpcclient.HttpClient, privateKeyStr string, tokenIDStr string, fee uint64) (string, error) {
+	// create sender private key from private key string
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize private key %v\n", err)
+	}
+	defer keyWallet.Zero()
+	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return "", errors.New("sender private key is invalid")
+	}
+
+	// create metadata
+	meta, err := metadata.NewWithDrawRewardRequest(
+		keyWallet.KeySet.PaymentAddress, tokenIDStr, 1, metadata.WithDrawRewardRequestMeta)
+	if err != nil {
+		return "", err
+	}
+
+	// create tx
+	tx := new(Tx)
+	tx, err = tx.Init(
+		rpcClient, keyWallet, []*crypto.PaymentInfo{}, fee, false, meta, nil, txVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// send tx
+	txID, err := tx.Send(rpcClient)
+	if err != nil {
+		tx.UnCacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk)
+		return "", err
+	}
+
+	tx.UpdateCacheUTXOsWithTxID(keyWallet.KeySet.PaymentAddress.Pk, tx.Proof.GetInputCoins())
+
+	return txID, nil
+}
+
+// CreateAndSendTxBurningRequest burns burnAmount of pToken tokenIDStr to burning address
+// to withdraw corresponding tokens to remoteAddrStr on Ethereum
+// fee is paid in PRV
+func CreateAndSendTxBurningRequest(
+	rpcClient *rpcclient.HttpClient,
+	privateKeyStr string,
+	tokenIDStr string,
+	burnAmount uint64,
+	remoteAddrStr string,
+	fee uint64,
+) (string, error) {
+	// create sender private key from private key string
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize private key %v\n", err)
+	}
+	defer keyWallet.Zero()
+	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return "", errors.New("sender private key is invalid")
+	}
+
+	tokenID, err := new(common.Hash).NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return "", errors.New("token ID is invalid")
+	}
+
+	// create metadata
+	meta, err := metadata.NewBurningRequest(
+		keyWallet.KeySet.PaymentAddress, burnAmount, *tokenID, "", remoteAddrStr, metadata.BurningRequestMeta)
+	if err != nil {
+		return "", err
+	}
+
+	burnKeyWallet, err := wallet.Base58CheckDeserialize(common.BurningAddress)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize burning address %v\n", err)
+	}
+	tokenParams := &CustomTokenPrivacyParamTx{
+		PropertyID:  tokenIDStr,
+		TokenTxType: CustomTokenTransfer,
+		Receiver: []*crypto.PaymentInfo{
+			{
+				PaymentAddress: burnKeyWallet.KeySet.PaymentAddress,
+				Amount:         burnAmount,
+			},
+		},
+	}
+
+	// create tx
+	tx := new(TxCustomTokenPrivacy)
+	tx, err = tx.Init(
+		rpcClient, keyWallet, []*crypto.PaymentInfo{}, fee, tokenParams, meta, nil, txVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// send tx
+	txID, err := tx.Send(rpcClient)
+	if err != nil {
+		tx.UnCacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk)
+		return "", err
+	}
+
+	tx.UpdateCacheUTXOsWithTxID(keyWallet.KeySet.PaymentAddress.Pk)
+
+	return txID, nil
+}
+
+// CreateAndSendTxIssuingETHRequest shields tokens deposited to Incognito Vault contract on Ethereum
+// proofStrs are nodes (in base64) of receipt proof of the deposit tx that is produced by a Merkle-Patricia proof generator
+// the deposit tx should have at least metadata.ETHConfirmationBlocks confirmations
+func CreateAndSendTxIssuingETHRequest(
+	rpcClient *rpcclient.HttpClient,
+	privateKeyStr string,
+	blockHashStr string,
+	txIndex uint,
+	proofStrs []string,
+	incTokenIDStr string,
+	fee uint64,
+) (string, error) {
+	blockHash, err := metadata.NewETHHashFromHex(blockHashStr)
+	if err != nil {
+		return "", err
+	}
+	incTokenID, err := new(common.Hash).NewHashFromStr(incTokenIDStr)
+	if err != nil {
+		return "", errors.New("token ID is invalid")
+	}
+
+	// create metadata
+	meta, err := metadata.NewIssuingETHRequest(blockHash, txIndex, proofStrs, *incTokenID, metadata.IssuingETHRequestMeta)
+	if err != nil {
+		return "", err
+	}
+
+	return createAndSendTxWithMetadata(rpcClient, privateKeyStr, meta, fee)
+}
+
+// CreateAndSendTxIssuingRequest mints pTokens for receiver after tokens were deposited to a centralized bridge
+// privateKeyStr must be the private key of the centralized bridge account
+func CreateAndSendTxIssuingRequest(
+	rpcClient *rpcclient.HttpClient,
+	privateKeyStr string,
+	receiverAddrStr string,
+	depositedAmount uint64,
+	tokenIDStr string,
+	tokenName string,
+	fee uint64,
+) (string, error) {
+	receiverKeyWallet, err := wallet.Base58CheckDeserialize(receiverAddrStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize receiver address %v\n", err)
+	}
+	tokenID, err := new(common.Hash).NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return "", errors.New("token ID is invalid")
+	}
+
+	// create metadata
+	meta, err := metadata.NewIssuingRequest(
+		receiverKeyWallet.KeySet.PaymentAddress, depositedAmount, *tokenID, tokenName, metadata.IssuingRequestMeta)
+	if err != nil {
+		return "", err
+	}
+
+	return createAndSendTxWithMetadata(rpcClient, privateKeyStr, meta, fee)
+}
+
+// createAndSendTxWithMetadata creates and sends a normal tx without payments that only contains metadata
+func createAndSendTxWithMetadata(rpcClient *rpcclient.HttpClient, privateKeyStr string, meta metadata.Metadata, fee uint64) (string, error) {
+	// create sender private key from private key string
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("Can not deserialize private key %v\n", err)
+	}
+	defer keyWallet.Zero()
+	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return "", errors.New("sender private key is invalid")
+	}
+
+	// create tx
+	tx := new(Tx)
+	tx, err = tx.Init(
+		rpcClient, keyWallet, []*crypto.PaymentInfo{}, fee, false, meta, nil, txVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// send tx
+	txID, err := tx.Send(rpcClient)
+	if err != nil {
+		tx.UnCacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk)
+		return "", err
+	}
+
+	tx.UpdateCacheUTXOsWithTxID(keyWallet.KeySet.PaymentAddress.Pk, tx.Proof.GetInputCoins())
+
+	return txID, nil
+}
+
+// GetBridgeReqWithStatus returns status of an issuing request
+// (common.BridgeRequestNotFoundStatus, BridgeRequestProcessingStatus, BridgeRequestAcceptedStatus or BridgeRequestRejectedStatus)
+func GetBridgeReqWithStatus(rpcClient *rpcclient.HttpClient, txID string) (byte, error) {
+	var bridgeReqRes rpcclient.GetBridgeReqWithStatusRes
+	params := []interface{}{
+		map[string]interface{}{
+			"TxReqID": txID,
+		},
+	}
+	err := rpcClient.RPCCall("getbridgereqwithstatus", params, &bridgeReqRes)
+	if err != nil {
+		return 0, err
+	}
+	if bridgeReqRes.RPCError != nil {
+		return 0, errors.New(bridgeReqRes.RPCError.Message)
+	}
+	return bridgeReqRes.Result, nil
+}
@@ -0,0 +1,361 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+)
+
+// methods of remote signer protocol
+const (
+	signerMethodGetKeys             = "getkeys"
+	signerMethodDeriveSerialNumbers = "deriveserialnumbers"
+	signerMethodProve               = "prove"
+	signerMethodSign                = "sign"
+)
+
+// signerRequest and signerResponse are messages of remote signer protocol (JSON over HTTP POST)
+type signerRequest struct {
+	Method string
+	Params json.RawMessage
+}
+
+type signerResponse struct {
+	Result json.RawMessage
+	Error  string
+}
+
+type signerKeysResult struct {
+	PaymentAddress string
+	ReadonlyKey    string
+}
+
+type signerDeriveSerialNumbersParams struct {
+	SNDs [][]byte
+}
+
+type signerDeriveSerialNumbersResult struct {
+	SerialNumbers [][]byte
+}
+
+// signerProveParams is PaymentWitnessParam without private key, coins are in bytes of coin details
+type signerProveParams struct {
+	HasPrivacy              bool
+	InputCoins              [][]byte
+	OutputCoins             [][]byte
+	PublicKeyLastByteSender byte
+	Commitments             [][]byte
+	CommitmentIndices       []uint64
+	MyCommitmentIndices     []uint64
+	Fee                     uint64
+}
+
+type signerProveResult struct {
+	Proof  []byte
+	RandSK []byte
+}
+
+type signerSignParams struct {
+	TxHash []byte
+	RandSK []byte
+}
+
+type signerSignResult struct {
+	SigPubKey []byte
+	Sig       []byte
+}
+
+func newSignerProveParams(param *zkp.PaymentWitnessParam) *signerProveParams {
+	params := &signerProveParams{
+		HasPrivacy:              param.HasPrivacy,
+		InputCoins:              make([][]byte, len(param.InputCoins)),
+		OutputCoins:             make([][]byte, len(param.OutputCoins)),
+		PublicKeyLastByteSender: param.PublicKeyLastByteSender,
+		Commitments:             make([][]byte, len(param.Commitments)),
+		CommitmentIndices:       param.CommitmentIndices,
+		MyCommitmentIndices:     param.MyCommitmentIndices,
+		Fee:                     param.Fee,
+	}
+	for i, coin := range param.InputCoins {
+		params.InputCoins[i] = coin.Bytes()
+	}
+	for i, coin := range param.OutputCoins {
+		params.OutputCoins[i] = coin.CoinDetails.Bytes()
+	}
+	for i, cm := range param.Commitments {
+		params.Commitments[i] = cm.ToBytesS()
+	}
+	return params
+}
+
+func (params *signerProveParams) toPaymentWitnessParam() (*zkp.PaymentWitnessParam, error) {
+	param := &zkp.PaymentWitnessParam{
+		HasPrivacy:              params.HasPrivacy,
+		InputCoins:              make([]*crypto.InputCoin, len(params.InputCoins)),
+		OutputCoins:             make([]*crypto.OutputCoin, len(params.OutputCoins)),
+		PublicKeyLastByteSender: params.PublicKeyLastByteSender,
+		Commitments:             make([]*crypto.Point, len(params.Commitments)),
+		CommitmentIndices:       params.CommitmentIndices,
+		MyCommitmentIndices:     params.MyCommitmentIndices,
+		Fee:                     params.Fee,
+	}
+	for i, coinBytes := range params.InputCoins {
+		param.InputCoins[i] = new(crypto.InputCoin)
+		err := param.InputCoins[i].SetBytes(coinBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input coin %v: %v", i, err)
+		}
+	}
+	for i, coinBytes := range params.OutputCoins {
+		param.OutputCoins[i] = new(crypto.OutputCoin)
+		param.OutputCoins[i].CoinDetails = new(crypto.Coin)
+		err := param.OutputCoins[i].CoinDetails.SetBytes(coinBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output coin %v: %v", i, err)
+		}
+	}
+	for i, cmBytes := range params.Commitments {
+		cm, err := new(crypto.Point).FromBytesS(cmBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commitment %v: %v", i, err)
+		}
+		param.Commitments[i] = cm
+	}
+	return param, nil
+}
+
+// RemoteSigner is a Signer that forwards signing operations to a signing service over HTTP
+// the signing service is served by NewSignerHandler, which holds the private key
+type RemoteSigner struct {
+	url            string
+	httpClient     *http.Client
+	paymentAddress crypto.PaymentAddress
+	readonlyKey    crypto.ViewingKey
+}
+
+// NewRemoteSigner connects to the signing service at url and gets public keys of its account
+// if httpClient is nil, http.DefaultClient is used
+func NewRemoteSigner(url string, httpClient *http.Client) (*RemoteSigner, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	signer := &RemoteSigner{
+		url:        url,
+		httpClient: httpClient,
+	}
+
+	var keys signerKeysResult
+	err := signer.call(signerMethodGetKeys, nil, &keys)
+	if err != nil {
+		return nil, err
+	}
+	keyWallet, err := wallet.NewWatchOnlyKeyWallet(keys.PaymentAddress, keys.ReadonlyKey)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid keys of remote signer %v\n", err)
+	}
+	signer.paymentAddress = keyWallet.KeySet.PaymentAddress
+	signer.readonlyKey = keyWallet.KeySet.ReadonlyKey
+	return signer, nil
+}
+
+func (signer *RemoteSigner) call(method string, params interface{}, result interface{}) error {
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	reqBytes, err := json.Marshal(signerRequest{Method: method, Params: paramsBytes})
+	if err != nil {
+		return err
+	}
+
+	resp, err := signer.httpClient.Post(signer.url, "application/json", bytes.NewReader(reqBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var signerRes signerResponse
+	err = json.Unmarshal(body, &signerRes)
+	if err != nil {
+		return fmt.Errorf("Can not parse response of remote signer %v\n", err)
+	}
+	if signerRes.Error != "" {
+		return errors.New(signerRes.Error)
+	}
+	return json.Unmarshal(signerRes.Result, result)
+}
+
+func (signer *RemoteSigner) PaymentAddress() crypto.PaymentAddress {
+	return signer.paymentAddress
+}
+
+func (signer *RemoteSigner) ReadonlyKey() crypto.ViewingKey {
+	return signer.readonlyKey
+}
+
+func (signer *RemoteSigner) DeriveSerialNumbers(snds []*crypto.Scalar) ([]*crypto.Point, error) {
+	params := signerDeriveSerialNumbersParams{SNDs: make([][]byte, len(snds))}
+	for i, snd := range snds {
+		if snd == nil {
+			return nil, errors.New("serial number derivator is nil")
+		}
+		params.SNDs[i] = snd.ToBytesS()
+	}
+
+	var result signerDeriveSerialNumbersResult
+	err := signer.call(signerMethodDeriveSerialNumbers, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	serialNumbers := make([]*crypto.Point, len(result.SerialNumbers))
+	for i, snBytes := range result.SerialNumbers {
+		serialNumbers[i], err = new(crypto.Point).FromBytesS(snBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid serial number from remote signer %v\n", err)
+		}
+	}
+	return serialNumbers, nil
+}
+
+func (signer *RemoteSigner) Prove(param *zkp.PaymentWitnessParam) (*zkp.PaymentProof, *crypto.Scalar, error) {
+	var result signerProveResult
+	err := signer.call(signerMethodProve, newSignerProveParams(param), &result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof := new(zkp.PaymentProof)
+	privacyErr := proof.SetBytes(result.Proof)
+	if privacyErr != nil {
+		return nil, nil, fmt.Errorf("Invalid proof from remote signer %v\n", privacyErr)
+	}
+	// tx with privacy is signed by commitment of secret key with randSK, it can not be signed without randSK
+	if param.HasPrivacy && (len(result.RandSK) != crypto.Ed25519KeySize || new(crypto.Scalar).FromBytesS(result.RandSK).IsZero()) {
+		return nil, nil, errors.New("Remote signer returns empty randomness of secret key for proof with privacy")
+	}
+	return proof, new(crypto.Scalar).FromBytesS(result.RandSK), nil
+}
+
+func (signer *RemoteSigner) Sign(txHash []byte, randSK *crypto.Scalar) ([]byte, []byte, error) {
+	params := signerSignParams{TxHash: txHash}
+	if randSK != nil {
+		params.RandSK = randSK.ToBytesS()
+	}
+
+	var result signerSignResult
+	err := signer.call(signerMethodSign, params, &result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.SigPubKey, result.Sig, nil
+}
+
+// NewSignerHandler returns a HTTP handler that serves signing operations of signer for RemoteSigner
+// the handler has no authentication, it must be served behind an authenticated channel (eg: mutual TLS)
+func NewSignerHandler(signer Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var res signerResponse
+		result, err := handleSignerRequest(signer, r)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Result, err = json.Marshal(result)
+			if err != nil {
+				res.Error = err.Error()
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+func handleSignerRequest(signer Signer, r *http.Request) (interface{}, error) {
+	if r.Method != http.MethodPost {
+		return nil, errors.New("method must be POST")
+	}
+	var req signerRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %v", err)
+	}
+
+	switch req.Method {
+	case signerMethodGetKeys:
+		keyWallet := new(wallet.KeyWallet)
+		keyWallet.KeySet.PaymentAddress = signer.PaymentAddress()
+		keyWallet.KeySet.ReadonlyKey = signer.ReadonlyKey()
+		return signerKeysResult{
+			PaymentAddress: keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
+			ReadonlyKey:    keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType),
+		}, nil
+
+	case signerMethodDeriveSerialNumbers:
+		var params signerDeriveSerialNumbersParams
+		err := json.Unmarshal(req.Params, &params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid params: %v", err)
+		}
+		snds := make([]*crypto.Scalar, len(params.SNDs))
+		for i, sndBytes := range params.SNDs {
+			snds[i] = new(crypto.Scalar).FromBytesS(sndBytes)
+		}
+		serialNumbers, err := signer.DeriveSerialNumbers(snds)
+		if err != nil {
+			return nil, err
+		}
+		result := signerDeriveSerialNumbersResult{SerialNumbers: make([][]byte, len(serialNumbers))}
+		for i, sn := range serialNumbers {
+			result.SerialNumbers[i] = sn.ToBytesS()
+		}
+		return result, nil
+
+	case signerMethodProve:
+		var params signerProveParams
+		err := json.Unmarshal(req.Params, &params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid params: %v", err)
+		}
+		param, err := params.toPaymentWitnessParam()
+		if err != nil {
+			return nil, err
+		}
+		proof, randSK, err := signer.Prove(param)
+		if err != nil {
+			return nil, err
+		}
+		return signerProveResult{Proof: proof.Bytes(), RandSK: randSK.ToBytesS()}, nil
+
+	case signerMethodSign:
+		var params signerSignParams
+		err := json.Unmarshal(req.Params, &params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid params: %v", err)
+		}
+		// empty RandSK is for tx without privacy
+		var randSK *crypto.Scalar
+		if len(params.RandSK) > 0 {
+			if len(params.RandSK) != crypto.Ed25519KeySize {
+				return nil, errors.New("invalid params: invalid randomness of secret key")
+			}
+			randSK = new(crypto.Scalar).FromBytesS(params.RandSK)
+		}
+		sigPubKey, sig, err := signer.Sign(params.TxHash, randSK)
+		if err != nil {
+			return nil, err
+		}
+		return signerSignResult{SigPubKey: sigPubKey, Sig: sig}, nil
+	}
+
+	return nil, fmt.Errorf("unknown method %v", req.Method)
+}
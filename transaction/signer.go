@@ -0,0 +1,185 @@
+package transaction
+
+import (
+	"errors"
+	"io"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+)
+
+// Signer holds the private key of the sender and exposes only the operations that are needed to build txs
+// so the private key can be kept in a separate signing service (see RemoteSigner)
+type Signer interface {
+	// PaymentAddress returns payment address of the sender
+	PaymentAddress() crypto.PaymentAddress
+
+	// ReadonlyKey returns readonly key of the sender that is used to get output coins
+	ReadonlyKey() crypto.ViewingKey
+
+	// DeriveSerialNumbers returns serial numbers of coins with serial number derivators snds
+	DeriveSerialNumbers(snds []*crypto.Scalar) ([]*crypto.Point, error)
+
+	// Prove creates payment proof that proves knowledge of the private key of input coins
+	// PrivateKey of param is ignored, it is set by signer
+	// it returns the randomness of secret key commitment that is used to sign the tx
+	Prove(param *zkp.PaymentWitnessParam) (*zkp.PaymentProof, *crypto.Scalar, error)
+
+	// Sign signs tx hash by Schnorr signature with key (private key, randSK)
+	// it returns public key of signature and signature in bytes
+	Sign(txHash []byte, randSK *crypto.Scalar) ([]byte, []byte, error)
+}
+
+// LocalSigner is a Signer that holds the private key in memory
+// it holds its own copy of the private key, which is zeroed by Destroy
+type LocalSigner struct {
+	keyWallet *wallet.KeyWallet
+	rand      io.Reader // source of randomness of txs created by signer, crypto/rand if nil
+}
+
+// NewLocalSigner returns a Signer from key wallet that contains private key
+// private key is copied, so keyWallet can be zeroed independently
+func NewLocalSigner(keyWallet *wallet.KeyWallet) (*LocalSigner, error) {
+	if keyWallet == nil || len(keyWallet.KeySet.PrivateKey) != common.PrivateKeySize {
+		return nil, errors.New("private key is invalid")
+	}
+	signerKeyWallet := new(wallet.KeyWallet)
+	err := signerKeyWallet.KeySet.InitFromPrivateKeyByte(append(crypto.PrivateKey{}, keyWallet.KeySet.PrivateKey...))
+	if err != nil {
+		return nil, err
+	}
+	return &LocalSigner{keyWallet: signerKeyWallet}, nil
+}
+
+// NewLocalSignerFromPrivateKeyStr returns a Signer from serialized private key
+func NewLocalSignerFromPrivateKeyStr(privateKeyStr string) (*LocalSigner, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	defer keyWallet.Zero()
+	return NewLocalSigner(keyWallet)
+}
+
+// NewLocalSignerFromSecureKey returns a Signer from serialized private key held by secureKey
+// the serialized private key is never converted to a string
+func NewLocalSignerFromSecureKey(secureKey *crypto.SecureKey) (*LocalSigner, error) {
+	keyWallet, err := wallet.DeserializeSecureKey(secureKey)
+	if err != nil {
+		return nil, err
+	}
+	defer keyWallet.Zero()
+	return NewLocalSigner(keyWallet)
+}
+
+// SetRandomness sets the source of SNDs, proofs, encryption of output coins and signatures of txs created by signer,
+// crypto/rand is used if rand is nil
+// it must only be set in tests, a tx created from known randomness reveals its private data
+func (signer *LocalSigner) SetRandomness(rand io.Reader) {
+	signer.rand = rand
+}
+
+// Destroy zeroes the private key of signer, signer can not be used afterward
+func (signer *LocalSigner) Destroy() {
+	signer.keyWallet.Zero()
+}
+
+func (signer *LocalSigner) PaymentAddress() crypto.PaymentAddress {
+	return signer.keyWallet.KeySet.PaymentAddress
+}
+
+func (signer *LocalSigner) ReadonlyKey() crypto.ViewingKey {
+	return signer.keyWallet.KeySet.ReadonlyKey
+}
+
+func (signer *LocalSigner) DeriveSerialNumbers(snds []*crypto.Scalar) ([]*crypto.Point, error) {
+	sk := new(crypto.Scalar).FromBytesS(signer.keyWallet.KeySet.PrivateKey)
+	defer sk.Zero()
+	serialNumbers := make([]*crypto.Point, len(snds))
+	for i, snd := range snds {
+		if snd == nil {
+			return nil, errors.New("serial number derivator is nil")
+		}
+		serialNumbers[i] = new(crypto.Point).Derive(crypto.PedCom.G[crypto.PedersenPrivateKeyIndex], sk, snd)
+	}
+	return serialNumbers, nil
+}
+
+func (signer *LocalSigner) Prove(param *zkp.PaymentWitnessParam) (*zkp.PaymentProof, *crypto.Scalar, error) {
+	witnessParam := *param
+	if witnessParam.Rand == nil {
+		witnessParam.Rand = signer.rand
+	}
+	witnessParam.PrivateKey = new(crypto.Scalar).FromBytesS(signer.keyWallet.KeySet.PrivateKey)
+	defer witnessParam.PrivateKey.Zero()
+
+	witness := new(zkp.PaymentWitness)
+	err := witness.Init(witnessParam)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := witness.Prove(witnessParam.HasPrivacy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	randSK := new(crypto.Scalar).FromUint64(0)
+	if witnessParam.HasPrivacy {
+		randSK = witness.GetRandSecretKey()
+	}
+	return proof, randSK, nil
+}
+
+func (signer *LocalSigner) Sign(txHash []byte, randSK *crypto.Scalar) ([]byte, []byte, error) {
+	if randSK == nil {
+		randSK = new(crypto.Scalar).FromUint64(0)
+	}
+	sk := new(crypto.Scalar).FromBytesS(signer.keyWallet.KeySet.PrivateKey)
+	defer sk.Zero()
+	sigKey := new(crypto.SchnorrPrivateKey)
+	sigKey.Set(sk, randSK)
+
+	signature, err := sigKey.SignWithRandomness(txHash, signer.rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sigKey.GetPublicKey().GetPublicKey().ToBytesS(), signature.Bytes(), nil
+}
+
+// deriveSerialNumbersBySigner sets serial numbers of output coins derived by signer
+func deriveSerialNumbersBySigner(signer Signer, outputCoins []*crypto.OutputCoin) ([]*crypto.Point, error) {
+	snds := make([]*crypto.Scalar, len(outputCoins))
+	for i, coin := range outputCoins {
+		snds[i] = coin.CoinDetails.GetSNDerivator()
+	}
+	serialNumbers, err := signer.DeriveSerialNumbers(snds)
+	if err != nil {
+		return nil, err
+	}
+	if len(serialNumbers) != len(outputCoins) {
+		return nil, errors.New("number of serial numbers is invalid")
+	}
+	for i, coin := range outputCoins {
+		coin.CoinDetails.SetSerialNumber(serialNumbers[i])
+	}
+	return serialNumbers, nil
+}
+
+// signerRandomness returns the source of randomness of txs created by signer,
+// it is nil (crypto/rand) for signers other than LocalSigner
+func signerRandomness(signer Signer) io.Reader {
+	if localSigner, ok := signer.(*LocalSigner); ok {
+		return localSigner.rand
+	}
+	return nil
+}
+
+// signerKeyWallet returns key wallet that only contains public keys of signer
+func signerKeyWallet(signer Signer) *wallet.KeyWallet {
+	keyWallet := new(wallet.KeyWallet)
+	keyWallet.KeySet.PaymentAddress = signer.PaymentAddress()
+	keyWallet.KeySet.ReadonlyKey = signer.ReadonlyKey()
+	return keyWallet
+}
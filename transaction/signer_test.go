@@ -0,0 +1,285 @@
+package transaction
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRemoteSigner(t *testing.T) (*LocalSigner, *RemoteSigner, func()) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	localSigner, err := NewLocalSigner(keyWallet)
+	assert.Equal(t, nil, err)
+
+	server := httptest.NewServer(NewSignerHandler(localSigner))
+	remoteSigner, err := NewRemoteSigner(server.URL, server.Client())
+	assert.Equal(t, nil, err)
+	return localSigner, remoteSigner, server.Close
+}
+
+func TestRemoteSignerKeys(t *testing.T) {
+	localSigner, remoteSigner, closeFn := newTestRemoteSigner(t)
+	defer closeFn()
+
+	assert.Equal(t, localSigner.PaymentAddress().Pk, remoteSigner.PaymentAddress().Pk)
+	assert.Equal(t, localSigner.PaymentAddress().Tk, remoteSigner.PaymentAddress().Tk)
+	assert.Equal(t, localSigner.ReadonlyKey().Rk, remoteSigner.ReadonlyKey().Rk)
+
+	outputCoins := newTestOutputCoins([]uint64{100, 200})
+	snds := []*crypto.Scalar{outputCoins[0].CoinDetails.GetSNDerivator(), outputCoins[1].CoinDetails.GetSNDerivator()}
+	expectedSNs, err := localSigner.DeriveSerialNumbers(snds)
+	assert.Equal(t, nil, err)
+	serialNumbers, err := remoteSigner.DeriveSerialNumbers(snds)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(serialNumbers))
+	for i := range serialNumbers {
+		assert.Equal(t, expectedSNs[i].ToBytesS(), serialNumbers[i].ToBytesS())
+	}
+}
+
+func TestRemoteSignerSign(t *testing.T) {
+	_, remoteSigner, closeFn := newTestRemoteSigner(t)
+	defer closeFn()
+
+	txHash := common.HashB([]byte("tx"))
+	sigPubKey, sig, err := remoteSigner.Sign(txHash, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte(remoteSigner.PaymentAddress().Pk), sigPubKey)
+
+	pk, err := new(crypto.Point).FromBytesS(sigPubKey)
+	assert.Equal(t, nil, err)
+	verifyKey := new(crypto.SchnorrPublicKey)
+	verifyKey.Set(pk)
+	signature := new(crypto.SchnSignature)
+	err = signature.SetBytes(sig)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, verifyKey.Verify(signature, txHash))
+	assert.Equal(t, false, verifyKey.Verify(signature, common.HashB([]byte("other tx"))))
+}
+
+func TestRemoteSignerProveNoPrivacy(t *testing.T) {
+	localSigner, remoteSigner, closeFn := newTestRemoteSigner(t)
+	defer closeFn()
+
+	pk, _ := new(crypto.Point).FromBytesS(localSigner.PaymentAddress().Pk)
+	inputCoins := make([]*crypto.InputCoin, 2)
+	for i, outputCoin := range newTestOutputCoins([]uint64{500, 700}) {
+		outputCoin.CoinDetails.SetPublicKey(pk)
+		outputCoin.CoinDetails.SetRandomness(crypto.RandomScalar())
+		assert.Equal(t, nil, outputCoin.CoinDetails.CommitAll())
+		inputCoins[i] = new(crypto.InputCoin).Init()
+		inputCoins[i].CoinDetails = outputCoin.CoinDetails
+	}
+	_, err := deriveSerialNumbersBySigner(remoteSigner, []*crypto.OutputCoin{{CoinDetails: inputCoins[0].CoinDetails}, {CoinDetails: inputCoins[1].CoinDetails}})
+	assert.Equal(t, nil, err)
+
+	outputCoins := newTestOutputCoins([]uint64{1100, 100})
+	for _, outputCoin := range outputCoins {
+		outputCoin.CoinDetails.SetPublicKey(pk)
+	}
+
+	proof, randSK, err := remoteSigner.Prove(&zkp.PaymentWitnessParam{
+		HasPrivacy:              false,
+		InputCoins:              inputCoins,
+		OutputCoins:             outputCoins,
+		PublicKeyLastByteSender: localSigner.PaymentAddress().Pk[len(localSigner.PaymentAddress().Pk)-1],
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, randSK.IsZero())
+	assert.Equal(t, 2, len(proof.GetInputCoins()))
+	assert.Equal(t, 2, len(proof.GetOutputCoins()))
+	assert.Equal(t, uint64(1100), proof.GetOutputCoins()[0].CoinDetails.GetValue())
+	assert.Equal(t, inputCoins[1].CoinDetails.GetSerialNumber().ToBytesS(), proof.GetInputCoins()[1].CoinDetails.GetSerialNumber().ToBytesS())
+}
+
+// testSignerWithoutRandSK is a signer that returns no randomness of secret key of proof
+type testSignerWithoutRandSK struct {
+	*LocalSigner
+}
+
+func (signer testSignerWithoutRandSK) Prove(param *zkp.PaymentWitnessParam) (*zkp.PaymentProof, *crypto.Scalar, error) {
+	proof, _, err := signer.LocalSigner.Prove(param)
+	return proof, new(crypto.Scalar).FromUint64(0), err
+}
+
+// newTestPaymentParam returns the param to prove a payment spending two coins of signer,
+// commitments of rings of privacy payment are added to commitments
+func newTestPaymentParam(t *testing.T, signer Signer, isPrivacy bool, commitments map[uint64][]byte) *zkp.PaymentWitnessParam {
+	senderPk := signer.PaymentAddress().Pk
+	pk, _ := new(crypto.Point).FromBytesS(senderPk)
+	inputCoins := make([]*crypto.InputCoin, 2)
+	for i, outputCoin := range newTestOutputCoins([]uint64{500, 700}) {
+		outputCoin.CoinDetails.SetPublicKey(pk)
+		outputCoin.CoinDetails.SetRandomness(crypto.RandomScalar())
+		assert.Equal(t, nil, outputCoin.CoinDetails.CommitAll())
+		inputCoins[i] = new(crypto.InputCoin).Init()
+		inputCoins[i].CoinDetails = outputCoin.CoinDetails
+	}
+	_, err := deriveSerialNumbersBySigner(signer, []*crypto.OutputCoin{{CoinDetails: inputCoins[0].CoinDetails}, {CoinDetails: inputCoins[1].CoinDetails}})
+	assert.Equal(t, nil, err)
+
+	outputCoins := newTestOutputCoins([]uint64{1100, 90})
+	for _, outputCoin := range outputCoins {
+		outputCoin.CoinDetails.SetPublicKey(pk)
+	}
+
+	param := &zkp.PaymentWitnessParam{
+		HasPrivacy:              isPrivacy,
+		InputCoins:              inputCoins,
+		OutputCoins:             outputCoins,
+		PublicKeyLastByteSender: senderPk[len(senderPk)-1],
+		Fee:                     10,
+	}
+	if isPrivacy {
+		for i, inputCoin := range inputCoins {
+			startIndex := uint64(len(commitments))
+			myIndex := startIndex + uint64(i+3)%crypto.CommitmentRingSize
+			for j := 0; j < crypto.CommitmentRingSize; j++ {
+				cmIndex := startIndex + uint64(j)
+				commitment := crypto.RandomPoint()
+				if cmIndex == myIndex {
+					commitment = inputCoin.CoinDetails.GetCoinCommitment()
+				}
+				commitments[cmIndex] = commitment.ToBytesS()
+				param.Commitments = append(param.Commitments, commitment)
+				param.CommitmentIndices = append(param.CommitmentIndices, cmIndex)
+			}
+			param.MyCommitmentIndices = append(param.MyCommitmentIndices, myIndex)
+		}
+	}
+	return param
+}
+
+func TestRemoteSignerProvePrivacy(t *testing.T) {
+	localSigner, remoteSigner, closeFn := newTestRemoteSigner(t)
+	defer closeFn()
+
+	commitments := make(map[uint64][]byte)
+	param := newTestPaymentParam(t, remoteSigner, true, commitments)
+	proof, randSK, err := remoteSigner.Prove(param)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, randSK.IsZero())
+	assert.Equal(t, 2, len(proof.GetOneOfManyProof()))
+
+	// proofs returned by remote signer are verified against commitments of rings
+	cmIndices := proof.GetCommitmentIndices()
+	for i, oneOfManyProof := range proof.GetOneOfManyProof() {
+		cmInputSum := new(crypto.Point).Add(proof.GetCommitmentInputSecretKey(), proof.GetCommitmentInputValue()[i])
+		cmInputSum.Add(cmInputSum, proof.GetCommitmentInputSND()[i])
+		cmInputSum.Add(cmInputSum, proof.GetCommitmentInputShardID())
+		ring := make([]*crypto.Point, crypto.CommitmentRingSize)
+		for j := range ring {
+			commitment, err := new(crypto.Point).FromBytesS(commitments[cmIndices[i*crypto.CommitmentRingSize+j]])
+			assert.Equal(t, nil, err)
+			ring[j] = new(crypto.Point).Sub(commitment, cmInputSum)
+		}
+		oneOfManyProof.Statement.Set(ring)
+		isValid, err := oneOfManyProof.Verify()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, isValid)
+
+		isValid, err = proof.GetSerialNumberProof()[i].Verify(nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, isValid)
+	}
+	isValid, err := proof.GetAggregatedRangeProof().Verify()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, isValid)
+
+	// tx with privacy is signed by commitment of secret key, not public key
+	tx := &Tx{
+		Version:              1,
+		Type:                 common.TxNormalType,
+		LockTime:             time.Now().Unix(),
+		Fee:                  param.Fee,
+		Proof:                proof,
+		PubKeyLastByteSender: param.PublicKeyLastByteSender,
+	}
+	assert.Equal(t, nil, tx.signTxWithSigner(remoteSigner, randSK))
+	assert.Equal(t, proof.GetCommitmentInputSecretKey().ToBytesS(), tx.SigPubKey)
+	assert.NotEqual(t, []byte(localSigner.PaymentAddress().Pk), tx.SigPubKey)
+	sigPubKey, _ := new(crypto.Point).FromBytesS(tx.SigPubKey)
+	verifyKey := new(crypto.SchnorrPublicKey)
+	verifyKey.Set(sigPubKey)
+	signature := new(crypto.SchnSignature)
+	assert.Equal(t, nil, signature.SetBytes(tx.Sig))
+	assert.Equal(t, true, verifyKey.Verify(signature, tx.Hash()[:]))
+
+	// tx with privacy can not be signed without randomness of secret key
+	unsignedTx := *tx
+	unsignedTx.Sig = nil
+	unsignedTx.SigPubKey = nil
+	assert.NotEqual(t, nil, unsignedTx.signTxWithSigner(remoteSigner, nil))
+	assert.NotEqual(t, nil, unsignedTx.signTxWithSigner(remoteSigner, new(crypto.Scalar).FromUint64(0)))
+
+	// randomness of secret key is missing in response of remote signer
+	server := httptest.NewServer(NewSignerHandler(testSignerWithoutRandSK{localSigner}))
+	defer server.Close()
+	remoteSigner2, err := NewRemoteSigner(server.URL, server.Client())
+	assert.Equal(t, nil, err)
+	_, _, err = remoteSigner2.Prove(newTestPaymentParam(t, remoteSigner2, true, commitments))
+	assert.NotEqual(t, nil, err)
+	_, _, err = remoteSigner2.Prove(newTestPaymentParam(t, remoteSigner2, false, commitments))
+	assert.Equal(t, nil, err)
+}
+
+func TestRemoteSignerError(t *testing.T) {
+	_, remoteSigner, closeFn := newTestRemoteSigner(t)
+	defer closeFn()
+
+	_, err := remoteSigner.DeriveSerialNumbers([]*crypto.Scalar{nil})
+	assert.NotEqual(t, nil, err)
+
+	var res signerKeysResult
+	err = remoteSigner.call("unknown", nil, &res)
+	assert.NotEqual(t, nil, err)
+
+	// randomness of secret key must be empty or 32 bytes
+	var signRes signerSignResult
+	err = remoteSigner.call(signerMethodSign, signerSignParams{TxHash: common.HashB([]byte("tx")), RandSK: []byte{1, 2, 3}}, &signRes)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLocalSignerDestroy(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	privateKeyStr := keyWallet.Base58CheckSerialize(wallet.PriKeyType)
+	signer, err := NewLocalSignerFromSecureKey(crypto.NewSecureKey([]byte(privateKeyStr)))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, keyWallet.KeySet.PaymentAddress.Pk, signer.PaymentAddress().Pk)
+
+	// signer holds its own copy of private key
+	signer2, err := NewLocalSigner(keyWallet)
+	assert.Equal(t, nil, err)
+	signer2.Destroy()
+	assert.NotEqual(t, make([]byte, 32), []byte(keyWallet.KeySet.PrivateKey))
+	assert.Equal(t, make([]byte, 32), []byte(signer2.keyWallet.KeySet.PrivateKey))
+
+	_, _, err = signer.Sign(common.HashB([]byte("tx")), nil)
+	assert.Equal(t, nil, err)
+}
+
+func TestLocalSignerSetRandomness(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	txHash := common.HashB([]byte("tx"))
+	signatures := make([][]byte, 2)
+	for i := range signatures {
+		signer, err := NewLocalSigner(keyWallet)
+		assert.Equal(t, nil, err)
+		signer.SetRandomness(crypto.NewDRBG([]byte("seed")))
+		_, signatures[i], err = signer.Sign(txHash, nil)
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, signatures[0], signatures[1])
+
+	// signer without randomness uses crypto/rand
+	signer, _ := NewLocalSigner(keyWallet)
+	_, signature, err := signer.Sign(txHash, nil)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, signatures[0], signature)
+}
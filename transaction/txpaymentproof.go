@@ -0,0 +1,192 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/toolkit"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+)
+
+// txPaymentProofSize = tx hash + output index + value + randomness
+const txPaymentProofSize = common.HashSize + 1 + common.Uint64Size + crypto.Ed25519KeySize
+
+// TxPaymentProof is a proof of send of an output coin of a tx, it reveals the value and the randomness of the coin,
+// which are encrypted for the receiver in privacy txs.
+// the sender gives it to the receiver or an auditor to resolve a payment dispute without sharing viewing keys
+type TxPaymentProof struct {
+	TxHash      common.Hash
+	OutputIndex byte
+	Value       uint64
+	Randomness  *crypto.Scalar
+}
+
+// setOutputOpenings keeps values and randomness of output coins before they are hidden in privacy txs
+func (tx *Tx) setOutputOpenings(outputCoins []*crypto.OutputCoin) {
+	tx.outputOpenings = make([]*toolkit.Opening, len(outputCoins))
+	for i, coin := range outputCoins {
+		tx.outputOpenings[i] = &toolkit.Opening{
+			Value: coin.CoinDetails.GetValue(),
+			Blind: coin.CoinDetails.GetRandomness(),
+		}
+	}
+}
+
+func newTxPaymentProof(txHash *common.Hash, outputOpenings []*toolkit.Opening, outputIndex int) (*TxPaymentProof, error) {
+	if len(outputOpenings) == 0 {
+		return nil, errors.New("output coins of tx are unknown, tx is not created by this sender")
+	}
+	if outputIndex < 0 || outputIndex >= len(outputOpenings) {
+		return nil, fmt.Errorf("output index %v is out of range", outputIndex)
+	}
+	opening := outputOpenings[outputIndex]
+	if opening.Blind == nil {
+		return nil, errors.New("randomness of output coin is unknown")
+	}
+	return &TxPaymentProof{
+		TxHash:      *txHash,
+		OutputIndex: byte(outputIndex),
+		Value:       opening.Value,
+		Randomness:  new(crypto.Scalar).Set(opening.Blind),
+	}, nil
+}
+
+// GetTxPaymentProof returns the payment proof of output coin at outputIndex of tx
+// it is only available in the tx object created by the sender, output coins of txs on chain can not be opened
+func (tx *Tx) GetTxPaymentProof(outputIndex int) (*TxPaymentProof, error) {
+	return newTxPaymentProof(tx.Hash(), tx.outputOpenings, outputIndex)
+}
+
+// getTxPaymentProofs returns payment proofs of output coins of tx by payment addresses of receivers,
+// the output coin at index i is sent to paymentAddrStrs[i], the following output coin is the change of the sender
+func (tx *Tx) getTxPaymentProofs(paymentAddrStrs []string) (map[string]*TxPaymentProof, error) {
+	proofs := make(map[string]*TxPaymentProof, len(paymentAddrStrs))
+	for i, paymentAddrStr := range paymentAddrStrs {
+		proof, err := tx.GetTxPaymentProof(i)
+		if err != nil {
+			return nil, err
+		}
+		proofs[paymentAddrStr] = proof
+	}
+	return proofs, nil
+}
+
+// GetTxPaymentProof returns the payment proof of PRV output coin at outputIndex of tx, with the hash of the token tx
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) GetTxPaymentProof(outputIndex int) (*TxPaymentProof, error) {
+	return newTxPaymentProof(txCustomTokenPrivacy.Hash(), txCustomTokenPrivacy.Tx.outputOpenings, outputIndex)
+}
+
+// Bytes returns tx hash || output index || value || randomness
+func (proof TxPaymentProof) Bytes() []byte {
+	if proof.Randomness == nil {
+		return []byte{}
+	}
+	var bytes []byte
+	bytes = append(bytes, proof.TxHash[:]...)
+	bytes = append(bytes, proof.OutputIndex)
+	bytes = append(bytes, common.Uint64ToBytes(proof.Value)...)
+	bytes = append(bytes, proof.Randomness.ToBytesS()...)
+	return bytes
+}
+
+func (proof *TxPaymentProof) SetBytes(bytes []byte) error {
+	if len(bytes) != txPaymentProofSize {
+		return errors.New("invalid length of payment proof")
+	}
+	offset := 0
+	copy(proof.TxHash[:], bytes[offset:offset+common.HashSize])
+	offset += common.HashSize
+	proof.OutputIndex = bytes[offset]
+	offset++
+	proof.Value, _ = common.BytesToUint64(bytes[offset : offset+common.Uint64Size])
+	offset += common.Uint64Size
+	proof.Randomness = new(crypto.Scalar).FromBytesS(bytes[offset:])
+	if !proof.Randomness.ScalarValid() {
+		return errors.New("invalid randomness of payment proof")
+	}
+	return nil
+}
+
+// MarshalJSON encodes proof in a base58 check string, so that it can be shared as text
+func (proof TxPaymentProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base58.Base58Check{}.Encode(proof.Bytes(), common.ZeroByte))
+}
+
+func (proof *TxPaymentProof) UnmarshalJSON(data []byte) error {
+	dataStr := ""
+	err := json.Unmarshal(data, &dataStr)
+	if err != nil {
+		return err
+	}
+	temp, _, err := base58.Base58Check{}.Decode(dataStr)
+	if err != nil {
+		return err
+	}
+	return proof.SetBytes(temp)
+}
+
+// Verify checks proof against the output coins of its tx on chain, e.g. Proof.GetOutputCoins() of GetTxByHash
+// it returns the public key of the receiver, which the verifier compares with the payment address of the receiver
+func (proof TxPaymentProof) Verify(outputCoins []*crypto.OutputCoin) ([]byte, error) {
+	if proof.Randomness == nil {
+		return nil, errors.New("randomness of payment proof is nil")
+	}
+	if int(proof.OutputIndex) >= len(outputCoins) {
+		return nil, fmt.Errorf("tx has no output coin at index %v", proof.OutputIndex)
+	}
+	outputCoin := outputCoins[proof.OutputIndex]
+	if outputCoin == nil || outputCoin.CoinDetails == nil || outputCoin.CoinDetails.GetPublicKey() == nil ||
+		outputCoin.CoinDetails.GetSNDerivator() == nil || outputCoin.CoinDetails.GetCoinCommitment() == nil {
+		return nil, errors.New("output coin of tx is invalid")
+	}
+
+	// the commitment of public key, snd of the coin on chain and value, randomness of proof
+	coin := new(crypto.Coin).Init()
+	coin.SetPublicKey(outputCoin.CoinDetails.GetPublicKey())
+	coin.SetSNDerivator(outputCoin.CoinDetails.GetSNDerivator())
+	coin.SetValue(proof.Value)
+	coin.SetRandomness(proof.Randomness)
+	err := coin.CommitAll()
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.IsPointEqual(coin.GetCoinCommitment(), outputCoin.CoinDetails.GetCoinCommitment()) {
+		return nil, errors.New("value or randomness of payment proof does not match output coin")
+	}
+	return coin.GetPublicKey().ToBytesS(), nil
+}
+
+// VerifyTxPaymentProof gets the tx of proof from chain and checks that its output coin is sent to paymentAddressStr
+// with the value of proof, only PRV output coins are checked
+func VerifyTxPaymentProof(rpcClient *rpcclient.HttpClient, proof *TxPaymentProof, paymentAddressStr string) (bool, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid payment address: %v", err)
+	}
+
+	tx, err := GetTxByHash(rpcClient, proof.TxHash.String())
+	if err != nil {
+		return false, err
+	}
+	if !tx.IsInBlock {
+		return false, errors.New("tx is not in a block")
+	}
+	if tx.Hash != proof.TxHash.String() || tx.Proof == nil {
+		return false, errors.New("tx of payment proof is not found")
+	}
+
+	receiver, err := proof.Verify(tx.Proof.GetOutputCoins())
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(receiver, keyWallet.KeySet.PaymentAddress.Pk) {
+		return false, errors.New("output coin is not sent to the payment address")
+	}
+	return true, nil
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPaymentTx returns a tx with output coins to receivers and the output coins as they are on chain in privacy txs
+func newTestPaymentTx(t *testing.T, receivers []*wallet.KeyWallet, values []uint64) (*Tx, []*crypto.OutputCoin) {
+	outputCoins := newTestOutputCoins(values)
+	onChainCoins := make([]*crypto.OutputCoin, len(outputCoins))
+	for i, outputCoin := range outputCoins {
+		pk, err := new(crypto.Point).FromBytesS(receivers[i].KeySet.PaymentAddress.Pk)
+		assert.Equal(t, nil, err)
+		outputCoin.CoinDetails.SetPublicKey(pk)
+		outputCoin.CoinDetails.SetRandomness(crypto.RandomScalar())
+		assert.Equal(t, nil, outputCoin.CoinDetails.CommitAll())
+
+		onChainCoins[i] = new(crypto.OutputCoin).Init()
+		onChainCoins[i].CoinDetails.SetPublicKey(pk)
+		onChainCoins[i].CoinDetails.SetSNDerivator(outputCoin.CoinDetails.GetSNDerivator())
+		onChainCoins[i].CoinDetails.SetCoinCommitment(outputCoin.CoinDetails.GetCoinCommitment())
+	}
+	tx := new(Tx)
+	tx.setOutputOpenings(outputCoins)
+	return tx, onChainCoins
+}
+
+func TestTxPaymentProof(t *testing.T) {
+	receiver, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	sender, _ := wallet.NewMasterKey([]byte{4, 5, 6})
+	tx, onChainCoins := newTestPaymentTx(t, []*wallet.KeyWallet{receiver, sender}, []uint64{1000, 234})
+
+	for i, value := range []uint64{1000, 234} {
+		proof, err := tx.GetTxPaymentProof(i)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, *tx.Hash(), proof.TxHash)
+		assert.Equal(t, value, proof.Value)
+
+		pk, err := proof.Verify(onChainCoins)
+		assert.Equal(t, nil, err)
+		if i == 0 {
+			assert.Equal(t, []byte(receiver.KeySet.PaymentAddress.Pk), pk)
+		} else {
+			assert.Equal(t, []byte(sender.KeySet.PaymentAddress.Pk), pk)
+		}
+
+		// the proof is shared as text
+		data, err := json.Marshal(proof)
+		assert.Equal(t, nil, err)
+		proof2 := new(TxPaymentProof)
+		assert.Equal(t, nil, json.Unmarshal(data, proof2))
+		assert.Equal(t, proof.Bytes(), proof2.Bytes())
+		_, err = proof2.Verify(onChainCoins)
+		assert.Equal(t, nil, err)
+	}
+
+	_, err := tx.GetTxPaymentProof(2)
+	assert.NotEqual(t, nil, err)
+	_, err = new(Tx).GetTxPaymentProof(0)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestTxPaymentProofFailed(t *testing.T) {
+	receiver, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	tx, onChainCoins := newTestPaymentTx(t, []*wallet.KeyWallet{receiver, receiver}, []uint64{1000, 1000})
+	proof, err := tx.GetTxPaymentProof(0)
+	assert.Equal(t, nil, err)
+
+	wrongValue := *proof
+	wrongValue.Value = 1001
+	_, err = wrongValue.Verify(onChainCoins)
+	assert.NotEqual(t, nil, err)
+
+	wrongRandomness := *proof
+	wrongRandomness.Randomness = crypto.RandomScalar()
+	_, err = wrongRandomness.Verify(onChainCoins)
+	assert.NotEqual(t, nil, err)
+
+	// the proof of an output coin is not a proof of another output coin with the same value
+	wrongIndex := *proof
+	wrongIndex.OutputIndex = 1
+	_, err = wrongIndex.Verify(onChainCoins)
+	assert.NotEqual(t, nil, err)
+	wrongIndex.OutputIndex = 2
+	_, err = wrongIndex.Verify(onChainCoins)
+	assert.NotEqual(t, nil, err)
+
+	assert.NotEqual(t, nil, new(TxPaymentProof).SetBytes(proof.Bytes()[1:]))
+	assert.NotEqual(t, nil, json.Unmarshal([]byte(`"abc"`), new(TxPaymentProof)))
+}
+
+func TestGetTxPaymentProofs(t *testing.T) {
+	receiver1, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	receiver2, _ := wallet.NewMasterKey([]byte{7, 8, 9})
+	sender, _ := wallet.NewMasterKey([]byte{4, 5, 6})
+	tx, onChainCoins := newTestPaymentTx(t, []*wallet.KeyWallet{receiver1, receiver2, sender}, []uint64{1000, 20, 234})
+
+	paymentAddrStrs := []string{
+		receiver1.Base58CheckSerialize(wallet.PaymentAddressType),
+		receiver2.Base58CheckSerialize(wallet.PaymentAddressType),
+	}
+	proofs, err := tx.getTxPaymentProofs(paymentAddrStrs)
+	assert.Equal(t, nil, err)
+	// no proof of change of sender
+	assert.Equal(t, 2, len(proofs))
+	for i, receiver := range []*wallet.KeyWallet{receiver1, receiver2} {
+		proof := proofs[paymentAddrStrs[i]]
+		assert.Equal(t, byte(i), proof.OutputIndex)
+		pk, err := proof.Verify(onChainCoins)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte(receiver.KeySet.PaymentAddress.Pk), pk)
+	}
+
+	_, err = new(Tx).getTxPaymentProofs(paymentAddrStrs)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCreateAndSendNormalTxWithPaymentProofs(t *testing.T) {
+	sender, _ := wallet.NewMasterKey([]byte{4, 5, 6})
+	signer, err := NewLocalSigner(sender)
+	assert.Equal(t, nil, err)
+	receiver, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	paymentAddrStr := receiver.Base58CheckSerialize(wallet.PaymentAddressType)
+	paymentInfoParam := map[string]uint64{paymentAddrStr: 1000}
+
+	var sentTx Tx
+	rpcClient, calls, closeNode := newTestNode(t, map[string]testRPCHandler{
+		"listoutputcoins": func(params []json.RawMessage) interface{} {
+			return rpcclient.ListOutputCoins{Outputs: map[string][]rpcclient.OutCoin{
+				sender.Base58CheckSerialize(wallet.ReadonlyKeyType): newTestOutCoins(sender.KeySet.PaymentAddress.Pk, newTestOutputCoins([]uint64{1500})),
+			}}
+		},
+		"hasserialnumbers": func(params []json.RawMessage) interface{} {
+			return []bool{false}
+		},
+		"hassnderivators": func(params []json.RawMessage) interface{} {
+			return []bool{false}
+		},
+		"sendtransaction": func(params []json.RawMessage) interface{} {
+			txBytes, _, err := base58.Base58Check{}.Decode(unmarshalTestParam(t, params[0]))
+			assert.Equal(t, nil, err)
+			assert.Equal(t, nil, json.Unmarshal(txBytes, &sentTx))
+			return rpcclient.CreateTransactionResult{TxID: sentTx.Hash().String()}
+		},
+	})
+	defer closeNode()
+
+	// privacy txs can not be created, nothing is sent
+	_, _, err = CreateAndSendNormalTxWithPaymentProofs(rpcClient, signer, paymentInfoParam, 10, true)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 0, len(*calls))
+
+	txID, proofs, err := CreateAndSendNormalTxWithPaymentProofs(rpcClient, signer, paymentInfoParam, 10, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, sentTx.Hash().String(), txID)
+	assert.Equal(t, 0, len(sentTx.Proof.GetOneOfManyProof()))
+	assert.Equal(t, 1, len(proofs))
+	pk, err := proofs[paymentAddrStr].Verify(sentTx.Proof.GetOutputCoins())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte(receiver.KeySet.PaymentAddress.Pk), pk)
+}
@@ -9,10 +9,11 @@ import (
 	"github.com/0xkraken/incognito-sdk-golang/common/base58"
 	"github.com/0xkraken/incognito-sdk-golang/crypto"
 	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/toolkit"
 	"github.com/0xkraken/incognito-sdk-golang/metadata"
 	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
 	"github.com/0xkraken/incognito-sdk-golang/wallet"
-	"math/big"
+	"io"
 	"strconv"
 	"time"
 )
@@ -32,9 +33,10 @@ type Tx struct {
 	// Metadata, optional
 	Metadata metadata.Metadata
 	// private field, not use for json parser, only use as temp variable
-	sigPrivKey       []byte       // is ALWAYS private property of struct, if privacy: 64 bytes, and otherwise, 32 bytes
-	cachedHash       *common.Hash // cached hash data of tx
-	cachedActualSize *uint64      // cached actualsize data for tx
+	sigPrivKey       []byte             // is ALWAYS private property of struct, if privacy: 64 bytes, and otherwise, 32 bytes
+	cachedHash       *common.Hash       // cached hash data of tx
+	cachedActualSize *uint64            // cached actualsize data for tx
+	outputOpenings   []*toolkit.Opening // values and randomness of output coins, only known by the sender
 }
 
 func (tx Tx) String() string {
@@ -83,26 +85,41 @@ func (tx *Tx) Init(
 	metaData metadata.Metadata,
 	info []byte,
 	txVersion int8) (*Tx, error) {
-	senderFullKey := keyWallet.KeySet
-	senderPrivateKey := senderFullKey.PrivateKey
+	signer, err := NewLocalSigner(keyWallet)
+	if err != nil {
+		return nil, err
+	}
+	defer signer.Destroy()
+	return tx.InitWithSigner(rpcClient, signer, paymentInfo, fee, isPrivacy, metaData, info, txVersion)
+}
 
+// InitWithSigner is the same as Init, but private key of sender is only accessed through signer
+func (tx *Tx) InitWithSigner(
+	rpcClient *rpcclient.HttpClient,
+	signer Signer,
+	paymentInfo []*crypto.PaymentInfo,
+	fee uint64,
+	isPrivacy bool,
+	metaData metadata.Metadata,
+	info []byte,
+	txVersion int8) (*Tx, error) {
 	inputCoins := []*crypto.InputCoin{}
 	var err error
 	for {
 		// get input coins to spent
-		inputCoins, _, err = GetInputCoinsToCreateNormalTx(rpcClient, &senderPrivateKey, paymentInfo, fee)
+		inputCoins, _, err = GetInputCoinsToCreateTxBySigner(rpcClient, signer, paymentInfo, fee, common.PRVIDStr)
 		if err != nil {
 			return nil, err
 		}
 
 		// cache utxos for this transaction
-		err = tx.CacheUTXOs(keyWallet.KeySet.PaymentAddress.Pk, inputCoins)
+		err = tx.CacheUTXOs(signer.PaymentAddress().Pk, inputCoins)
 		if err == nil {
 			break
 		}
 	}
 
-	return tx.InitWithSpecificUTXOs(rpcClient, keyWallet, paymentInfo, fee, isPrivacy, metaData, info, txVersion, inputCoins)
+	return tx.InitWithSpecificUTXOsAndSigner(rpcClient, signer, paymentInfo, fee, isPrivacy, metaData, info, txVersion, inputCoins)
 }
 
 func (tx *Tx) InitWithSpecificUTXOs (
@@ -115,12 +132,31 @@ func (tx *Tx) InitWithSpecificUTXOs (
 	info []byte,
 	txVersion int8,
 	inputCoins []*crypto.InputCoin) (*Tx, error) {
+	signer, err := NewLocalSigner(keyWallet)
+	if err != nil {
+		return nil, err
+	}
+	defer signer.Destroy()
+	return tx.InitWithSpecificUTXOsAndSigner(rpcClient, signer, paymentInfo, fee, isPrivacy, metaData, info, txVersion, inputCoins)
+}
+
+// InitWithSpecificUTXOsAndSigner is the same as InitWithSpecificUTXOs,
+// but private key of sender is only accessed through signer
+func (tx *Tx) InitWithSpecificUTXOsAndSigner(
+	rpcClient *rpcclient.HttpClient,
+	signer Signer,
+	paymentInfo []*crypto.PaymentInfo,
+	fee uint64,
+	isPrivacy bool,
+	metaData metadata.Metadata,
+	info []byte,
+	txVersion int8,
+	inputCoins []*crypto.InputCoin) (*Tx, error) {
 
 	var err error
 	// get public key last byte of sender
-	senderFullKey := keyWallet.KeySet
-	pkLastByteSender := senderFullKey.PaymentAddress.Pk[len(senderFullKey.PaymentAddress.Pk)-1]
-	senderPrivateKey := senderFullKey.PrivateKey
+	senderPaymentAddress := signer.PaymentAddress()
+	pkLastByteSender := senderPaymentAddress.Pk[len(senderPaymentAddress.Pk)-1]
 
 	// check valid of input coins, payment infos
 	if len(inputCoins) > 255 {
@@ -211,7 +247,7 @@ func (tx *Tx) InitWithSpecificUTXOs (
 	if overBalance > 0 {
 		changePaymentInfo := new(crypto.PaymentInfo)
 		changePaymentInfo.Amount = uint64(overBalance)
-		changePaymentInfo.PaymentAddress = senderFullKey.PaymentAddress
+		changePaymentInfo.PaymentAddress = senderPaymentAddress
 		paymentInfo = append(paymentInfo, changePaymentInfo)
 	}
 
@@ -219,19 +255,20 @@ func (tx *Tx) InitWithSpecificUTXOs (
 	outputCoins := make([]*crypto.OutputCoin, len(paymentInfo))
 
 	// create SNDs for output coins
+	rand := signerRandomness(signer)
 	ok := true
 	sndOuts := make([]*crypto.Scalar, 0)
 
 	for ok {
 		for i := 0; i < len(paymentInfo); i++ {
-			sndOut := crypto.RandomScalar()
+			sndOut := crypto.RandomScalarFromReader(rand)
 			keyWallet := new(wallet.KeyWallet)
 			keyWallet.KeySet.PaymentAddress = paymentInfo[i].PaymentAddress
 			paymentAddrStr := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
 			for {
 				ok1, err := CheckSNDerivatorExistence(rpcClient, paymentAddrStr, []*crypto.Scalar{sndOut})
 				if err != nil || ok1[0] {
-					sndOut = crypto.RandomScalar()
+					sndOut = crypto.RandomScalarFromReader(rand)
 				} else {
 					break
 				}
@@ -286,11 +323,9 @@ func (tx *Tx) InitWithSpecificUTXOs (
 		}
 	}
 
-	// prepare witness for proving
-	witness := new(zkp.PaymentWitness)
+	// prove payment by signer
 	paymentWitnessParam := zkp.PaymentWitnessParam{
 		HasPrivacy:              isPrivacy,
-		PrivateKey:              new(crypto.Scalar).FromBytesS(senderPrivateKey),
 		InputCoins:              inputCoins,
 		OutputCoins:             outputCoins,
 		PublicKeyLastByteSender: pkLastByteSender,
@@ -298,29 +333,21 @@ func (tx *Tx) InitWithSpecificUTXOs (
 		CommitmentIndices:       commitmentIndexs,
 		MyCommitmentIndices:     myCommitmentIndexs,
 		Fee:                     fee,
+		Rand:                    rand,
 	}
-	err = witness.Init(paymentWitnessParam)
-	if err.(*crypto.PrivacyError) != nil {
-		jsonParam, _ := json.MarshalIndent(paymentWitnessParam, common.EmptyString, "  ")
-		return nil, errors.New(string(jsonParam))
-	}
-
-	tx.Proof, err = witness.Prove(isPrivacy)
-	if err.(*crypto.PrivacyError) != nil {
+	proof, randSK, err := signer.Prove(&paymentWitnessParam)
+	if err != nil {
 		jsonParam, _ := json.MarshalIndent(paymentWitnessParam, common.EmptyString, "  ")
-		return nil, errors.New(string(jsonParam))
+		return nil, fmt.Errorf("Can not prove payment %v: %v", err, string(jsonParam))
 	}
+	tx.Proof = proof
+	tx.setOutputOpenings(tx.Proof.GetOutputCoins())
 
-	// set private key for signing tx
-	sigPrivKey := []byte{}
 	if isPrivacy {
-		randSK := witness.GetRandSecretKey()
-		sigPrivKey = append(senderPrivateKey, randSK.ToBytesS()...)
-
 		// encrypt coin details (Randomness)
 		// hide information of output coins except coin commitments, public key, snDerivators
 		for i := 0; i < len(tx.Proof.GetOutputCoins()); i++ {
-			err = tx.Proof.GetOutputCoins()[i].Encrypt(paymentInfo[i].PaymentAddress.Tk)
+			err = tx.Proof.GetOutputCoins()[i].EncryptWithRandomness(paymentInfo[i].PaymentAddress.Tk, rand)
 			if err.(*crypto.PrivacyError) != nil {
 				return nil, err
 			}
@@ -337,15 +364,11 @@ func (tx *Tx) InitWithSpecificUTXOs (
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetPublicKey(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetRandomness(nil)
 		}
-
-	} else {
-		randSK := big.NewInt(0)
-		sigPrivKey = append(senderPrivateKey, randSK.Bytes()...)
 	}
 
 	// sign tx
 	tx.PubKeyLastByteSender = pkLastByteSender
-	err = tx.SignTx(sigPrivKey)
+	err = tx.signTxWithSigner(signer, randSK)
 	if err != nil {
 		return nil, err
 	}
@@ -353,8 +376,33 @@ func (tx *Tx) InitWithSpecificUTXOs (
 	return tx, nil
 }
 
+// signTxWithSigner signs tx by signer with randomness randSK of secret key commitment
+func (tx *Tx) signTxWithSigner(signer Signer, randSK *crypto.Scalar) error {
+	if tx.Sig != nil {
+		return errors.New("input transaction must be an unsigned one")
+	}
+	// tx with privacy is signed by commitment of secret key, its randomness can not be empty
+	if tx.Proof != nil && len(tx.Proof.GetOneOfManyProof()) > 0 && (randSK == nil || randSK.IsZero()) {
+		return errors.New("randomness of secret key of tx with privacy is empty")
+	}
+
+	sigPubKey, sig, err := signer.Sign(tx.Hash()[:], randSK)
+	if err != nil {
+		return err
+	}
+	tx.SigPubKey = sigPubKey
+	tx.Sig = sig
+	return nil
+}
+
 // signTx - signs tx
 func (tx *Tx) SignTx(sigPrivKey []byte) error {
+	return tx.SignTxWithRandomness(sigPrivKey, nil)
+}
+
+// SignTxWithRandomness is the same as SignTx, but nonce of signature is read from rand,
+// crypto/rand is used if rand is nil
+func (tx *Tx) SignTxWithRandomness(sigPrivKey []byte, rand io.Reader) error {
 	//Check input transaction
 	if tx.Sig != nil {
 		return errors.New("input transaction must be an unsigned one")
@@ -371,7 +419,7 @@ func (tx *Tx) SignTx(sigPrivKey []byte) error {
 	// save public key for verification signature tx
 	tx.SigPubKey = sigKey.GetPublicKey().GetPublicKey().ToBytesS()
 
-	signature, err := sigKey.Sign(tx.Hash()[:])
+	signature, err := sigKey.SignWithRandomness(tx.Hash()[:], rand)
 	if err != nil {
 		return err
 	}
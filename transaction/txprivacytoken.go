@@ -1,17 +1,171 @@
 package transaction
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/metadata"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+)
+
 // TxCustomTokenPrivacy is class tx which is inherited from P tx(supporting privacy) for fee
 // and contain data(with supporting privacy format) to support issuing and transfer a custom token(token from end-user, look like erc-20)
 // Dev or end-user can use this class tx to create an token type which use personal purpose
 // TxCustomTokenPrivacy is an advance format of TxNormalToken
 // so that user need to spend a lot fee to create this class tx
-//type TxCustomTokenPrivacy struct {
-//	Tx                                    // inherit from normal tx of P(supporting privacy) with a high fee to ensure that tx could contain a big data of privacy for token
-//	TxPrivacyTokenData TxPrivacyTokenData `json:"TxTokenPrivacyData"` // supporting privacy format
-//	// private field, not use for json parser, only use as temp variable
-//	cachedHash *common.Hash // cached hash data of tx
-//}
-//
+type TxCustomTokenPrivacy struct {
+	Tx                                    // inherit from normal tx of P(supporting privacy) with a high fee to ensure that tx could contain a big data of privacy for token
+	TxPrivacyTokenData TxPrivacyTokenData `json:"TxTokenPrivacyData"` // supporting privacy format
+	// private field, not use for json parser, only use as temp variable
+	cachedHash *common.Hash // cached hash data of tx
+}
+
+func (txCustomTokenPrivacy TxCustomTokenPrivacy) String() string {
+	// get hash of tx
+	record := txCustomTokenPrivacy.Tx.Hash().String()
+	// add more hash of tx custom token data privacy
+	tokenPrivacyDataHash, _ := txCustomTokenPrivacy.TxPrivacyTokenData.Hash()
+	record += tokenPrivacyDataHash.String()
+	if txCustomTokenPrivacy.Metadata != nil {
+		record += string(txCustomTokenPrivacy.Metadata.Hash()[:])
+	}
+	return record
+}
+
+// Hash returns the hash of all fields of the transaction
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Hash() *common.Hash {
+	if txCustomTokenPrivacy.cachedHash != nil {
+		return txCustomTokenPrivacy.cachedHash
+	}
+	// final hash
+	hash := common.HashH([]byte(txCustomTokenPrivacy.String()))
+	txCustomTokenPrivacy.cachedHash = &hash
+	return &hash
+}
+
+// Init - build normal tx component for fee (in PRV) and privacy custom token data
+// only CustomTokenTransfer is supported
+// if tokenParams.TokenInput is empty, token utxos of sender are chosen to spend
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(
+	rpcClient *rpcclient.HttpClient,
+	keyWallet *wallet.KeyWallet,
+	paymentInfo []*crypto.PaymentInfo,
+	fee uint64,
+	tokenParams *CustomTokenPrivacyParamTx,
+	metaData metadata.Metadata,
+	info []byte,
+	txVersion int8) (*TxCustomTokenPrivacy, error) {
+	if tokenParams == nil {
+		return nil, errors.New("token params is nil")
+	}
+	if tokenParams.TokenTxType != CustomTokenTransfer {
+		return nil, fmt.Errorf("token tx type %v is not supported", tokenParams.TokenTxType)
+	}
+	propertyID, err := new(common.Hash).NewHashFromStr(tokenParams.PropertyID)
+	if err != nil {
+		return nil, errors.New("TokenID is invalid")
+	}
+
+	// get token input coins to spend
+	senderPrivateKey := keyWallet.KeySet.PrivateKey
+	tokenInputCoins := tokenParams.TokenInput
+	if len(tokenInputCoins) == 0 {
+		for {
+			tokenInputCoins, _, err = GetInputCoinsToCreateTxByToken(
+				rpcClient, &senderPrivateKey, tokenParams.Receiver, tokenParams.Fee, tokenParams.PropertyID)
+			if err != nil {
+				return nil, err
+			}
+
+			// cache utxos for this transaction
+			err = AddUTXOsToCache(keyWallet.KeySet.PaymentAddress.Pk, "TxID", tokenInputCoins)
+			if err == nil {
+				break
+			}
+		}
+	}
+
+	// make a transferring for privacy custom token
+	// fee always 0 and reuse function of normal tx for custom token ID
+	tokenTx := new(Tx)
+	tokenTx, err = tokenTx.InitWithSpecificUTXOs(
+		rpcClient, keyWallet, tokenParams.Receiver, tokenParams.Fee, false, nil, nil, txVersion, tokenInputCoins)
+	if err != nil {
+		RemoveUTXOsFromCache(keyWallet.KeySet.PaymentAddress.Pk, tokenInputCoins)
+		return nil, err
+	}
+
+	// init data for tx PRV for fee
+	normalTx := new(Tx)
+	normalTx, err = normalTx.Init(rpcClient, keyWallet, paymentInfo, fee, false, metaData, info, txVersion)
+	if err != nil {
+		RemoveUTXOsFromCache(keyWallet.KeySet.PaymentAddress.Pk, tokenInputCoins)
+		return nil, err
+	}
+	// override TxCustomTokenPrivacyType type
+	normalTx.Type = common.TxCustomTokenPrivacyType
+
+	txCustomTokenPrivacy.Tx = *normalTx
+	txCustomTokenPrivacy.TxPrivacyTokenData = TxPrivacyTokenData{
+		TxNormal:       *tokenTx,
+		PropertyID:     *propertyID,
+		PropertyName:   tokenParams.PropertyName,
+		PropertySymbol: tokenParams.PropertySymbol,
+		Type:           tokenParams.TokenTxType,
+		Mintable:       tokenParams.Mintable,
+	}
+	txCustomTokenPrivacy.cachedHash = nil
+
+	return txCustomTokenPrivacy, nil
+}
+
+// GetInputCoins returns input coins in both PRV and token
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) GetInputCoins() []*crypto.InputCoin {
+	inputCoins := []*crypto.InputCoin{}
+	if txCustomTokenPrivacy.Proof != nil {
+		inputCoins = append(inputCoins, txCustomTokenPrivacy.Proof.GetInputCoins()...)
+	}
+	if txCustomTokenPrivacy.TxPrivacyTokenData.TxNormal.Proof != nil {
+		inputCoins = append(inputCoins, txCustomTokenPrivacy.TxPrivacyTokenData.TxNormal.Proof.GetInputCoins()...)
+	}
+	return inputCoins
+}
+
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Send(rpcClient *rpcclient.HttpClient) (string, error) {
+	txBytes, err := json.Marshal(txCustomTokenPrivacy)
+	if err != nil {
+		return "", err
+	}
+	txStr := base58.Base58Check{}.Encode(txBytes, common.Base58Version)
+
+	var sendRawTxRes rpcclient.SendRawTokenTxRes
+	params := []interface{}{
+		txStr,
+	}
+	err = rpcClient.RPCCall("sendrawprivacycustomtokentransaction", params, &sendRawTxRes)
+	if err != nil {
+		return "", err
+	}
+	if sendRawTxRes.RPCError != nil {
+		return "", errors.New(sendRawTxRes.RPCError.Message)
+	}
+
+	return sendRawTxRes.Result.TxID, nil
+}
+
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) UpdateCacheUTXOsWithTxID(publicKey []byte) error {
+	return UpdateUTXOsCacheWithTxID(publicKey, txCustomTokenPrivacy.Hash().String(), txCustomTokenPrivacy.GetInputCoins())
+}
+
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) UnCacheUTXOs(publicKey []byte) {
+	RemoveUTXOsFromCache(publicKey, txCustomTokenPrivacy.GetInputCoins())
+}
+
 //func (txCustomTokenPrivacy *TxCustomTokenPrivacy) UnmarshalJSON(data []byte) error {
 //	tx := Tx{}
 //	err := json.Unmarshal(data, &tx)
@@ -41,37 +195,6 @@ package transaction
 //	return nil
 //}
 //
-//func (txCustomTokenPrivacy TxCustomTokenPrivacy) String() string {
-//	// get hash of tx
-//	record := txCustomTokencrypto.Tx.Hash().String()
-//	// add more hash of tx custom token data privacy
-//	tokenPrivacyDataHash, _ := txCustomTokencrypto.TxPrivacyTokenData.Hash()
-//	record += tokenPrivacyDataHash.String()
-//	if txCustomTokencrypto.Metadata != nil {
-//		record += string(txCustomTokencrypto.Metadata.Hash()[:])
-//	}
-//	return record
-//}
-//
-//func (txCustomTokenPrivacy TxCustomTokenPrivacy) JSONString() string {
-//	data, err := json.MarshalIndent(txCustomTokenPrivacy, "", "\t")
-//	if err != nil {
-//		fmt.Errorf(err)
-//		return ""
-//	}
-//	return string(data)
-//}
-//
-//// Hash returns the hash of all fields of the transaction
-//func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Hash() *common.Hash {
-//	if txCustomTokencrypto.cachedHash != nil {
-//		return txCustomTokencrypto.cachedHash
-//	}
-//	// final hash
-//	hash := common.HashH([]byte(txCustomTokencrypto.String()))
-//	return &hash
-//}
-//
 //// GetTxActualSize computes the virtual size of a given transaction
 //// size of this tx = (normal TxNormal size) + (custom token data size)
 //func (txCustomTokenPrivacy TxCustomTokenPrivacy) GetTxActualSize() uint64 {
@@ -152,182 +275,6 @@ package transaction
 //	return params
 //}
 //
-//// Init -  build normal tx component and privacy custom token data
-//func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenInitParams) error {
-//	var err error
-//	// init data for tx PRV for fee
-//	normalTx := Tx{}
-//	err = normalTx.Init(NewTxPrivacyInitParams(
-//		params.senderKey,
-//		params.paymentInfo,
-//		params.inputCoin,
-//		params.feeNativeCoin,
-//		params.hasPrivacyCoin,
-//		params.transactionStateDB,
-//		nil,
-//		params.metaData,
-//		params.info))
-//	if err != nil {
-//		return NewTransactionErr(PrivacyTokenInitPRVError, err)
-//	}
-//	// override TxCustomTokenPrivacyType type
-//	normalTx.Type = common.TxCustomTokenPrivacyType
-//	txCustomTokencrypto.Tx = normalTx
-//
-//	// check tx size
-//	limitFee := uint64(0)
-//	estimateTxSizeParam := NewEstimateTxSizeParam(len(params.inputCoin), len(params.paymentInfo),
-//		params.hasPrivacyCoin, nil, params.tokenParams, limitFee)
-//	if txSize := EstimateTxSize(estimateTxSizeParam); txSize > common.MaxTxSize {
-//		return NewTransactionErr(ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
-//	}
-//
-//	// check action type and create privacy custom toke data
-//	var handled = false
-//	// Add token data component
-//	switch params.tokenParams.TokenTxType {
-//	case CustomTokenInit:
-//		// case init a new privacy custom token
-//		{
-//			handled = true
-//			txCustomTokencrypto.TxPrivacyTokenData = TxPrivacyTokenData{
-//				Type:           params.tokenParams.TokenTxType,
-//				PropertyName:   params.tokenParams.PropertyName,
-//				PropertySymbol: params.tokenParams.PropertySymbol,
-//				Amount:         params.tokenParams.Amount,
-//			}
-//
-//			// issue token with data of privacy
-//			temp := Tx{}
-//			temp.Type = common.TxNormalType
-//			temp.Proof = new(zkp.PaymentProof)
-//			tempOutputCoin := make([]*crypto.OutputCoin, 1)
-//			tempOutputCoin[0] = new(crypto.OutputCoin)
-//			tempOutputCoin[0].CoinDetails = new(crypto.Coin)
-//			tempOutputCoin[0].CoinDetails.SetValue(params.tokenParams.Amount)
-//			PK, err := new(crypto.Point).FromBytesS(params.tokenParams.Receiver[0].PaymentAddress.Pk)
-//			if err != nil {
-//				return NewTransactionErr(DecompressPaymentAddressError, err)
-//			}
-//			tempOutputCoin[0].CoinDetails.SetPublicKey(PK)
-//			tempOutputCoin[0].CoinDetails.SetRandomness(crypto.RandomScalar())
-//
-//			// set info coin for output coin
-//			if len(params.tokenParams.Receiver[0].Message) > 0 {
-//				if len(params.tokenParams.Receiver[0].Message) > crypto.MaxSizeInfoCoin {
-//					return NewTransactionErr(ExceedSizeInfoOutCoinError, nil)
-//				}
-//				tempOutputCoin[0].CoinDetails.SetInfo(params.tokenParams.Receiver[0].Message)
-//			}
-//
-//			sndOut := crypto.RandomScalar()
-//			tempOutputCoin[0].CoinDetails.SetSNDerivator(sndOut)
-//			temp.Proof.SetOutputCoins(tempOutputCoin)
-//
-//			// create coin commitment
-//			err = temp.Proof.GetOutputCoins()[0].CoinDetails.CommitAll()
-//			if err != nil {
-//				return NewTransactionErr(CommitOutputCoinError, err)
-//			}
-//			// get last byte
-//			temp.PubKeyLastByteSender = params.tokenParams.Receiver[0].PaymentAddress.Pk[len(params.tokenParams.Receiver[0].PaymentAddress.Pk)-1]
-//
-//			// sign Tx
-//			temp.SigPubKey = params.tokenParams.Receiver[0].PaymentAddress.Pk
-//			temp.sigPrivKey = *params.senderKey
-//			err = temp.signTx()
-//			if err != nil {
-//				fmt.Errorf(errors.New("can't sign this tx"))
-//				return NewTransactionErr(SignTxError, err)
-//			}
-//
-//			txCustomTokencrypto.TxPrivacyTokenData.TxNormal = temp
-//			hashInitToken, err := txCustomTokencrypto.TxPrivacyTokenData.Hash()
-//			if err != nil {
-//				fmt.Errorf(errors.New("can't hash this token data"))
-//				return NewTransactionErr(UnexpectedError, err)
-//			}
-//
-//			if params.tokenParams.Mintable {
-//				propertyID, err := common.Hash{}.NewHashFromStr(params.tokenParams.PropertyID)
-//				if err != nil {
-//					return NewTransactionErr(TokenIDInvalidError, err, propertyID.String())
-//				}
-//				txCustomTokencrypto.TxPrivacyTokenData.PropertyID = *propertyID
-//				txCustomTokencrypto.TxPrivacyTokenData.Mintable = true
-//			} else {
-//				//NOTICE: @merman update PropertyID calculated from hash of tokendata and shardID
-//				newHashInitToken := common.HashH(append(hashInitToken.GetBytes(), params.shardID))
-//				Logger.log.Debug("New Privacy Token %+v ", newHashInitToken)
-//				existed := statedb.PrivacyTokenIDExisted(params.transactionStateDB, newHashInitToken)
-//				if existed {
-//					fmt.Errorf("INIT Tx Custom Token Privacy is Existed", newHashInitToken)
-//					return NewTransactionErr(TokenIDExistedError, errors.New("this token is existed in network"))
-//				}
-//				txCustomTokencrypto.TxPrivacyTokenData.PropertyID = newHashInitToken
-//				Logger.log.Debugf("A new token privacy wil be issued with ID: %+v", txCustomTokencrypto.TxPrivacyTokenData.PropertyID.String())
-//			}
-//		}
-//	case CustomTokenTransfer:
-//		{
-//			handled = true
-//			// make a transfering for privacy custom token
-//			// fee always 0 and reuse function of normal tx for custom token ID
-//			temp := Tx{}
-//			propertyID, _ := common.Hash{}.NewHashFromStr(params.tokenParams.PropertyID)
-//			existed := statedb.PrivacyTokenIDExisted(params.transactionStateDB, *propertyID)
-//			if !existed {
-//				isBridgeToken := false
-//				allBridgeTokensBytes, err := statedb.GetAllBridgeTokens(params.bridgeStateDB)
-//				if err != nil {
-//					return NewTransactionErr(TokenIDExistedError, err)
-//				}
-//				if len(allBridgeTokensBytes) > 0 {
-//					var allBridgeTokens []*rawdbv2.BridgeTokenInfo
-//					err = json.Unmarshal(allBridgeTokensBytes, &allBridgeTokens)
-//					if err != nil {
-//						return NewTransactionErr(TokenIDExistedError, err)
-//					}
-//					for _, bridgeTokens := range allBridgeTokens {
-//						if propertyID.IsEqual(bridgeTokens.TokenID) {
-//							isBridgeToken = true
-//							break
-//						}
-//					}
-//				}
-//				if !isBridgeToken {
-//					return NewTransactionErr(TokenIDExistedError, errors.New("invalid Token ID"))
-//				}
-//			}
-//			Logger.log.Debugf("Token %+v wil be transfered with", propertyID)
-//			txCustomTokencrypto.TxPrivacyTokenData = TxPrivacyTokenData{
-//				Type:           params.tokenParams.TokenTxType,
-//				PropertyName:   params.tokenParams.PropertyName,
-//				PropertySymbol: params.tokenParams.PropertySymbol,
-//				PropertyID:     *propertyID,
-//				Mintable:       params.tokenParams.Mintable,
-//			}
-//			err := temp.Init(NewTxPrivacyInitParams(params.senderKey,
-//				params.tokenParams.Receiver,
-//				params.tokenParams.TokenInput,
-//				params.tokenParams.Fee,
-//				params.hasPrivacyToken,
-//				params.transactionStateDB,
-//				propertyID,
-//				nil,
-//				nil))
-//			if err != nil {
-//				return NewTransactionErr(PrivacyTokenInitTokenDataError, err)
-//			}
-//			txCustomTokencrypto.TxPrivacyTokenData.TxNormal = temp
-//		}
-//	}
-//	if !handled {
-//		return NewTransactionErr(PrivacyTokenTxTypeNotHandleError, errors.New("can't handle this TokenTxType"))
-//	}
-//	return nil
-//}
-//
 //// ValidateType - check type of tx
 //func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateType() bool {
 //	return txCustomTokencrypto.Type == common.TxCustomTokenPrivacyType
@@ -0,0 +1,245 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/aggregaterange"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/oneoutofmany"
+)
+
+// GetCommitmentFn returns commitment of token tokenID at index cmIndex in shard shardID,
+// it has the same params as GetCommitmentByIndex without rpc client
+type GetCommitmentFn func(tokenID *common.Hash, cmIndex uint64, shardID byte) ([]byte, error)
+
+// TxVerifyError is returned by VerifyTxBatch for each invalid tx
+type TxVerifyError struct {
+	Index  int    // index of tx in batch
+	TxHash string // hash of tx
+	Err    error
+}
+
+func (e *TxVerifyError) Error() string {
+	return fmt.Sprintf("tx %v at index %v is invalid: %v", e.TxHash, e.Index, e.Err)
+}
+
+// txBatchItem holds the proofs of a tx that are verified in batch
+type txBatchItem struct {
+	index           int
+	rangeProof      *aggregaterange.AggregatedRangeProof
+	oneOfManyProofs []*oneoutofmany.OneOutOfManyProof
+	err             error
+}
+
+// VerifyTxBatch verifies signatures, serial number proofs, one-out-of-many proofs and range proofs of PRV txs
+// range proofs and one-out-of-many proofs of all txs are verified together by multi-scalar multiplications,
+// if the batch fails, it is split in halves until the invalid txs are found
+// signatures are verified one by one: a signature is (e, z) without its nonce commitment R,
+// so R of each signature must be recomputed to check its challenge e, which can not be batched
+// getCommitmentFn is used to get the commitments of one-out-of-many proofs, they are not verified if it is nil
+// double spending, fee and sum of input and output values are not verified
+// it returns nil if all txs are valid, otherwise the errors of invalid txs in order of index
+func VerifyTxBatch(txs []*Tx, getCommitmentFn GetCommitmentFn) []*TxVerifyError {
+	txErrs := make([]*TxVerifyError, 0)
+	items := make([]*txBatchItem, 0)
+	for i, tx := range txs {
+		item, err := newTxBatchItem(i, tx, getCommitmentFn)
+		if err != nil {
+			txErrs = append(txErrs, newTxVerifyError(i, tx, err))
+			continue
+		}
+		items = append(items, item)
+	}
+
+	for _, item := range findInvalidBatchItems(items) {
+		txErrs = append(txErrs, newTxVerifyError(item.index, txs[item.index], item.err))
+	}
+	if len(txErrs) == 0 {
+		return nil
+	}
+	sort.Slice(txErrs, func(i, j int) bool {
+		return txErrs[i].Index < txErrs[j].Index
+	})
+	return txErrs
+}
+
+func newTxVerifyError(index int, tx *Tx, err error) *TxVerifyError {
+	txErr := &TxVerifyError{Index: index, Err: err}
+	if tx != nil {
+		txErr.TxHash = tx.Hash().String()
+	}
+	return txErr
+}
+
+// newTxBatchItem verifies the parts of tx that are not batched and returns the proofs to verify in batch
+func newTxBatchItem(index int, tx *Tx, getCommitmentFn GetCommitmentFn) (*txBatchItem, error) {
+	if tx == nil {
+		return nil, errors.New("tx is nil")
+	}
+	err := verifyTxSignature(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &txBatchItem{index: index}
+	if tx.Proof == nil {
+		return item, nil
+	}
+
+	// has no privacy
+	if len(tx.Proof.GetOneOfManyProof()) == 0 {
+		for i, snProof := range tx.Proof.GetSerialNumberNoPrivacyProof() {
+			valid, err := snProof.Verify(nil)
+			if !valid {
+				return nil, crypto.NewPrivacyErr(crypto.VerifySerialNumberNoPrivacyProofFailedErr, fmt.Errorf("input coin %v: %v", i, err))
+			}
+		}
+		return item, nil
+	}
+
+	// public key of signature must be the commitment of secret key of input coins
+	cmInputSK := tx.Proof.GetCommitmentInputSecretKey()
+	if cmInputSK == nil || !bytes.Equal(cmInputSK.ToBytesS(), tx.SigPubKey) {
+		return nil, errors.New("public key of signature is not the commitment of secret key of input coins")
+	}
+
+	oneOfManyProofs := tx.Proof.GetOneOfManyProof()
+	snProofs := tx.Proof.GetSerialNumberProof()
+	cmInputValue := tx.Proof.GetCommitmentInputValue()
+	cmInputSND := tx.Proof.GetCommitmentInputSND()
+	cmIndices := tx.Proof.GetCommitmentIndices()
+	if tx.Proof.GetCommitmentInputShardID() == nil || len(snProofs) != len(oneOfManyProofs) || len(cmInputValue) != len(oneOfManyProofs) ||
+		len(cmInputSND) != len(oneOfManyProofs) || len(cmIndices) != len(oneOfManyProofs)*crypto.CommitmentRingSize {
+		return nil, errors.New("number of proofs and commitments of input coins are mismatched")
+	}
+
+	for i, snProof := range snProofs {
+		valid, err := snProof.Verify(nil)
+		if !valid {
+			return nil, crypto.NewPrivacyErr(crypto.VerifySerialNumberPrivacyProofFailedErr, fmt.Errorf("input coin %v: %v", i, err))
+		}
+	}
+
+	if getCommitmentFn != nil {
+		tokenID := &common.Hash{}
+		_ = tokenID.SetBytes(common.PRVCoinID[:])
+		shardID := common.GetShardIDFromLastByte(tx.PubKeyLastByteSender)
+
+		item.oneOfManyProofs = make([]*oneoutofmany.OneOutOfManyProof, len(oneOfManyProofs))
+		for i, oneOfManyProof := range oneOfManyProofs {
+			// sum of commitments of input coin without randomness
+			cmInputSum := new(crypto.Point).Add(cmInputSK, cmInputValue[i])
+			cmInputSum.Add(cmInputSum, cmInputSND[i])
+			cmInputSum.Add(cmInputSum, tx.Proof.GetCommitmentInputShardID())
+
+			commitments := make([]*crypto.Point, crypto.CommitmentRingSize)
+			for j := range commitments {
+				cmIndex := cmIndices[i*crypto.CommitmentRingSize+j]
+				cmBytes, err := getCommitmentFn(tokenID, cmIndex, shardID)
+				if err != nil {
+					return nil, fmt.Errorf("can not get commitment from index=%d shardID=%+v: %v", cmIndex, shardID, err)
+				}
+				commitments[j], err = new(crypto.Point).FromBytesS(cmBytes)
+				if err != nil {
+					return nil, fmt.Errorf("can not decompress commitment from index=%d shardID=%+v: %v", cmIndex, shardID, err)
+				}
+				commitments[j].Sub(commitments[j], cmInputSum)
+			}
+
+			// statement is set on a copy to keep proof of tx unchanged
+			proof := *oneOfManyProof
+			proof.Statement = &oneoutofmany.OneOutOfManyStatement{Commitments: commitments}
+			item.oneOfManyProofs[i] = &proof
+		}
+	}
+
+	// range proof must be of the commitments of output values of tx, or values of other commitments are proved
+	cmOutputValue := tx.Proof.GetCommitmentOutputValue()
+	rangeProof := tx.Proof.GetAggregatedRangeProof()
+	if rangeProof == nil || rangeProof.IsNil() {
+		if len(cmOutputValue) > 0 {
+			return nil, errors.New("range proof of output values is missing")
+		}
+		return item, nil
+	}
+	cmsValue := rangeProof.GetCommitments()
+	if len(cmsValue) != len(cmOutputValue) {
+		return nil, errors.New("number of commitments of range proof and output values are mismatched")
+	}
+	for i := range cmOutputValue {
+		if !crypto.IsPointEqual(cmsValue[i], cmOutputValue[i]) {
+			return nil, fmt.Errorf("commitment %v of range proof is not the commitment of output value", i)
+		}
+	}
+	item.rangeProof = rangeProof
+	return item, nil
+}
+
+// verifyTxSignature verifies Schnorr signature of tx hash with SigPubKey
+func verifyTxSignature(tx *Tx) error {
+	if len(tx.Sig) != 2*common.BigIntSize && len(tx.Sig) != 3*common.BigIntSize {
+		return errors.New("invalid size of signature")
+	}
+	pk, err := new(crypto.Point).FromBytesS(tx.SigPubKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key of signature: %v", err)
+	}
+	verifyKey := new(crypto.SchnorrPublicKey)
+	verifyKey.Set(pk)
+
+	signature := new(crypto.SchnSignature)
+	err = signature.SetBytes(tx.Sig)
+	if err != nil {
+		return err
+	}
+	if !verifyKey.Verify(signature, tx.Hash()[:]) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// findInvalidBatchItems verifies the batched proofs of items,
+// if the batch fails it is split in halves until the invalid items are isolated
+func findInvalidBatchItems(items []*txBatchItem) []*txBatchItem {
+	if len(items) == 0 {
+		return nil
+	}
+	err := verifyBatchItems(items)
+	if err == nil {
+		return nil
+	}
+	if len(items) == 1 {
+		items[0].err = err
+		return []*txBatchItem{items[0]}
+	}
+	mid := len(items) / 2
+	return append(findInvalidBatchItems(items[:mid]), findInvalidBatchItems(items[mid:])...)
+}
+
+// verifyBatchItems verifies range proofs and one-out-of-many proofs of all items in batch
+func verifyBatchItems(items []*txBatchItem) error {
+	rangeProofs := make([]*aggregaterange.AggregatedRangeProof, 0)
+	oneOfManyProofs := make([]*oneoutofmany.OneOutOfManyProof, 0)
+	for _, item := range items {
+		if item.rangeProof != nil {
+			rangeProofs = append(rangeProofs, item.rangeProof)
+		}
+		oneOfManyProofs = append(oneOfManyProofs, item.oneOfManyProofs...)
+	}
+
+	if len(rangeProofs) > 0 {
+		valid, err, _ := aggregaterange.VerifyBatchingAggregatedRangeProofs(rangeProofs)
+		if !valid {
+			return crypto.NewPrivacyErr(crypto.VerifyAggregatedProofFailedErr, err)
+		}
+	}
+	valid, err, _ := oneoutofmany.VerifyBatchingOneOutOfManyProofs(oneOfManyProofs)
+	if !valid {
+		return crypto.NewPrivacyErr(crypto.VerifyOneOutOfManyProofFailedErr, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/crypto/zkp/aggregaterange"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBatchTx creates a signed tx spending two coins of signer,
+// commitments of privacy tx are added to commitments at indices of its rings
+func newTestBatchTx(t *testing.T, signer Signer, isPrivacy bool, commitments map[uint64][]byte) *Tx {
+	return newTestBatchTxWithRangeProof(t, signer, isPrivacy, commitments, nil)
+}
+
+// newTestBatchTxWithRangeProof is newTestBatchTx with range proof of privacy tx replaced by rangeProof before signing
+func newTestBatchTxWithRangeProof(t *testing.T, signer Signer, isPrivacy bool, commitments map[uint64][]byte, rangeProof *aggregaterange.AggregatedRangeProof) *Tx {
+	param := newTestPaymentParam(t, signer, isPrivacy, commitments)
+	proof, randSK, err := signer.Prove(param)
+	assert.Equal(t, nil, err)
+	if rangeProof != nil {
+		*proof.GetAggregatedRangeProof() = *rangeProof
+	}
+	tx := &Tx{
+		Version:              1,
+		Type:                 common.TxNormalType,
+		LockTime:             time.Now().Unix(),
+		Fee:                  param.Fee,
+		Proof:                proof,
+		PubKeyLastByteSender: param.PublicKeyLastByteSender,
+	}
+	assert.Equal(t, nil, tx.signTxWithSigner(signer, randSK))
+	return tx
+}
+
+func TestVerifyTxBatch(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	signer, _ := NewLocalSigner(keyWallet)
+
+	commitments := make(map[uint64][]byte)
+	getCommitmentFn := func(tokenID *common.Hash, cmIndex uint64, shardID byte) ([]byte, error) {
+		assert.Equal(t, common.PRVCoinID, *tokenID)
+		commitment, ok := commitments[cmIndex]
+		if !ok {
+			return nil, errors.New("commitment not found")
+		}
+		return commitment, nil
+	}
+
+	txs := make([]*Tx, 5)
+	for i := range txs {
+		txs[i] = newTestBatchTx(t, signer, i%2 == 0, commitments)
+	}
+	assert.Equal(t, 0, len(VerifyTxBatch(txs, getCommitmentFn)))
+	assert.Equal(t, 0, len(VerifyTxBatch(txs, nil)))
+	assert.Equal(t, 0, len(VerifyTxBatch(nil, getCommitmentFn)))
+
+	// invalid signature of tx without privacy
+	txs[1].Sig[0] ^= 1
+	// commitment in ring of privacy tx is replaced
+	cmIndex := txs[2].Proof.GetCommitmentIndices()[5]
+	commitments[cmIndex] = crypto.RandomPoint().ToBytesS()
+
+	txErrs := VerifyTxBatch(txs, getCommitmentFn)
+	assert.Equal(t, 2, len(txErrs))
+	assert.Equal(t, 1, txErrs[0].Index)
+	assert.Equal(t, txs[1].Hash().String(), txErrs[0].TxHash)
+	assert.Equal(t, 2, txErrs[1].Index)
+	assert.Equal(t, crypto.ErrCodeMessage[crypto.VerifyOneOutOfManyProofFailedErr].Code, txErrs[1].Err.(*crypto.PrivacyError).GetCode())
+
+	// one-out-of-many proofs are not verified without commitments
+	txErrs = VerifyTxBatch(txs, nil)
+	assert.Equal(t, 1, len(txErrs))
+	assert.Equal(t, 1, txErrs[0].Index)
+
+	// signature of privacy tx must be signed by commitment of secret key
+	txs[4].SigPubKey = txs[3].SigPubKey
+	txErrs = VerifyTxBatch(txs[3:], nil)
+	assert.Equal(t, 1, len(txErrs))
+	assert.Equal(t, 1, txErrs[0].Index)
+}
+
+func TestVerifyTxBatchRangeProofOfOtherCommitments(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	signer, _ := NewLocalSigner(keyWallet)
+	commitments := make(map[uint64][]byte)
+
+	// a valid range proof of the output values of another tx
+	otherTx := newTestBatchTx(t, signer, true, commitments)
+	tx := newTestBatchTxWithRangeProof(t, signer, true, commitments, otherTx.Proof.GetAggregatedRangeProof())
+	valid, err, _ := aggregaterange.VerifyBatchingAggregatedRangeProofs([]*aggregaterange.AggregatedRangeProof{tx.Proof.GetAggregatedRangeProof()})
+	assert.Equal(t, true, valid)
+	assert.Equal(t, nil, err)
+
+	txErrs := VerifyTxBatch([]*Tx{otherTx, tx}, nil)
+	assert.Equal(t, 1, len(txErrs))
+	assert.Equal(t, 1, txErrs[0].Index)
+}
@@ -0,0 +1,146 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+)
+
+// WatchOnlyCoin is an output coin of a watch-only account
+// spent status is only known if serial number of the coin is supplied
+type WatchOnlyCoin struct {
+	Coin         *crypto.OutputCoin
+	SerialNumber string // in base58check, empty if it is not supplied
+	IsSpentKnown bool
+	IsSpent      bool
+}
+
+// WatchOnlyBalance summarizes output coins of a watch-only account
+// TotalReceived = SpentAmount + UnspentAmount + UnknownAmount
+type WatchOnlyBalance struct {
+	Coins         []*WatchOnlyCoin
+	TotalReceived uint64
+	SpentAmount   uint64
+	UnspentAmount uint64
+	UnknownAmount uint64 // amount of coins whose serial numbers are not supplied
+}
+
+// coinKey identifies an output coin by its SND in base58check
+func coinKey(coin *crypto.OutputCoin) string {
+	return base58.Base58Check{}.Encode(coin.CoinDetails.GetSNDerivator().ToBytesS(), common.ZeroByte)
+}
+
+// ExportSerialNumbers derives serial numbers of output coins with private key
+// it is run by the key holder (eg: offline), the result is supplied to GetWatchOnlyBalance
+// to know spent status of coins without exposing the private key
+// the result maps SND of coin to its serial number, both in base58check
+func ExportSerialNumbers(privateKeyStr string, outputCoins []*crypto.OutputCoin) (map[string]string, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return nil, fmt.Errorf("Can not deserialize private key %v\n", err)
+	}
+	defer keyWallet.Zero()
+	if len(keyWallet.KeySet.PrivateKey) != common.PrivateKeySize {
+		return nil, errors.New("private key is invalid")
+	}
+
+	serialNumbers, err := DeriveSerialNumbers(&keyWallet.KeySet.PrivateKey, outputCoins)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(outputCoins))
+	for i, coin := range outputCoins {
+		result[coinKey(coin)] = base58.Base58Check{}.Encode(serialNumbers[i].ToBytesS(), common.ZeroByte)
+	}
+	return result, nil
+}
+
+// newWatchOnlyBalance builds balance of outputCoins
+// checkSNFn returns whether serial numbers exist on network
+func newWatchOnlyBalance(
+	outputCoins []*crypto.OutputCoin,
+	serialNumbers map[string]string,
+	checkSNFn func(sns []*crypto.Point) ([]bool, error),
+) (*WatchOnlyBalance, error) {
+	balance := &WatchOnlyBalance{
+		Coins: make([]*WatchOnlyCoin, len(outputCoins)),
+	}
+	knownSNs := make([]*crypto.Point, 0)
+	knownCoins := make([]*WatchOnlyCoin, 0)
+	for i, coin := range outputCoins {
+		balance.Coins[i] = &WatchOnlyCoin{Coin: coin}
+		balance.TotalReceived += coin.CoinDetails.GetValue()
+
+		snStr, ok := serialNumbers[coinKey(coin)]
+		if !ok {
+			balance.UnknownAmount += coin.CoinDetails.GetValue()
+			continue
+		}
+		snBytes, _, err := base58.Base58Check{}.Decode(snStr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid serial number %v: %v\n", snStr, err)
+		}
+		sn, err := new(crypto.Point).FromBytesS(snBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid serial number %v: %v\n", snStr, err)
+		}
+		balance.Coins[i].SerialNumber = snStr
+		knownSNs = append(knownSNs, sn)
+		knownCoins = append(knownCoins, balance.Coins[i])
+	}
+
+	if len(knownSNs) > 0 {
+		isExisted, err := checkSNFn(knownSNs)
+		if err != nil {
+			return nil, err
+		}
+		if len(isExisted) != len(knownSNs) {
+			return nil, errors.New("invalid response of checking serial numbers")
+		}
+		for i, coin := range knownCoins {
+			coin.IsSpentKnown = true
+			coin.IsSpent = isExisted[i]
+			if coin.IsSpent {
+				balance.SpentAmount += coin.Coin.CoinDetails.GetValue()
+			} else {
+				balance.UnspentAmount += coin.Coin.CoinDetails.GetValue()
+			}
+		}
+	}
+	return balance, nil
+}
+
+// GetWatchOnlyBalance returns PRV balance of an account by its payment address and readonly key
+// serialNumbers is optional, it is the result of ExportSerialNumbers
+func GetWatchOnlyBalance(rpcClient *rpcclient.HttpClient, paymentAddressStr string, readonlyKeyStr string, serialNumbers map[string]string) (*WatchOnlyBalance, error) {
+	return GetWatchOnlyBalanceByToken(rpcClient, paymentAddressStr, readonlyKeyStr, common.PRVIDStr, serialNumbers)
+}
+
+// GetWatchOnlyBalanceByToken returns balance in tokenIDStr of an account by its payment address and readonly key
+// incoming coins are decrypted with readonly key, no private key is required
+func GetWatchOnlyBalanceByToken(
+	rpcClient *rpcclient.HttpClient,
+	paymentAddressStr string,
+	readonlyKeyStr string,
+	tokenIDStr string,
+	serialNumbers map[string]string,
+) (*WatchOnlyBalance, error) {
+	_, err := wallet.NewWatchOnlyKeyWallet(paymentAddressStr, readonlyKeyStr)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid watch-only keys %v\n", err)
+	}
+
+	outputCoins, err := GetListOutputCoinsByToken(rpcClient, paymentAddressStr, readonlyKeyStr, tokenIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("Can not get output coins of account: %v\n", err)
+	}
+
+	return newWatchOnlyBalance(outputCoins, serialNumbers, func(sns []*crypto.Point) ([]bool, error) {
+		return CheckExistenceSerialNumber(rpcClient, paymentAddressStr, sns, tokenIDStr)
+	})
+}
@@ -0,0 +1,89 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/0xkraken/incognito-sdk-golang/rpcclient"
+	"github.com/0xkraken/incognito-sdk-golang/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchOnlyBalance(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	privateKeyStr := keyWallet.Base58CheckSerialize(wallet.PriKeyType)
+	outputCoins := newTestOutputCoins([]uint64{100, 200, 300, 400})
+
+	// key holder exports serial numbers of the first three coins only
+	serialNumbers, err := ExportSerialNumbers(privateKeyStr, outputCoins[:3])
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(serialNumbers))
+
+	expectedSNs, _ := DeriveSerialNumbers(&keyWallet.KeySet.PrivateKey, outputCoins[:3])
+	var checkedSNs []*crypto.Point
+	balance, err := newWatchOnlyBalance(outputCoins, serialNumbers, func(sns []*crypto.Point) ([]bool, error) {
+		checkedSNs = sns
+		return []bool{true, false, true}, nil
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(checkedSNs))
+	for i := range checkedSNs {
+		assert.Equal(t, expectedSNs[i].ToBytesS(), checkedSNs[i].ToBytesS())
+	}
+
+	assert.Equal(t, uint64(1000), balance.TotalReceived)
+	assert.Equal(t, uint64(400), balance.SpentAmount)
+	assert.Equal(t, uint64(200), balance.UnspentAmount)
+	assert.Equal(t, uint64(400), balance.UnknownAmount)
+	assert.Equal(t, true, balance.Coins[0].IsSpentKnown)
+	assert.Equal(t, true, balance.Coins[0].IsSpent)
+	assert.Equal(t, false, balance.Coins[1].IsSpent)
+	assert.Equal(t, false, balance.Coins[3].IsSpentKnown)
+	assert.Equal(t, "", balance.Coins[3].SerialNumber)
+}
+
+func TestWatchOnlyBalanceWithoutSerialNumbers(t *testing.T) {
+	outputCoins := newTestOutputCoins([]uint64{100, 200})
+	balance, err := newWatchOnlyBalance(outputCoins, nil, func(sns []*crypto.Point) ([]bool, error) {
+		t.Fatal("serial numbers must not be checked")
+		return nil, nil
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(300), balance.TotalReceived)
+	assert.Equal(t, uint64(300), balance.UnknownAmount)
+
+	_, err = newWatchOnlyBalance(outputCoins, map[string]string{coinKey(outputCoins[0]): "invalid"}, nil)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestGetWatchOnlyBalanceByToken(t *testing.T) {
+	keyWallet, _ := wallet.NewMasterKey([]byte{1, 2, 3})
+	privateKeyStr := keyWallet.Base58CheckSerialize(wallet.PriKeyType)
+	paymentAddressStr := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	readonlyKeyStr := keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType)
+	tokenIDStr := common.Hash{5}.String()
+	outputCoins := newTestOutputCoins([]uint64{100, 200})
+	serialNumbers, err := ExportSerialNumbers(privateKeyStr, outputCoins)
+	assert.Equal(t, nil, err)
+
+	rpcClient, _, closeNode := newTestNode(t, map[string]testRPCHandler{
+		"listoutputcoins": func(params []json.RawMessage) interface{} {
+			return rpcclient.ListOutputCoins{Outputs: map[string][]rpcclient.OutCoin{
+				readonlyKeyStr: newTestOutCoins(keyWallet.KeySet.PaymentAddress.Pk, outputCoins),
+			}}
+		},
+		// serial numbers are checked in the token
+		"hasserialnumbers": func(params []json.RawMessage) interface{} {
+			assert.Equal(t, tokenIDStr, unmarshalTestParam(t, params[2]))
+			return []bool{true, false}
+		},
+	})
+	defer closeNode()
+
+	balance, err := GetWatchOnlyBalanceByToken(rpcClient, paymentAddressStr, readonlyKeyStr, tokenIDStr, serialNumbers)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(100), balance.SpentAmount)
+	assert.Equal(t, uint64(200), balance.UnspentAmount)
+}
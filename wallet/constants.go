@@ -21,3 +21,11 @@ const (
 	PaymentAddressType = byte(0x1) // Serialize wallet account key into string with only PAYMENT ADDRESS of account keyset
 	ReadonlyKeyType    = byte(0x2) // Serialize wallet account key into string with only READONLY KEY of account keyset
 )
+
+const (
+	HardenedKeyStart = uint32(0x80000000) // child indices from 2^31 are hardened in derivation paths
+	hardenedMarker   = "'"
+
+	// IncognitoCoinType is coin type of Incognito in derivation paths m/44'/587'/...
+	IncognitoCoinType = 587
+)
@@ -26,6 +26,10 @@ const (
 	NewMnemonicError
 	MnemonicInvalidError
 	InvalidSeserializedKey
+	InvalidDerivationPathErr
+	GenerateAccountErr
+	InvalidAccountExportErr
+	InvalidPaymentURIErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -53,6 +57,10 @@ var ErrCodeMessage = map[int]struct {
 	NewMnemonicError:      {-1015, "Can not create mnemonic"},
 	MnemonicInvalidError:  {-1016, "Mnemonic is invalid"},
 	InvalidSeserializedKey:  {-1016, "Serialized key is invalid"},
+	InvalidDerivationPathErr: {-1017, "Derivation path is invalid"},
+	GenerateAccountErr:       {-1018, "Can not generate account"},
+	InvalidAccountExportErr:  {-1019, "Account export is invalid"},
+	InvalidPaymentURIErr:     {-1020, "Payment URI is invalid"},
 }
 
 type WalletError struct {
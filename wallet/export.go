@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const accountExportVersion = 1
+
+// AccountExport is a versioned JSON document to move an account between wallets
+// private key is only included if it is exported with a passphrase, it is encrypted the same as wallet file
+type AccountExport struct {
+	Version             int
+	Name                string
+	Path                string `json:",omitempty"`
+	PaymentAddress      string
+	ReadonlyKey         string
+	EncryptedPrivateKey *EncryptedData `json:",omitempty"`
+}
+
+// ExportAccount returns account as JSON document
+// private key is encrypted with passphrase and included if passphrase is not empty and account is not watch-only
+func ExportAccount(account *AccountWallet, passphrase string) ([]byte, error) {
+	if account == nil || account.Key == nil {
+		return nil, NewWalletError(InvalidAccountExportErr, errors.New("account is nil"))
+	}
+	export := AccountExport{
+		Version:        accountExportVersion,
+		Name:           account.Name,
+		Path:           account.Path,
+		PaymentAddress: account.PaymentAddressStr(),
+		ReadonlyKey:    account.ReadonlyKeyStr(),
+	}
+	if passphrase != "" && !account.IsWatchOnly {
+		encryptedData, err := encryptWithPassphrase([]byte(account.PrivateKeyStr()), passphrase)
+		if err != nil {
+			return nil, err
+		}
+		export.EncryptedPrivateKey = encryptedData
+	}
+
+	result, err := json.Marshal(export)
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	return result, nil
+}
+
+// ParseAccountExport parses JSON document of ExportAccount
+// and checks that payment address and readonly key are of the same account
+func ParseAccountExport(data []byte) (*AccountExport, error) {
+	export := new(AccountExport)
+	err := json.Unmarshal(data, export)
+	if err != nil {
+		return nil, NewWalletError(JsonUnmarshalErr, err)
+	}
+	if export.Version != accountExportVersion {
+		return nil, NewWalletError(InvalidAccountExportErr, fmt.Errorf("unsupported version %v", export.Version))
+	}
+	_, err = NewWatchOnlyKeyWallet(export.PaymentAddress, export.ReadonlyKey)
+	if err != nil {
+		return nil, NewWalletError(InvalidAccountExportErr, err)
+	}
+	if export.Path != "" {
+		_, err = ParseDerivationPath(export.Path)
+		if err != nil {
+			return nil, NewWalletError(InvalidAccountExportErr, err)
+		}
+	}
+	return export, nil
+}
+
+// HasPrivateKey returns true if export contains encrypted private key
+func (export *AccountExport) HasPrivateKey() bool {
+	return export.EncryptedPrivateKey != nil
+}
+
+// Key returns key of exported account
+// if passphrase is empty or export has no private key, it returns a watch-only key
+func (export *AccountExport) Key(passphrase string) (*KeyWallet, error) {
+	watchOnlyKey, err := NewWatchOnlyKeyWallet(export.PaymentAddress, export.ReadonlyKey)
+	if err != nil {
+		return nil, NewWalletError(InvalidAccountExportErr, err)
+	}
+	if passphrase == "" || !export.HasPrivateKey() {
+		return watchOnlyKey, nil
+	}
+
+	privateKeyStr, err := decryptWithPassphrase(export.EncryptedPrivateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newKeyWalletFromPrivateKeyStr(string(privateKeyStr))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(key.KeySet.PaymentAddress.Pk, watchOnlyKey.KeySet.PaymentAddress.Pk) {
+		return nil, NewWalletError(InvalidAccountExportErr, errors.New("private key does not match payment address"))
+	}
+	return key, nil
+}
+
+// ImportAccountExport adds account from JSON document of ExportAccount with its exported name
+// the account is watch-only if passphrase is empty or the document has no private key
+func (w *Wallet) ImportAccountExport(data []byte, passphrase string) (*AccountWallet, error) {
+	export, err := ParseAccountExport(data)
+	if err != nil {
+		return nil, err
+	}
+	key, err := export.Key(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var account *AccountWallet
+	if len(key.KeySet.PrivateKey) == 0 {
+		account, err = w.ImportWatchOnlyAccount(export.PaymentAddress, export.ReadonlyKey, export.Name)
+	} else {
+		account, err = w.ImportAccount(key.Base58CheckSerialize(PriKeyType), export.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	account.Path = export.Path
+	return account, nil
+}
+
+// HexSerialize encodes the key corresponding to keyType in KeySet in hex
+// it is the same serialized data as Base58CheckSerialize
+func (key *KeyWallet) HexSerialize(keyType byte) string {
+	serializedKey, err := key.Serialize(keyType)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(serializedKey)
+}
+
+// HexDeserialize deserializes a key encoded by HexSerialize
+func HexDeserialize(data string) (*KeyWallet, error) {
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, NewWalletError(InvalidSeserializedKey, err)
+	}
+	return deserialize(b)
+}
@@ -0,0 +1,99 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for exporting and importing accounts
+*/
+
+func TestExportAccountWithPrivateKey(t *testing.T) {
+	StandardScryptN = LightScryptN
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	account, _ := w.CreateAccountFromPath("account 0", "m/44'/587'/0'/0/5")
+
+	data, err := ExportAccount(account, "passphrase")
+	assert.Equal(t, nil, err)
+
+	export, err := ParseAccountExport(data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "account 0", export.Name)
+	assert.Equal(t, "m/44'/587'/0'/0/5", export.Path)
+	assert.Equal(t, account.PaymentAddressStr(), export.PaymentAddress)
+	assert.Equal(t, account.ReadonlyKeyStr(), export.ReadonlyKey)
+	assert.Equal(t, true, export.HasPrivateKey())
+
+	_, err = export.Key("wrong passphrase")
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+
+	other, _ := NewWalletFromMnemonic("other", testMnemonic, "other")
+	imported, err := other.ImportAccountExport(data, "passphrase")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, account.PrivateKeyStr(), imported.PrivateKeyStr())
+	assert.Equal(t, account.Path, imported.Path)
+	assert.Equal(t, true, imported.IsImported)
+	assert.Equal(t, false, imported.IsWatchOnly)
+
+	_, err = other.ImportAccountExport(data, "passphrase")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountNameErr].code, err.(*WalletError).GetCode())
+}
+
+func TestExportAccountWatchOnly(t *testing.T) {
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	account, _ := w.CreateNewAccount("account 0")
+
+	data, err := ExportAccount(account, "")
+	assert.Equal(t, nil, err)
+	export, err := ParseAccountExport(data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, export.HasPrivateKey())
+
+	other, _ := NewWalletFromMnemonic("other", testMnemonic, "other")
+	imported, err := other.ImportAccountExport(data, "passphrase")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, imported.IsWatchOnly)
+	assert.Equal(t, account.PaymentAddressStr(), imported.PaymentAddressStr())
+	assert.Equal(t, "", imported.PrivateKeyStr())
+}
+
+func TestParseAccountExportInvalid(t *testing.T) {
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	account0, _ := w.CreateNewAccount("account 0")
+	account1, _ := w.CreateNewAccount("account 1")
+
+	_, err := ParseAccountExport([]byte("not json"))
+	assert.Equal(t, ErrCodeMessage[JsonUnmarshalErr].code, err.(*WalletError).GetCode())
+
+	export := AccountExport{
+		Version:        2,
+		PaymentAddress: account0.PaymentAddressStr(),
+		ReadonlyKey:    account0.ReadonlyKeyStr(),
+	}
+	data, _ := json.Marshal(export)
+	_, err = ParseAccountExport(data)
+	assert.Equal(t, ErrCodeMessage[InvalidAccountExportErr].code, err.(*WalletError).GetCode())
+
+	// keys of different accounts
+	export.Version = accountExportVersion
+	export.ReadonlyKey = account1.ReadonlyKeyStr()
+	data, _ = json.Marshal(export)
+	_, err = ParseAccountExport(data)
+	assert.Equal(t, ErrCodeMessage[InvalidAccountExportErr].code, err.(*WalletError).GetCode())
+}
+
+func TestHexSerialize(t *testing.T) {
+	key, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	for _, keyType := range []byte{PriKeyType, PaymentAddressType, ReadonlyKeyType} {
+		hexStr := key.HexSerialize(keyType)
+		decoded, err := HexDeserialize(hexStr)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, key.Base58CheckSerialize(keyType), decoded.Base58CheckSerialize(keyType))
+	}
+
+	_, err := HexDeserialize("zz")
+	assert.Equal(t, ErrCodeMessage[InvalidSeserializedKey].code, err.(*WalletError).GetCode())
+}
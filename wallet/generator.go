@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+)
+
+const (
+	anyShardID              = -1
+	defaultProgressInterval = 1000
+	base58Alphabet          = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// GeneratedAccount is an account found by AccountGenerator
+type GeneratedAccount struct {
+	Key      *KeyWallet
+	Index    uint32 // child index of master key, only for child key generator
+	Seed     []byte // seed of master key, only for random seed generator
+	Attempts uint64 // number of keys were tried
+}
+
+// AccountGenerator searches accounts whose shard ID and payment address prefix match the target in parallel
+// it derives child keys of a master key at increasing indices, or master keys from random seeds
+type AccountGenerator struct {
+	NumWorkers int
+	// OnProgress is called with the total number of tried keys after every ProgressInterval keys
+	// calls are not concurrent
+	OnProgress       func(attempts uint64)
+	ProgressInterval uint64
+
+	shardID    int    // anyShardID to accept all shards
+	prefix     string // prefix of serialized payment address, empty to accept all addresses
+	masterKey  *KeyWallet
+	startIndex uint32
+	progressMu sync.Mutex
+}
+
+func newAccountGenerator(shardID int, prefix string) (*AccountGenerator, error) {
+	if shardID != anyShardID && (shardID < 0 || shardID >= common.MaxShardNumber) {
+		return nil, NewWalletError(GenerateAccountErr, fmt.Errorf("shard ID must be in range [0, %v) or %v", common.MaxShardNumber, anyShardID))
+	}
+	for _, c := range prefix {
+		if !strings.ContainsRune(base58Alphabet, c) {
+			return nil, NewWalletError(GenerateAccountErr, fmt.Errorf("prefix contains non-base58 character %q", c))
+		}
+	}
+	return &AccountGenerator{
+		NumWorkers:       runtime.NumCPU(),
+		ProgressInterval: defaultProgressInterval,
+		shardID:          shardID,
+		prefix:           prefix,
+	}, nil
+}
+
+// NewAccountGenerator returns a generator that creates master keys from random seeds
+// until the account is in shardID (-1 for any shard) and its payment address starts with prefix
+// note that all payment addresses start with "12"
+func NewAccountGenerator(shardID int, prefix string) (*AccountGenerator, error) {
+	return newAccountGenerator(shardID, prefix)
+}
+
+// NewChildAccountGenerator returns a generator that derives child keys of masterKey from startIndex
+// until the account is in shardID (-1 for any shard) and its payment address starts with prefix
+// it always returns the lowest matching index, so the account can be restored by NewChildKey
+func NewChildAccountGenerator(masterKey *KeyWallet, startIndex uint32, shardID int, prefix string) (*AccountGenerator, error) {
+	if masterKey == nil {
+		return nil, NewWalletError(GenerateAccountErr, errors.New("master key is nil"))
+	}
+	g, err := newAccountGenerator(shardID, prefix)
+	if err != nil {
+		return nil, err
+	}
+	g.masterKey = masterKey
+	g.startIndex = startIndex
+	return g, nil
+}
+
+// isMatched checks shard ID and payment address prefix of key
+func (g *AccountGenerator) isMatched(key *KeyWallet) bool {
+	if g.shardID != anyShardID {
+		pk := key.KeySet.PaymentAddress.Pk
+		if int(common.GetShardIDFromLastByte(pk[len(pk)-1])) != g.shardID {
+			return false
+		}
+	}
+	if g.prefix != "" && !strings.HasPrefix(key.Base58CheckSerialize(PaymentAddressType), g.prefix) {
+		return false
+	}
+	return true
+}
+
+func (g *AccountGenerator) reportProgress(attempts uint64) {
+	if g.OnProgress == nil || g.ProgressInterval == 0 || attempts%g.ProgressInterval != 0 {
+		return
+	}
+	g.progressMu.Lock()
+	defer g.progressMu.Unlock()
+	g.OnProgress(attempts)
+}
+
+// Generate runs NumWorkers workers until a matching account is found
+// it returns error if stopCh is closed before that
+func (g *AccountGenerator) Generate(stopCh <-chan struct{}) (*GeneratedAccount, error) {
+	numWorkers := g.NumWorkers
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+
+	var (
+		attempts  uint64
+		nextIndex = uint64(g.startIndex)
+		found     *GeneratedAccount
+		workerErr error
+		mux       sync.Mutex
+		wg        sync.WaitGroup
+	)
+	// isDone returns true if generator is stopped,
+	// or a match was found at an index that is lower than index (for child key generator)
+	isDone := func(index uint64) bool {
+		select {
+		case <-stopCh:
+			return true
+		default:
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if found == nil && workerErr == nil {
+			return false
+		}
+		return g.masterKey == nil || workerErr != nil || uint64(found.Index) < index
+	}
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var account *GeneratedAccount
+				var err error
+				if g.masterKey != nil {
+					index := atomic.AddUint64(&nextIndex, 1) - 1
+					if index >= uint64(HardenedKeyStart) {
+						err = errors.New("all non-hardened child indices were tried")
+					} else if isDone(index) {
+						return
+					} else {
+						account, err = g.tryChildKey(uint32(index))
+					}
+				} else {
+					if isDone(0) {
+						return
+					}
+					account, err = g.tryRandomSeed()
+				}
+
+				if err != nil {
+					mux.Lock()
+					if workerErr == nil {
+						workerErr = err
+					}
+					mux.Unlock()
+					return
+				}
+				g.reportProgress(atomic.AddUint64(&attempts, 1))
+				if account == nil {
+					continue
+				}
+
+				mux.Lock()
+				if found == nil || account.Index < found.Index {
+					found = account
+				}
+				mux.Unlock()
+				if g.masterKey == nil {
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	mux.Lock()
+	defer mux.Unlock()
+	if found != nil {
+		found.Attempts = atomic.LoadUint64(&attempts)
+		return found, nil
+	}
+	if workerErr != nil {
+		return nil, NewWalletError(GenerateAccountErr, workerErr)
+	}
+	return nil, NewWalletError(GenerateAccountErr, errors.New("generation is cancelled"))
+}
+
+func (g *AccountGenerator) tryChildKey(index uint32) (*GeneratedAccount, error) {
+	childKey, err := g.masterKey.NewChildKey(index)
+	if err != nil {
+		return nil, err
+	}
+	if !g.isMatched(childKey) {
+		return nil, nil
+	}
+	return &GeneratedAccount{Key: childKey, Index: index}, nil
+}
+
+func (g *AccountGenerator) tryRandomSeed() (*GeneratedAccount, error) {
+	seed := make([]byte, seedKeyLen)
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	if !g.isMatched(key) {
+		return nil, nil
+	}
+	return &GeneratedAccount{Key: key, Seed: seed}, nil
+}
@@ -0,0 +1,92 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for AccountGenerator
+*/
+
+func getShardID(key *KeyWallet) int {
+	pk := key.KeySet.PaymentAddress.Pk
+	return int(common.GetShardIDFromLastByte(pk[len(pk)-1]))
+}
+
+func TestAccountGeneratorChildKey(t *testing.T) {
+	masterKey, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		// the expected result is the lowest matching index
+		expectedIndex := uint32(0)
+		for {
+			childKey, _ := masterKey.NewChildKey(expectedIndex)
+			if getShardID(childKey) == shardID {
+				break
+			}
+			expectedIndex++
+		}
+
+		g, err := NewChildAccountGenerator(masterKey, 0, shardID, "")
+		assert.Equal(t, nil, err)
+		g.NumWorkers = 4
+		account, err := g.Generate(nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expectedIndex, account.Index)
+		assert.Equal(t, shardID, getShardID(account.Key))
+		assert.True(t, account.Attempts >= uint64(expectedIndex)+1)
+	}
+}
+
+func TestAccountGeneratorRandomSeed(t *testing.T) {
+	var progress []uint64
+	g, err := NewAccountGenerator(3, "12S")
+	assert.Equal(t, nil, err)
+	g.NumWorkers = 2
+	g.ProgressInterval = 1
+	g.OnProgress = func(attempts uint64) {
+		progress = append(progress, attempts)
+	}
+
+	account, err := g.Generate(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, getShardID(account.Key))
+	assert.True(t, strings.HasPrefix(account.Key.Base58CheckSerialize(PaymentAddressType), "12S"))
+	assert.True(t, len(progress) > 0)
+
+	// the account is restored from seed
+	restored, _ := NewMasterKey(account.Seed)
+	assert.Equal(t, account.Key.KeySet, restored.KeySet)
+}
+
+func TestAccountGeneratorCancel(t *testing.T) {
+	// no payment address starts with 1z, so generator only stops when it is cancelled
+	g, err := NewAccountGenerator(-1, "1z")
+	assert.Equal(t, nil, err)
+
+	stopCh := make(chan struct{})
+	g.ProgressInterval = 10
+	g.OnProgress = func(attempts uint64) {
+		if attempts == 50 {
+			close(stopCh)
+		}
+	}
+	account, err := g.Generate(stopCh)
+	assert.Nil(t, account)
+	assert.Equal(t, ErrCodeMessage[GenerateAccountErr].code, err.(*WalletError).GetCode())
+}
+
+func TestAccountGeneratorInvalidParams(t *testing.T) {
+	_, err := NewAccountGenerator(common.MaxShardNumber, "")
+	assert.NotEqual(t, nil, err)
+	_, err = NewAccountGenerator(-2, "")
+	assert.NotEqual(t, nil, err)
+	_, err = NewAccountGenerator(0, "12l") // l is not base58 character
+	assert.NotEqual(t, nil, err)
+	_, err = NewChildAccountGenerator(nil, 0, 0, "")
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	pathMasterKey = "m"
+	pathSeparator = "/"
+)
+
+// ParseDerivationPath parses a derivation path (eg: m/44'/587'/0'/0/5) into child indices
+// hardened indices are marked by ' (or h, H) and are returned with HardenedKeyStart added
+func ParseDerivationPath(path string) ([]uint32, error) {
+	components := strings.Split(strings.TrimSpace(path), pathSeparator)
+	if components[0] != pathMasterKey {
+		return nil, NewWalletError(InvalidDerivationPathErr, errors.New("path must start with m"))
+	}
+
+	indices := make([]uint32, 0, len(components)-1)
+	for _, component := range components[1:] {
+		isHardened := false
+		if strings.HasSuffix(component, hardenedMarker) || strings.HasSuffix(component, "h") || strings.HasSuffix(component, "H") {
+			isHardened = true
+			component = component[:len(component)-1]
+		}
+
+		// only plain decimal digits are accepted, no sign or leading spaces
+		if component == "" || strings.TrimLeft(component, "0123456789") != "" {
+			return nil, NewWalletError(InvalidDerivationPathErr, fmt.Errorf("invalid path component %v", component))
+		}
+		index, err := strconv.ParseUint(component, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, NewWalletError(InvalidDerivationPathErr, fmt.Errorf("path component %v is out of range", component))
+		}
+
+		if isHardened {
+			index += uint64(HardenedKeyStart)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// FormatDerivationPath is the inverse of ParseDerivationPath, hardened indices are marked by '
+func FormatDerivationPath(indices []uint32) string {
+	path := pathMasterKey
+	for _, index := range indices {
+		if index >= HardenedKeyStart {
+			path += pathSeparator + strconv.FormatUint(uint64(index-HardenedKeyStart), 10) + hardenedMarker
+		} else {
+			path += pathSeparator + strconv.FormatUint(uint64(index), 10)
+		}
+	}
+	return path
+}
+
+// DeriveFromPath derives the child key at path (eg: m/44'/587'/0'/0/5) from master key
+// hardened indices are derived by NewHardenedChildKey, others by NewChildKey,
+// path "m/i" is the same as NewChildKey(i), which is used for account i
+func (key *KeyWallet) DeriveFromPath(path string) (*KeyWallet, error) {
+	if key.Depth != 0 {
+		return nil, NewWalletError(InvalidDerivationPathErr, errors.New("path must be derived from master key"))
+	}
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	childKey := key
+	for _, index := range indices {
+		if index >= HardenedKeyStart {
+			childKey, err = childKey.NewHardenedChildKey(index)
+		} else {
+			childKey, err = childKey.NewChildKey(index)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return childKey, nil
+}
@@ -0,0 +1,161 @@
+package wallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for derivation path functions
+*/
+
+func TestHDPathParseDerivationPath(t *testing.T) {
+	data := []struct {
+		path    string
+		indices []uint32
+		isValid bool
+	}{
+		{"m", []uint32{}, true},
+		{"m/0", []uint32{0}, true},
+		{"m/44'/587'/0'/0/5", []uint32{HardenedKeyStart + 44, HardenedKeyStart + 587, HardenedKeyStart, 0, 5}, true},
+		{"m/44h/587H/0'", []uint32{HardenedKeyStart + 44, HardenedKeyStart + 587, HardenedKeyStart}, true},
+		{"m/2147483647'", []uint32{HardenedKeyStart + 2147483647}, true},
+		{"m/2147483648", nil, false}, // out of range
+		{"44'/587'", nil, false},     // missing m
+		{"m/", nil, false},
+		{"m//0", nil, false},
+		{"m/-1", nil, false},
+		{"m/+1", nil, false},
+		{"m/0''", nil, false},
+		{"m/a", nil, false},
+		{"", nil, false},
+	}
+
+	for _, item := range data {
+		indices, err := ParseDerivationPath(item.path)
+		if !item.isValid {
+			assert.NotEqual(t, nil, err, item.path)
+			assert.Equal(t, ErrCodeMessage[InvalidDerivationPathErr].code, err.(*WalletError).GetCode())
+			continue
+		}
+		assert.Equal(t, nil, err, item.path)
+		assert.Equal(t, item.indices, indices)
+	}
+
+	assert.Equal(t, "m/44'/587'/0'/0/5", FormatDerivationPath([]uint32{HardenedKeyStart + 44, HardenedKeyStart + 587, HardenedKeyStart, 0, 5}))
+	assert.Equal(t, "m", FormatDerivationPath(nil))
+}
+
+func TestHDPathDeriveFromPathRegression(t *testing.T) {
+	// regression values with mnemonic "abandon ... about" and empty passphrase, they are outputs of this implementation:
+	// the master key and child keys of Incognito are not BIP32 or SLIP-10 keys, so they can not be reproduced by other tools
+	data := []struct {
+		path           string
+		privateKey     string
+		paymentAddress string
+	}{
+		{
+			"m",
+			"11111117HJEVjFHtDRSVvhgd116yXpnYWWZgrUNLzNBSviKg2iRebLGtFWfz961WDBJQcDnpwqEYxTHnuddF1iSEAZEsMzGLwG3NJZHt8KX",
+			"12RtrxJMvUsVNMNq2L2BaRQKzZkufMThUPxMeD69Yn826gUqqcf1T2JkK4FrrSRkfvooAQA12MfRYxBrXdGhxwReMAEwZMiqDyjLvDi",
+		},
+		{
+			"m/0",
+			"112t8rnXDnmpCa1b6QmwL9B4HgUNeMGYBpFYVAVXL5fqwnxCThzez5mmWGTXwGRufBCsSYFh1X4a1g4aEDeJPmZ9vsoWu3qXea4mce7Ayfzf",
+			"12S4P6ByB83cSPVzvEKsZwP7q5QYsgpzHpwCL82brR5UQPNpQ9LVtjUM8AhLjPkCzx8bDneS7NQX7kowWMgnmWwzVBxpAvYkDkJpDUt",
+		},
+		{
+			"m/1",
+			"112t8rnXLop54mQsJeSwFrGhbwKV56oGHTHWXHmTVaHzNxCNznmuUjg5qPct4BC8eMevrbWivZfyoWAxHYBV3rJ73NZ1P9s7yy7Jo9qckc2D",
+			"12S6Pc3VrXGBAw2SDAEkBmUijer9zimshJuayKiCZkcD1QeGwue4zS4kWniTeWA866fPpVLff6R44M62mzUbFLQtSU9FDVUeFabKFW1",
+		},
+		{
+			"m/44'/587'/0'",
+			"117ayWFKFAavU2bUvA9JFUMxpYb5JDVW25y66Y37kXFDNEoddVm2r4zX24ctxKm9whU7wQgQPbJoLiP7e5T6qBZYtzegR5YvvATNTqDiizCS",
+			"12RsJSb4atMDRsqzTVBpqPJ1hZSeAoDJFxttcCjm6bgcUJBoh5PgmeqgMiQ2Ay61W3UHBnW2Ny6ZZ4A62umwunG9vWxExHQb7byextj",
+		},
+		{
+			"m/44'/587'/0'/0/5",
+			"11AQgHvcfxgUvocpicz7UnhB8NUrbuScdosoZ8UGcv4aEfYqwKneoyBpSQLPMp6F5dUcW7azeHYokctEpwffg8h6SLyPtV3Th6GSwKWxDcAG",
+			"12S5EUALoqHnNDF5gGwszkHZcGTBWCpmnmQXdnf55wUrNotSCNXbFQnEipTEBg4H4LgYkp3Y6HczzDUhGPyZQrse8PKzPZLroq1sctk",
+		},
+		{
+			"m/44h/587h/1h/0/0",
+			"11AQgHvb8m4r6xpcTZ4bawe9cXWFPquFjaeBWhLcSaPP7M7EDdQVU9bAtEnrmUuXS1SSnTWRd1NMWoT9vcJPE3WkSZ41N9UkrnKaXtCDjSa6",
+			"12RwCZvnPo64LFyktxPPmGfBLKBA8i9DWFvK23TicA2v8h5UtrwdLpDdwhC2PemaYPFZk2tYZMzFGCDd7ivVHPwgXLG1D9xTEtz6zoX",
+		},
+	}
+
+	masterKey, err := NewMasterKeyFromMnemonic(testMnemonic, "")
+	assert.Equal(t, nil, err)
+	for _, item := range data {
+		childKey, err := masterKey.DeriveFromPath(item.path)
+		assert.Equal(t, nil, err, item.path)
+		assert.Equal(t, item.privateKey, childKey.Base58CheckSerialize(PriKeyType), item.path)
+		assert.Equal(t, item.paymentAddress, childKey.Base58CheckSerialize(PaymentAddressType), item.path)
+	}
+
+	// m/i is the same as NewChildKey(i)
+	childKey, _ := masterKey.NewChildKey(7)
+	pathKey, _ := masterKey.DeriveFromPath("m/7")
+	assert.Equal(t, childKey.KeySet, pathKey.KeySet)
+
+	// hardened and non-hardened children are different
+	hardenedKey, _ := masterKey.DeriveFromPath("m/7'")
+	assert.NotEqual(t, childKey.KeySet.PrivateKey, hardenedKey.KeySet.PrivateKey)
+
+	// m/i' is the same as NewHardenedChildKey(HardenedKeyStart + i)
+	hardenedChildKey, err := masterKey.NewHardenedChildKey(HardenedKeyStart + 7)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, hardenedKey.KeySet, hardenedChildKey.KeySet)
+	_, err = masterKey.NewHardenedChildKey(7)
+	assert.Equal(t, ErrCodeMessage[NewChildKeyError].code, err.(*WalletError).GetCode())
+
+	// NewChildKey derives indices from HardenedKeyStart from the index only, as older versions did
+	oldChildKey, err := masterKey.NewChildKey(HardenedKeyStart + 7)
+	assert.Equal(t, nil, err)
+	hmacObj := hmac.New(sha512.New, masterKey.ChainCode)
+	hmacObj.Write(common.Uint32ToBytes(HardenedKeyStart + 7))
+	expectedKeySet := (&incognitokey.KeySet{}).GenerateKey(hmacObj.Sum(nil)[:32])
+	assert.Equal(t, expectedKeySet.PrivateKey, oldChildKey.KeySet.PrivateKey)
+	assert.NotEqual(t, hardenedKey.KeySet.PrivateKey, oldChildKey.KeySet.PrivateKey)
+
+	// hardened child requires private key
+	paymentAddressKey, _ := Base58CheckDeserialize(masterKey.Base58CheckSerialize(PaymentAddressType))
+	paymentAddressKey.ChainCode = masterKey.ChainCode
+	_, err = paymentAddressKey.NewHardenedChildKey(HardenedKeyStart)
+	assert.Equal(t, ErrCodeMessage[NewChildKeyError].code, err.(*WalletError).GetCode())
+	_, err = paymentAddressKey.NewChildKey(HardenedKeyStart)
+	assert.Equal(t, nil, err)
+
+	// path is only derived from master key
+	_, err = childKey.DeriveFromPath("m/0")
+	assert.Equal(t, ErrCodeMessage[InvalidDerivationPathErr].code, err.(*WalletError).GetCode())
+}
+
+func TestHDPathWalletCreateAccountFromPath(t *testing.T) {
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+
+	account, err := w.CreateAccountFromPath("bip44", "m/44h/587h/0h/0/5")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "m/44'/587'/0'/0/5", account.Path)
+	assert.Equal(t, uint32(5), account.Index)
+	assert.Equal(t, "11AQgHvcfxgUvocpicz7UnhB8NUrbuScdosoZ8UGcv4aEfYqwKneoyBpSQLPMp6F5dUcW7azeHYokctEpwffg8h6SLyPtV3Th6GSwKWxDcAG", account.PrivateKeyStr())
+
+	_, err = w.CreateAccountFromPath("bip44 again", "m/44'/587'/0'/0/5")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountErr].code, err.(*WalletError).GetCode())
+	_, err = w.CreateAccountFromPath("master", "m")
+	assert.Equal(t, ErrCodeMessage[InvalidDerivationPathErr].code, err.(*WalletError).GetCode())
+
+	// CreateNewAccount skips index that is already added from path
+	_, err = w.CreateAccountFromPath("account 0", "m/0")
+	assert.Equal(t, nil, err)
+	account1, err := w.CreateNewAccount("account 1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "m/1", account1.Path)
+}
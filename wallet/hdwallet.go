@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/hmac"
 	"crypto/sha512"
+	"errors"
 	"fmt"
 	"github.com/0xkraken/incognito-sdk-golang/common"
 	"github.com/0xkraken/incognito-sdk-golang/common/base58"
@@ -54,31 +55,56 @@ func NewMasterKey(seed []byte) (*KeyWallet, error) {
 
 // NewChildKey derives a Child KeyWallet from a given parent as outlined by bip32
 // 2 child keys is derived from one key and a same child index are the same
+// all indices are derived from the index only, including childIdx >= HardenedKeyStart (see NewHardenedChildKey)
 func (key *KeyWallet) NewChildKey(childIdx uint32) (*KeyWallet, error) {
-	intermediary, err := key.getIntermediary(childIdx)
+	intermediary, err := key.getIntermediary(childIdx, false)
 	if err != nil {
 		return nil, NewWalletError(NewChildKeyError, err)
 	}
+	return key.newChildKeyFromIntermediary(childIdx, intermediary), nil
+}
 
+// NewHardenedChildKey derives a hardened Child KeyWallet from a given parent as outlined by bip32
+// childIdx must be at least HardenedKeyStart, the child is derived from the private key of parent and the index,
+// so it is different from NewChildKey(childIdx) and it requires private key
+func (key *KeyWallet) NewHardenedChildKey(childIdx uint32) (*KeyWallet, error) {
+	if childIdx < HardenedKeyStart {
+		return nil, NewWalletError(NewChildKeyError, errors.New("hardened child index must be at least HardenedKeyStart"))
+	}
+	intermediary, err := key.getIntermediary(childIdx, true)
+	if err != nil {
+		return nil, NewWalletError(NewChildKeyError, err)
+	}
+	return key.newChildKeyFromIntermediary(childIdx, intermediary), nil
+}
+
+func (key *KeyWallet) newChildKeyFromIntermediary(childIdx uint32, intermediary []byte) *KeyWallet {
 	newSeed := []byte{}
 	newSeed = append(newSeed[:], intermediary[:32]...)
 	newKeyset := (&incognitokey.KeySet{}).GenerateKey(newSeed)
 	// Create Child KeySet with data common to all both scenarios
-	childKey := &KeyWallet{
+	return &KeyWallet{
 		ChildNumber: common.Uint32ToBytes(childIdx),
 		ChainCode:   intermediary[32:],
 		Depth:       key.Depth + 1,
 		KeySet:      *newKeyset,
 	}
-
-	return childKey, nil
 }
 
-// getIntermediary
-func (key *KeyWallet) getIntermediary(childIdx uint32) ([]byte, error) {
+// getIntermediary computes HMAC-SHA512 of child index with chain code as key
+// for hardened child, data is 0x00 || private key || index, so it requires private key
+// otherwise, data is only index
+func (key *KeyWallet) getIntermediary(childIdx uint32, hardened bool) ([]byte, error) {
 	childIndexBytes := common.Uint32ToBytes(childIdx)
 
 	var data []byte
+	if hardened {
+		if len(key.KeySet.PrivateKey) != common.PrivateKeySize {
+			return nil, errors.New("hardened child key requires private key of parent key")
+		}
+		data = append(data, common.ZeroByte)
+		data = append(data, key.KeySet.PrivateKey[:]...)
+	}
 	data = append(data, childIndexBytes...)
 
 	hmacObj := hmac.New(sha512.New, key.ChainCode)
@@ -170,6 +196,9 @@ func deserialize(data []byte) (*KeyWallet, error) {
 		key.ChildNumber = data[2:6]
 		key.ChainCode = data[6:38]
 		keyLength := int(data[38])
+		if 39+keyLength != len(data)-4 {
+			return nil, NewWalletError(InvalidSeserializedKey, nil)
+		}
 		key.KeySet.PrivateKey = make([]byte, keyLength)
 		copy(key.KeySet.PrivateKey[:], data[39:39+keyLength])
 	} else if keyType == PaymentAddressType {
@@ -179,7 +208,13 @@ func deserialize(data []byte) (*KeyWallet, error) {
 			}
 		}
 		apkKeyLength := int(data[1])
+		if apkKeyLength+3 > len(data)-4 {
+			return nil, NewWalletError(InvalidSeserializedKey, nil)
+		}
 		pkencKeyLength := int(data[apkKeyLength+2])
+		if 3+apkKeyLength+pkencKeyLength != len(data)-4 {
+			return nil, NewWalletError(InvalidSeserializedKey, nil)
+		}
 		key.KeySet.PaymentAddress.Pk = make([]byte, apkKeyLength)
 		key.KeySet.PaymentAddress.Tk = make([]byte, pkencKeyLength)
 		copy(key.KeySet.PaymentAddress.Pk[:], data[2:2+apkKeyLength])
@@ -190,14 +225,19 @@ func deserialize(data []byte) (*KeyWallet, error) {
 		}
 
 		apkKeyLength := int(data[1])
-		if len(data) < apkKeyLength+3 {
-			return nil, NewWalletError(InvalidKeyTypeErr, nil)
+		if apkKeyLength+3 > len(data)-4 {
+			return nil, NewWalletError(InvalidSeserializedKey, nil)
 		}
 		skencKeyLength := int(data[apkKeyLength+2])
+		if 3+apkKeyLength+skencKeyLength != len(data)-4 {
+			return nil, NewWalletError(InvalidSeserializedKey, nil)
+		}
 		key.KeySet.ReadonlyKey.Pk = make([]byte, apkKeyLength)
 		key.KeySet.ReadonlyKey.Rk = make([]byte, skencKeyLength)
 		copy(key.KeySet.ReadonlyKey.Pk[:], data[2:2+apkKeyLength])
 		copy(key.KeySet.ReadonlyKey.Rk[:], data[3+apkKeyLength:3+apkKeyLength+skencKeyLength])
+	} else {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
 	}
 
 	// validate checksum
@@ -306,6 +306,41 @@ func TestHDWalletBase58CheckDeserializeWithInvalidData(t *testing.T) {
 	assert.NotEqual(t, nil, err)
 }
 
+func TestHDWalletDeserializeWithInvalidLength(t *testing.T) {
+	masterKey, _ := NewMasterKey([]byte{1, 2, 3})
+	paymentAddrBytes, _ := masterKey.Serialize(PaymentAddressType)
+
+	// length of public key points out of data
+	paymentAddrBytes[1] = 255
+	_, err := deserialize(paymentAddrBytes)
+	assert.Equal(t, NewWalletError(InvalidSeserializedKey, nil), err)
+
+	_, err = deserialize([]byte{3, 0, 0})
+	assert.Equal(t, NewWalletError(InvalidKeyTypeErr, nil), err)
+}
+
+// FuzzDeserialize checks that deserialize does not panic on any data,
+// and that a deserialized key is serialized to the same data
+func FuzzDeserialize(f *testing.F) {
+	masterKey, _ := NewMasterKey([]byte{1, 2, 3})
+	for _, keyType := range []byte{PriKeyType, PaymentAddressType, ReadonlyKeyType} {
+		data, _ := masterKey.Serialize(keyType)
+		f.Add(data)
+	}
+	f.Add(burnAddress1BytesDecode)
+	f.Add([]byte{3, 0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		key, err := deserialize(data)
+		if err != nil {
+			return
+		}
+		serialized, err := key.Serialize(data[0])
+		assert.Equal(t, nil, err)
+		assert.Equal(t, data, serialized)
+	})
+}
+
 func TestPrivateKeyToPaymentAddress(t *testing.T){
 	//Todo: need to fill private key
 	privateKeyStr := ""
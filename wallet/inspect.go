@@ -0,0 +1,116 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/incognitokey"
+)
+
+// KeyInfo is the result of inspecting a serialized key string
+type KeyInfo struct {
+	KeyType         byte   // PriKeyType, PaymentAddressType or ReadonlyKeyType
+	PublicKey       []byte // public key of account, nil if key is invalid
+	ShardID         byte   // shard of account, only valid if PublicKey is not nil
+	IsChecksumValid bool   // both base58check checksum and checksum of serialized key are valid
+	IsLengthValid   bool
+	IsBurnAddress   bool
+	IsValid         bool
+}
+
+// KeyTypeName returns readable name of key type
+func (info *KeyInfo) KeyTypeName() string {
+	switch info.KeyType {
+	case PriKeyType:
+		return "private key"
+	case PaymentAddressType:
+		return "payment address"
+	case ReadonlyKeyType:
+		return "readonly key"
+	}
+	return "unknown"
+}
+
+// expectedSerializedLen returns length in bytes of serialized key by key type
+func expectedSerializedLen(keyType byte) (int, bool) {
+	switch keyType {
+	case PriKeyType:
+		return privKeySerializedBytesLen, true
+	case PaymentAddressType:
+		return paymentAddrSerializedBytesLen, true
+	case ReadonlyKeyType:
+		return readOnlyKeySerializedBytesLen, true
+	}
+	return 0, false
+}
+
+// InspectKey decodes keyStr without panicking on malformed input and reports
+// key type, shard ID, validity of checksum and length, and whether it is a burn address
+// KeyInfo is returned as much as it can be decoded even if keyStr is invalid,
+// error is a WalletError with the code of the first problem found:
+// InvalidSeserializedKey (malformed base58 or length), InvalidChecksumErr or InvalidKeyTypeErr
+func InspectKey(keyStr string) (*KeyInfo, error) {
+	info := new(KeyInfo)
+
+	decoded, err := base58.Decode(keyStr)
+	if err != nil || len(decoded) < 1+common.CheckSumLen+1 {
+		return info, NewWalletError(InvalidSeserializedKey, errors.New("key is not a base58check string"))
+	}
+	outerChecksum := base58.ChecksumFirst4Bytes(decoded[:len(decoded)-common.CheckSumLen])
+	isOuterChecksumValid := bytes.Equal(outerChecksum, decoded[len(decoded)-common.CheckSumLen:])
+
+	data := decoded[1 : len(decoded)-common.CheckSumLen]
+	info.KeyType = data[0]
+	info.IsBurnAddress = keyStr == common.BurningAddress || bytes.Equal(data, burnAddress1BytesDecode)
+
+	expectedLen, ok := expectedSerializedLen(info.KeyType)
+	if !ok {
+		info.IsChecksumValid = isOuterChecksumValid
+		if !isOuterChecksumValid {
+			return info, NewWalletError(InvalidChecksumErr, nil)
+		}
+		return info, NewWalletError(InvalidKeyTypeErr, fmt.Errorf("unknown key type %v", info.KeyType))
+	}
+	info.IsLengthValid = len(data) == expectedLen || info.IsBurnAddress
+
+	isInnerChecksumValid := false
+	if len(data) > common.CheckSumLen {
+		innerChecksum := base58.ChecksumFirst4Bytes(data[:len(data)-common.CheckSumLen])
+		isInnerChecksumValid = bytes.Equal(innerChecksum, data[len(data)-common.CheckSumLen:])
+	}
+	info.IsChecksumValid = isOuterChecksumValid && isInnerChecksumValid
+
+	if !info.IsChecksumValid {
+		return info, NewWalletError(InvalidChecksumErr, nil)
+	}
+	if !info.IsLengthValid {
+		return info, NewWalletError(InvalidSeserializedKey, fmt.Errorf("invalid length %v of %v", len(data), info.KeyTypeName()))
+	}
+
+	// serialized keys are keyType || ... || len(key) || key || checksum
+	// public key is right after length byte of private key or at offset 2 of payment address and readonly key
+	switch info.KeyType {
+	case PriKeyType:
+		privateKeyOffset := 1 + 1 + childNumberLen + chainCodeLen + 1
+		if int(data[privateKeyOffset-1]) != common.PrivateKeySize {
+			return info, NewWalletError(InvalidSeserializedKey, errors.New("invalid length of private key"))
+		}
+		keySet := new(incognitokey.KeySet)
+		err := keySet.InitFromPrivateKeyByte(data[privateKeyOffset : privateKeyOffset+common.PrivateKeySize])
+		if err != nil {
+			return info, NewWalletError(InvalidSeserializedKey, err)
+		}
+		info.PublicKey = keySet.PaymentAddress.Pk
+	default:
+		if int(data[1]) != common.PublicKeySize {
+			return info, NewWalletError(InvalidSeserializedKey, errors.New("invalid length of public key"))
+		}
+		info.PublicKey = append([]byte{}, data[2:2+common.PublicKeySize]...)
+	}
+	info.ShardID = common.GetShardIDFromLastByte(info.PublicKey[len(info.PublicKey)-1])
+	info.IsValid = true
+	return info, nil
+}
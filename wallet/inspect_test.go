@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for InspectKey function
+*/
+
+func TestInspectKeyValidKeys(t *testing.T) {
+	masterKey, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	pk := masterKey.KeySet.PaymentAddress.Pk
+	shardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+
+	for _, keyType := range []byte{PriKeyType, PaymentAddressType, ReadonlyKeyType} {
+		info, err := InspectKey(masterKey.Base58CheckSerialize(keyType))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, keyType, info.KeyType)
+		assert.Equal(t, []byte(pk), info.PublicKey)
+		assert.Equal(t, shardID, info.ShardID)
+		assert.Equal(t, true, info.IsChecksumValid)
+		assert.Equal(t, true, info.IsLengthValid)
+		assert.Equal(t, false, info.IsBurnAddress)
+		assert.Equal(t, true, info.IsValid)
+	}
+}
+
+func TestInspectKeyBurnAddresses(t *testing.T) {
+	for _, burnAddress := range []string{common.BurningAddress, "15pABFiJVeh9D5uiQEhQX4SVibGGbdAVipQxBdxkmDqAJaoG1EdFKHBrNfs"} {
+		info, err := InspectKey(burnAddress)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, PaymentAddressType, info.KeyType)
+		assert.Equal(t, true, info.IsBurnAddress)
+		assert.Equal(t, true, info.IsValid)
+	}
+}
+
+func TestInspectKeyInvalidKeys(t *testing.T) {
+	masterKey, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	paymentAddress := masterKey.Base58CheckSerialize(PaymentAddressType)
+
+	// change a character in the middle to break checksum
+	badChar := "2"
+	if paymentAddress[50:51] == badChar {
+		badChar = "3"
+	}
+	wrongChecksum := paymentAddress[:50] + badChar + paymentAddress[51:]
+
+	// valid checksums but serialized key is too short
+	serialized, _ := masterKey.Serialize(PaymentAddressType)
+	shortData := serialized[:40]
+	shortData = append(shortData, base58.ChecksumFirst4Bytes(shortData)...)
+	wrongLength := base58.Base58Check{}.Encode(shortData, common.ZeroByte)
+
+	unknownType := []byte{0x9, 1, 2, 3}
+	unknownType = append(unknownType, base58.ChecksumFirst4Bytes(unknownType)...)
+	wrongType := base58.Base58Check{}.Encode(unknownType, common.ZeroByte)
+
+	data := []struct {
+		keyStr          string
+		errCode         int
+		isChecksumValid bool
+		isLengthValid   bool
+	}{
+		{"", ErrCodeMessage[InvalidSeserializedKey].code, false, false},
+		{"0OIl", ErrCodeMessage[InvalidSeserializedKey].code, false, false}, // not base58 characters
+		{wrongChecksum, ErrCodeMessage[InvalidChecksumErr].code, false, true},
+		{wrongLength, ErrCodeMessage[InvalidSeserializedKey].code, true, false},
+		{wrongType, ErrCodeMessage[InvalidKeyTypeErr].code, true, false},
+	}
+
+	for _, item := range data {
+		info, err := InspectKey(item.keyStr)
+		assert.NotEqual(t, nil, err, item.keyStr)
+		assert.Equal(t, item.errCode, err.(*WalletError).GetCode(), item.keyStr)
+		assert.Equal(t, item.isChecksumValid, info.IsChecksumValid, item.keyStr)
+		assert.Equal(t, item.isLengthValid, info.IsLengthValid, item.keyStr)
+		assert.Equal(t, false, info.IsValid, item.keyStr)
+	}
+}
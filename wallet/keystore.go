@@ -0,0 +1,344 @@
+package wallet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion = 1
+	keystoreKDF     = "scrypt"
+
+	// keystoreCipher authenticates ciphertext, data without cipher is encrypted by AES-CTR and checked by MAC
+	keystoreCipher = "aes-256-gcm"
+
+	scryptR       = 8
+	scryptDKLen   = 64 // first half is AES key, second half is MAC key of data without cipher
+	scryptSaltLen = 32
+)
+
+// StandardScryptN and StandardScryptP are scrypt params used to encrypt wallet files
+// they can be lowered (eg: LightScryptN) on devices with limited memory
+var (
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+)
+
+// LightScryptN uses 4MB memory and takes ~100ms on a modern CPU
+const LightScryptN = 1 << 12
+
+// scrypt params of wallet files are bounded, so that a crafted file can not exhaust memory or CPU
+// maxScryptN with scryptR uses 256MB memory, the same as StandardScryptN
+const (
+	maxScryptN = 1 << 18
+	maxScryptP = 4
+)
+
+// ScryptParams are params of scrypt to derive AES key and MAC key from passphrase
+type ScryptParams struct {
+	N     int
+	R     int
+	P     int
+	DKLen int
+	Salt  []byte
+}
+
+// EncryptedData is data encrypted with AES key derived from passphrase by scrypt
+type EncryptedData struct {
+	KDF        string
+	KDFParams  ScryptParams
+	Cipher     string `json:",omitempty"`
+	CipherText []byte
+	MAC        []byte `json:",omitempty"` // HashB(macKey || CipherText) if Cipher is empty, used to detect wrong passphrase
+}
+
+// keystoreFile is the content of wallet file on disk
+// only Name is plain, wallet data is encrypted
+type keystoreFile struct {
+	Version int
+	Name    string
+	EncryptedData
+}
+
+// walletData is the plaintext of keystoreFile
+type walletData struct {
+	Name             string
+	Mnemonic         string
+	MasterKey        string // serialized private key of master key
+	NextAccountIndex uint32
+	Accounts         []accountData
+}
+
+type accountData struct {
+	Name        string
+	PrivateKey  string
+	Path        string
+	Index       uint32
+	IsImported  bool
+	IsWatchOnly bool
+	// only for watch-only account
+	PaymentAddress string `json:",omitempty"`
+	ReadonlyKey    string `json:",omitempty"`
+}
+
+func (w *Wallet) toWalletData() *walletData {
+	data := &walletData{
+		Name:             w.Name,
+		Mnemonic:         w.Mnemonic,
+		MasterKey:        w.MasterKey.Base58CheckSerialize(PriKeyType),
+		NextAccountIndex: w.nextAccountIndex,
+		Accounts:         make([]accountData, len(w.accounts)),
+	}
+	for i, account := range w.accounts {
+		data.Accounts[i] = accountData{
+			Name:        account.Name,
+			PrivateKey:  account.PrivateKeyStr(),
+			Path:        account.Path,
+			Index:       account.Index,
+			IsImported:  account.IsImported,
+			IsWatchOnly: account.IsWatchOnly,
+		}
+		if account.IsWatchOnly {
+			data.Accounts[i].PaymentAddress = account.PaymentAddressStr()
+			data.Accounts[i].ReadonlyKey = account.ReadonlyKeyStr()
+		}
+	}
+	return data
+}
+
+func newWalletFromWalletData(data *walletData) (*Wallet, error) {
+	masterKey, err := newKeyWalletFromPrivateKeyStr(data.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+	w := &Wallet{
+		Name:             data.Name,
+		Mnemonic:         data.Mnemonic,
+		MasterKey:        masterKey,
+		nextAccountIndex: data.NextAccountIndex,
+	}
+	for _, accData := range data.Accounts {
+		var key *KeyWallet
+		if accData.IsWatchOnly {
+			key, err = NewWatchOnlyKeyWallet(accData.PaymentAddress, accData.ReadonlyKey)
+		} else {
+			key, err = newKeyWalletFromPrivateKeyStr(accData.PrivateKey)
+		}
+		if err != nil {
+			return nil, err
+		}
+		w.accounts = append(w.accounts, &AccountWallet{
+			Name:        accData.Name,
+			Key:         key,
+			Path:        accData.Path,
+			Index:       accData.Index,
+			IsImported:  accData.IsImported,
+			IsWatchOnly: accData.IsWatchOnly,
+		})
+	}
+	return w, nil
+}
+
+// validateScryptParams checks scrypt params before deriving keys, they are read from wallet files
+func validateScryptParams(params ScryptParams) error {
+	if params.N <= 1 || params.N > maxScryptN || params.N&(params.N-1) != 0 {
+		return fmt.Errorf("scrypt N must be a power of 2 not greater than %v, got %v", maxScryptN, params.N)
+	}
+	if params.R != scryptR {
+		return fmt.Errorf("scrypt R must be %v, got %v", scryptR, params.R)
+	}
+	if params.P < 1 || params.P > maxScryptP {
+		return fmt.Errorf("scrypt P must be in range [1, %v], got %v", maxScryptP, params.P)
+	}
+	if params.DKLen != scryptDKLen {
+		return fmt.Errorf("scrypt DKLen must be %v, got %v", scryptDKLen, params.DKLen)
+	}
+	return nil
+}
+
+func deriveKeystoreKeys(passphrase string, params ScryptParams) ([]byte, []byte, error) {
+	err := validateScryptParams(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), params.Salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(derivedKey) != scryptDKLen {
+		return nil, nil, errors.New("invalid length of derived key")
+	}
+	return derivedKey[:common.AESKeySize], derivedKey[common.AESKeySize:], nil
+}
+
+func keystoreMAC(macKey []byte, cipherText []byte) []byte {
+	return common.HashB(append(append([]byte{}, macKey...), cipherText...))
+}
+
+// encryptWithPassphrase encrypts plaintext with AES key derived from passphrase
+func encryptWithPassphrase(plaintext []byte, passphrase string) (*EncryptedData, error) {
+	salt := make([]byte, scryptSaltLen)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, NewWalletError(UnexpectedErr, err)
+	}
+	params := ScryptParams{
+		N:     StandardScryptN,
+		R:     scryptR,
+		P:     StandardScryptP,
+		DKLen: scryptDKLen,
+		Salt:  salt,
+	}
+	aesKey, _, err := deriveKeystoreKeys(passphrase, params)
+	if err != nil {
+		return nil, NewWalletError(AESEncryptErr, err)
+	}
+
+	aesObj := common.AESGCM{Key: aesKey}
+	cipherText, err := aesObj.Encrypt(plaintext, nil)
+	if err != nil {
+		return nil, NewWalletError(AESEncryptErr, err)
+	}
+
+	return &EncryptedData{
+		KDF:        keystoreKDF,
+		KDFParams:  params,
+		Cipher:     keystoreCipher,
+		CipherText: cipherText,
+	}, nil
+}
+
+// decryptWithPassphrase decrypts data with passphrase
+// it returns WrongPassphraseErr if ciphertext can not be authenticated,
+// a wrong passphrase can not be told from a changed ciphertext
+func decryptWithPassphrase(data *EncryptedData, passphrase string) ([]byte, error) {
+	if data.KDF != keystoreKDF {
+		return nil, NewWalletError(UnexpectedErr, errors.New("unsupported KDF"))
+	}
+	aesKey, macKey, err := deriveKeystoreKeys(passphrase, data.KDFParams)
+	if err != nil {
+		return nil, NewWalletError(AESDecryptErr, err)
+	}
+
+	switch data.Cipher {
+	case keystoreCipher:
+		aesObj := common.AESGCM{Key: aesKey}
+		plaintext, err := aesObj.Decrypt(data.CipherText, nil)
+		if err == common.AuthenticationFailedErr {
+			return nil, NewWalletError(WrongPassphraseErr, nil)
+		}
+		if err != nil {
+			return nil, NewWalletError(AESDecryptErr, err)
+		}
+		return plaintext, nil
+	case "":
+		// data encrypted before ciphers were added
+	default:
+		return nil, NewWalletError(UnexpectedErr, errors.New("unsupported cipher"))
+	}
+
+	if !hmac.Equal(keystoreMAC(macKey, data.CipherText), data.MAC) {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
+	}
+
+	aesObj := common.AES{Key: aesKey}
+	plaintext, err := aesObj.Decrypt(data.CipherText)
+	if err != nil {
+		return nil, NewWalletError(AESDecryptErr, err)
+	}
+	return plaintext, nil
+}
+
+// Encrypt encrypts wallet with passphrase and returns the content of wallet file
+func (w *Wallet) Encrypt(passphrase string) ([]byte, error) {
+	plaintext, err := json.Marshal(w.toWalletData())
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	encryptedData, err := encryptWithPassphrase(plaintext, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := keystoreFile{
+		Version:       keystoreVersion,
+		Name:          w.Name,
+		EncryptedData: *encryptedData,
+	}
+	result, err := json.Marshal(ks)
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	return result, nil
+}
+
+// DecryptWallet decrypts the content of wallet file with passphrase
+func DecryptWallet(data []byte, passphrase string) (*Wallet, error) {
+	var ks keystoreFile
+	err := json.Unmarshal(data, &ks)
+	if err != nil {
+		return nil, NewWalletError(JsonUnmarshalErr, err)
+	}
+	if ks.Version != keystoreVersion {
+		return nil, NewWalletError(UnexpectedErr, errors.New("unsupported wallet file version"))
+	}
+
+	plaintext, err := decryptWithPassphrase(&ks.EncryptedData, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var wData walletData
+	err = json.Unmarshal(plaintext, &wData)
+	if err != nil {
+		return nil, NewWalletError(JsonUnmarshalErr, err)
+	}
+	return newWalletFromWalletData(&wData)
+}
+
+// Save encrypts wallet with passphrase and writes it to filePath
+// the file is replaced atomically, so an existing wallet file is not corrupted if writing fails
+func (w *Wallet) Save(filePath string, passphrase string) error {
+	data, err := w.Encrypt(passphrase)
+	if err != nil {
+		return err
+	}
+
+	tmpFilePath := filePath + ".tmp"
+	err = ioutil.WriteFile(tmpFilePath, data, 0600)
+	if err != nil {
+		return NewWalletError(WriteFileErr, err)
+	}
+	err = os.Rename(tmpFilePath, filePath)
+	if err != nil {
+		os.Remove(tmpFilePath)
+		return NewWalletError(WriteFileErr, err)
+	}
+	return nil
+}
+
+// LoadWallet reads wallet file at filePath and decrypts it with passphrase
+func LoadWallet(filePath string, passphrase string) (*Wallet, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, NewWalletError(ReadFileErr, err)
+	}
+	return DecryptWallet(data, passphrase)
+}
+
+// ChangePassphrase re-encrypts wallet file at filePath with newPassphrase
+func ChangePassphrase(filePath string, oldPassphrase string, newPassphrase string) error {
+	w, err := LoadWallet(filePath, oldPassphrase)
+	if err != nil {
+		return err
+	}
+	return w.Save(filePath, newPassphrase)
+}
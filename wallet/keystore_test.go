@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for saving and loading encrypted wallet file
+*/
+
+func TestKeystoreSaveAndLoad(t *testing.T) {
+	StandardScryptN = LightScryptN
+	dir, err := ioutil.TempDir("", "wallet")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "wallet.json")
+
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	w.CreateNewAccount("account 0")
+	w.CreateNewAccount("account 1")
+	other, _ := NewMasterKeyFromMnemonic(testMnemonic, "other")
+	w.ImportAccount(other.Base58CheckSerialize(PriKeyType), "imported")
+	w.RemoveAccount("account 1")
+	watched, _ := NewMasterKeyFromMnemonic(testMnemonic, "watched")
+	w.ImportWatchOnlyAccount(watched.Base58CheckSerialize(PaymentAddressType), watched.Base58CheckSerialize(ReadonlyKeyType), "watch")
+
+	err = w.Save(filePath, "passphrase")
+	assert.Equal(t, nil, err)
+
+	loaded, err := LoadWallet(filePath, "passphrase")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, w.Name, loaded.Name)
+	assert.Equal(t, w.Mnemonic, loaded.Mnemonic)
+	assert.Equal(t, w.MasterKey.KeySet, loaded.MasterKey.KeySet)
+	assert.Equal(t, w.MasterKey.ChainCode, loaded.MasterKey.ChainCode)
+
+	accounts := w.ListAccounts()
+	loadedAccounts := loaded.ListAccounts()
+	assert.Equal(t, len(accounts), len(loadedAccounts))
+	for i := range accounts {
+		assert.Equal(t, accounts[i].Name, loadedAccounts[i].Name)
+		assert.Equal(t, accounts[i].Path, loadedAccounts[i].Path)
+		assert.Equal(t, accounts[i].Index, loadedAccounts[i].Index)
+		assert.Equal(t, accounts[i].IsImported, loadedAccounts[i].IsImported)
+		assert.Equal(t, accounts[i].IsWatchOnly, loadedAccounts[i].IsWatchOnly)
+		assert.Equal(t, accounts[i].Key.KeySet, loadedAccounts[i].Key.KeySet)
+	}
+
+	// next account index is persisted
+	account, err := loaded.CreateNewAccount("account 2")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(2), account.Index)
+
+	_, err = LoadWallet(filePath, "wrong passphrase")
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+	_, err = LoadWallet(filepath.Join(dir, "not-found.json"), "passphrase")
+	assert.Equal(t, ErrCodeMessage[ReadFileErr].code, err.(*WalletError).GetCode())
+}
+
+func TestKeystoreChangePassphrase(t *testing.T) {
+	StandardScryptN = LightScryptN
+	dir, err := ioutil.TempDir("", "wallet")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "wallet.json")
+
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	w.CreateNewAccount("account 0")
+	assert.Equal(t, nil, w.Save(filePath, "old"))
+
+	err = ChangePassphrase(filePath, "wrong", "new")
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+
+	assert.Equal(t, nil, ChangePassphrase(filePath, "old", "new"))
+	_, err = LoadWallet(filePath, "old")
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+	loaded, err := LoadWallet(filePath, "new")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(loaded.ListAccounts()))
+}
+
+func TestKeystoreDecryptWithoutCipher(t *testing.T) {
+	StandardScryptN = LightScryptN
+	plaintext := []byte("wallet data")
+
+	// data encrypted by AES-CTR and checked by MAC, like wallet files created before ciphers were added
+	data, _ := encryptWithPassphrase(plaintext, "passphrase")
+	aesKey, macKey, _ := deriveKeystoreKeys("passphrase", data.KDFParams)
+	aesObj := common.AES{Key: aesKey}
+	data.Cipher = ""
+	data.CipherText, _ = aesObj.Encrypt(plaintext)
+	data.MAC = keystoreMAC(macKey, data.CipherText)
+
+	decrypted, err := decryptWithPassphrase(data, "passphrase")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, plaintext, decrypted)
+	_, err = decryptWithPassphrase(data, "wrong passphrase")
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+}
+
+func TestKeystoreTampered(t *testing.T) {
+	StandardScryptN = LightScryptN
+	data, err := encryptWithPassphrase([]byte("wallet data"), "passphrase")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, keystoreCipher, data.Cipher)
+	assert.Equal(t, 0, len(data.MAC))
+
+	data.CipherText[len(data.CipherText)-1] ^= 1
+	_, err = decryptWithPassphrase(data, "passphrase")
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+
+	data.Cipher = "unknown"
+	_, err = decryptWithPassphrase(data, "passphrase")
+	assert.Equal(t, ErrCodeMessage[UnexpectedErr].code, err.(*WalletError).GetCode())
+}
+
+func TestKeystoreInvalidScryptParams(t *testing.T) {
+	StandardScryptN = LightScryptN
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	data, err := w.Encrypt("passphrase")
+	assert.Equal(t, nil, err)
+
+	// params of a crafted wallet file are rejected before scrypt allocates memory
+	for _, change := range []func(params *ScryptParams){
+		func(params *ScryptParams) { params.N = 1 << 30 },
+		func(params *ScryptParams) { params.N = LightScryptN + 1 },
+		func(params *ScryptParams) { params.N = 0 },
+		func(params *ScryptParams) { params.R = 1 << 20 },
+		func(params *ScryptParams) { params.P = 1 << 20 },
+		func(params *ScryptParams) { params.P = 0 },
+		func(params *ScryptParams) { params.DKLen = 1 << 30 },
+	} {
+		var ks keystoreFile
+		assert.Equal(t, nil, json.Unmarshal(data, &ks))
+		change(&ks.KDFParams)
+		changed, _ := json.Marshal(ks)
+		_, err = DecryptWallet(changed, "passphrase")
+		assert.Equal(t, ErrCodeMessage[AESDecryptErr].code, err.(*WalletError).GetCode())
+	}
+
+	// wallet files can not be created with params that can not be loaded
+	StandardScryptN = maxScryptN * 2
+	defer func() { StandardScryptN = LightScryptN }()
+	_, err = w.Encrypt("passphrase")
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,158 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	minEntropyBits = 128
+	maxEntropyBits = 256
+
+	bitsPerWord         = 11
+	seedIterations      = 2048
+	seedSaltPrefix      = "mnemonic"
+	mnemonicSeparator   = " "
+	entropyBitsMultiple = 32
+)
+
+// validateEntropyBits checks entropy size in bits is in [128, 256] and a multiple of 32 as BIP39
+func validateEntropyBits(bitSize int) error {
+	if bitSize < minEntropyBits || bitSize > maxEntropyBits || bitSize%entropyBitsMultiple != 0 {
+		return fmt.Errorf("entropy size must be a multiple of %v in range [%v, %v], got %v",
+			entropyBitsMultiple, minEntropyBits, maxEntropyBits, bitSize)
+	}
+	return nil
+}
+
+// NewEntropy generates a random entropy with bitSize bits
+// bitSize must be a multiple of 32 in range [128, 256]
+func NewEntropy(bitSize int) ([]byte, error) {
+	err := validateEntropyBits(bitSize)
+	if err != nil {
+		return nil, NewWalletError(NewEntropyError, err)
+	}
+
+	entropy := make([]byte, bitSize/8)
+	_, err = rand.Read(entropy)
+	if err != nil {
+		return nil, NewWalletError(NewEntropyError, err)
+	}
+	return entropy, nil
+}
+
+// NewMnemonic generates a new random BIP39 mnemonic from an entropy with entropyBits bits
+// 128 bits entropy gives 12 words, 256 bits entropy gives 24 words
+func NewMnemonic(entropyBits int) (string, error) {
+	entropy, err := NewEntropy(entropyBits)
+	if err != nil {
+		return "", err
+	}
+	return NewMnemonicFromEntropy(entropy)
+}
+
+// NewMnemonicFromEntropy encodes entropy into a BIP39 mnemonic:
+// checksum (first len(entropy)*8/32 bits of sha256(entropy)) is appended to entropy,
+// then each 11 bits is mapped to a word in the English word list
+func NewMnemonicFromEntropy(entropy []byte) (string, error) {
+	entropyBits := len(entropy) * 8
+	err := validateEntropyBits(entropyBits)
+	if err != nil {
+		return "", NewWalletError(NewMnemonicError, err)
+	}
+
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	wordCount := (entropyBits + entropyBits/32) / bitsPerWord
+	words := make([]string, wordCount)
+	for i := 0; i < wordCount; i++ {
+		idx := 0
+		for j := 0; j < bitsPerWord; j++ {
+			bitPos := i*bitsPerWord + j
+			bit := (data[bitPos/8] >> uint(7-bitPos%8)) & 1
+			idx = idx<<1 | int(bit)
+		}
+		words[i] = englishWordList[idx]
+	}
+
+	return strings.Join(words, mnemonicSeparator), nil
+}
+
+// EntropyFromMnemonic decodes a BIP39 mnemonic into its entropy
+// it returns error if any word is not in the word list or the checksum does not match
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	wordCount := len(words)
+	if wordCount%3 != 0 || wordCount < 12 || wordCount > 24 {
+		return nil, NewWalletError(MnemonicInvalidError, fmt.Errorf("invalid number of words %v", wordCount))
+	}
+
+	totalBits := wordCount * bitsPerWord
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+
+	data := make([]byte, (totalBits+7)/8)
+	for i, word := range words {
+		idx, ok := englishWordIndex[word]
+		if !ok {
+			return nil, NewWalletError(MnemonicInvalidError, fmt.Errorf("word %v is not in the word list", word))
+		}
+		for j := 0; j < bitsPerWord; j++ {
+			if (idx>>uint(bitsPerWord-1-j))&1 == 1 {
+				bitPos := i*bitsPerWord + j
+				data[bitPos/8] |= 1 << uint(7-bitPos%8)
+			}
+		}
+	}
+
+	entropy := data[:entropyBits/8]
+	checksum := sha256.Sum256(entropy)
+	mask := byte(0xFF << uint(8-checksumBits))
+	if checksum[0]&mask != data[entropyBits/8] {
+		return nil, NewWalletError(MnemonicInvalidError, errors.New("checksum does not match"))
+	}
+
+	return entropy, nil
+}
+
+// IsMnemonicValid returns true if all words of mnemonic are in the word list and its checksum is valid
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// NewSeed derives a 64-byte seed from mnemonic and passphrase as BIP39:
+// PBKDF2-HMAC-SHA512 with 2048 iterations and salt "mnemonic" + passphrase, both in UTF-8 NFKD
+// the mnemonic is not validated, use NewSeedWithErrorChecking to check it first
+func NewSeed(mnemonic string, passphrase string) []byte {
+	mnemonic = strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), mnemonicSeparator)
+	passphrase = norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(mnemonic), []byte(seedSaltPrefix+passphrase), seedIterations, seedKeyLen, sha512.New)
+}
+
+// NewSeedWithErrorChecking validates mnemonic before deriving seed from it
+func NewSeedWithErrorChecking(mnemonic string, passphrase string) ([]byte, error) {
+	_, err := EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return NewSeed(mnemonic, passphrase), nil
+}
+
+// NewMasterKeyFromMnemonic restores the master key from a BIP39 mnemonic and passphrase,
+// it is NewMasterKey of the BIP39 seed of mnemonic, accounts are derived by NewChildKey with account index
+func NewMasterKeyFromMnemonic(mnemonic string, passphrase string) (*KeyWallet, error) {
+	seed, err := NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewMasterKey(seed)
+}
@@ -0,0 +1,162 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for mnemonic functions with BIP39 test vectors (passphrase "TREZOR")
+*/
+
+var mnemonicTestVectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"80808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+}
+
+func TestMnemonicWordList(t *testing.T) {
+	assert.Equal(t, 2048, len(englishWordList))
+	assert.Equal(t, 2048, len(englishWordIndex))
+}
+
+func TestMnemonicTestVectors(t *testing.T) {
+	for _, item := range mnemonicTestVectors {
+		entropy, _ := hex.DecodeString(item.entropy)
+
+		mnemonic, err := NewMnemonicFromEntropy(entropy)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, item.mnemonic, mnemonic)
+
+		decodedEntropy, err := EntropyFromMnemonic(item.mnemonic)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, entropy, decodedEntropy)
+
+		seed, err := NewSeedWithErrorChecking(item.mnemonic, "TREZOR")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, item.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestMnemonicNewMnemonic(t *testing.T) {
+	data := []struct {
+		entropyBits int
+		wordCount   int
+		isValid     bool
+	}{
+		{128, 12, true},
+		{160, 15, true},
+		{192, 18, true},
+		{224, 21, true},
+		{256, 24, true},
+		{96, 0, false},
+		{136, 0, false},
+		{288, 0, false},
+	}
+
+	for _, item := range data {
+		mnemonic, err := NewMnemonic(item.entropyBits)
+		if !item.isValid {
+			assert.NotEqual(t, nil, err)
+			assert.Equal(t, ErrCodeMessage[NewEntropyError].code, err.(*WalletError).GetCode())
+			continue
+		}
+		assert.Equal(t, nil, err)
+		assert.Equal(t, item.wordCount, len(strings.Fields(mnemonic)))
+		assert.Equal(t, true, IsMnemonicValid(mnemonic))
+	}
+}
+
+func TestMnemonicIsMnemonicValid(t *testing.T) {
+	data := []struct {
+		mnemonic string
+		isValid  bool
+	}{
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", true},
+		{"  abandon abandon abandon abandon abandon abandon\tabandon abandon abandon abandon abandon about ", true},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", false}, // wrong checksum
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", false},         // 11 words
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon incognito", false},
+		{"", false},
+	}
+
+	for _, item := range data {
+		assert.Equal(t, item.isValid, IsMnemonicValid(item.mnemonic))
+	}
+}
+
+func TestMnemonicNewMasterKeyFromMnemonic(t *testing.T) {
+	mnemonic := mnemonicTestVectors[0].mnemonic
+
+	masterKey, err := NewMasterKeyFromMnemonic(mnemonic, "")
+	assert.Equal(t, nil, err)
+	expectedMasterKey, _ := NewMasterKey(NewSeed(mnemonic, ""))
+	assert.Equal(t, expectedMasterKey.KeySet.PrivateKey, masterKey.KeySet.PrivateKey)
+	assert.Equal(t, expectedMasterKey.ChainCode, masterKey.ChainCode)
+
+	// passphrase changes the master key
+	otherMasterKey, err := NewMasterKeyFromMnemonic(mnemonic, "TREZOR")
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, masterKey.KeySet.PrivateKey, otherMasterKey.KeySet.PrivateKey)
+
+	_, err = NewMasterKeyFromMnemonic("abandon abandon abandon", "")
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, ErrCodeMessage[MnemonicInvalidError].code, err.(*WalletError).GetCode())
+}
+
+// mnemonic and passphrase are normalized to NFKD as BIP39, so composed and decomposed characters give the same seed
+func TestMnemonicSeedNFKD(t *testing.T) {
+	mnemonic := mnemonicTestVectors[0].mnemonic
+	composed := "p\u00e2ssphr\u00e1se"
+	decomposed := "pa\u0302ssphra\u0301se"
+	assert.NotEqual(t, composed, decomposed)
+	assert.Equal(t, NewSeed(mnemonic, composed), NewSeed(mnemonic, decomposed))
+	assert.NotEqual(t, NewSeed(mnemonic, composed), NewSeed(mnemonic, "passphrase"))
+
+	// compatibility characters, eg: ideographic space, are decomposed too
+	assert.Equal(t, NewSeed(mnemonic, ""), NewSeed(strings.Replace(mnemonic, " ", "\u3000", -1), ""))
+	assert.Equal(t, NewSeed(mnemonic, "1"), NewSeed(mnemonic, "\uff11"))
+}
+
+// regression values of the first account restored from the mnemonic of the first BIP39 test vector,
+// they are outputs of this implementation, not of another wallet
+func TestMnemonicFirstAccountRegression(t *testing.T) {
+	masterKey, err := NewMasterKeyFromMnemonic(mnemonicTestVectors[0].mnemonic, "")
+	assert.Equal(t, nil, err)
+	account, err := masterKey.NewChildKey(0)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "112t8rnXDnmpCa1b6QmwL9B4HgUNeMGYBpFYVAVXL5fqwnxCThzez5mmWGTXwGRufBCsSYFh1X4a1g4aEDeJPmZ9vsoWu3qXea4mce7Ayfzf",
+		account.Base58CheckSerialize(PriKeyType))
+	assert.Equal(t, "12S4P6ByB83cSPVzvEKsZwP7q5QYsgpzHpwCL82brR5UQPNpQ9LVtjUM8AhLjPkCzx8bDneS7NQX7kowWMgnmWwzVBxpAvYkDkJpDUt",
+		account.Base58CheckSerialize(PaymentAddressType))
+}
@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+)
+
+const (
+	// PaymentURIScheme is the scheme of payment request URIs: incognito:<payment address>?amount=&token=&memo=
+	PaymentURIScheme = "incognito"
+
+	paymentURIMaxMemoLen = 512 // the same as max size of tx info
+)
+
+// PaymentRequest is a request to pay Amount of token TokenID to PaymentAddress
+// TokenID is empty for PRV, Amount is 0 if the payer chooses the amount
+type PaymentRequest struct {
+	PaymentAddress string
+	Amount         uint64
+	TokenID        string
+	Memo           string
+}
+
+func (req *PaymentRequest) validate() error {
+	key, err := Base58CheckDeserialize(req.PaymentAddress)
+	if err != nil || len(key.KeySet.PaymentAddress.Pk) == 0 {
+		return NewWalletError(InvalidPaymentURIErr, errors.New("invalid payment address"))
+	}
+	if req.TokenID != "" {
+		_, err := common.Hash{}.NewHashFromStr(req.TokenID)
+		if err != nil || len(req.TokenID) != common.MaxHashStringSize {
+			return NewWalletError(InvalidPaymentURIErr, fmt.Errorf("invalid token ID %v", req.TokenID))
+		}
+	}
+	if len(req.Memo) > paymentURIMaxMemoLen {
+		return NewWalletError(InvalidPaymentURIErr, fmt.Errorf("memo is longer than %v bytes", paymentURIMaxMemoLen))
+	}
+	return nil
+}
+
+// URI returns payment request as URI, query params with default values are omitted
+// to keep the URI short for QR codes
+func (req *PaymentRequest) URI() (string, error) {
+	err := req.validate()
+	if err != nil {
+		return "", err
+	}
+
+	query := make([]string, 0)
+	if req.Amount > 0 {
+		query = append(query, "amount="+strconv.FormatUint(req.Amount, 10))
+	}
+	if req.TokenID != "" && req.TokenID != common.PRVIDStr {
+		query = append(query, "token="+req.TokenID)
+	}
+	if req.Memo != "" {
+		query = append(query, "memo="+url.QueryEscape(req.Memo))
+	}
+
+	uri := PaymentURIScheme + ":" + req.PaymentAddress
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+	return uri, nil
+}
+
+// ParsePaymentURI parses a payment request URI created by PaymentRequest.URI
+// TokenID of the result is empty for PRV
+func ParsePaymentURI(uri string) (*PaymentRequest, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil {
+		return nil, NewWalletError(InvalidPaymentURIErr, err)
+	}
+	if !strings.EqualFold(u.Scheme, PaymentURIScheme) || u.Opaque == "" {
+		return nil, NewWalletError(InvalidPaymentURIErr, errors.New("URI must be in format incognito:<payment address>"))
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, NewWalletError(InvalidPaymentURIErr, err)
+	}
+
+	req := &PaymentRequest{PaymentAddress: u.Opaque}
+	for name, values := range query {
+		if len(values) != 1 {
+			return nil, NewWalletError(InvalidPaymentURIErr, fmt.Errorf("param %v must be set once", name))
+		}
+		switch name {
+		case "amount":
+			req.Amount, err = strconv.ParseUint(values[0], 10, 64)
+			if err != nil {
+				return nil, NewWalletError(InvalidPaymentURIErr, fmt.Errorf("invalid amount %v", values[0]))
+			}
+		case "token":
+			req.TokenID = values[0]
+			if req.TokenID == common.PRVIDStr {
+				req.TokenID = ""
+			}
+		case "memo":
+			req.Memo = values[0]
+		}
+		// unknown params are ignored for compatibility with newer versions
+	}
+
+	err = req.validate()
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
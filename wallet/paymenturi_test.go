@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for payment request URIs
+*/
+
+func TestPaymentURI(t *testing.T) {
+	key, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	paymentAddress := key.Base58CheckSerialize(PaymentAddressType)
+	tokenID := "ffd8d42dc40a8d166ea4848baf8b5f6e9fe0e9c30d60062eb7d44a8df9e00854"
+
+	testcases := []struct {
+		req *PaymentRequest
+		uri string
+	}{
+		{&PaymentRequest{PaymentAddress: paymentAddress}, "incognito:" + paymentAddress},
+		{&PaymentRequest{PaymentAddress: paymentAddress, Amount: 1000000000}, "incognito:" + paymentAddress + "?amount=1000000000"},
+		{
+			&PaymentRequest{PaymentAddress: paymentAddress, Amount: 5, TokenID: tokenID, Memo: "invoice #1 & more"},
+			"incognito:" + paymentAddress + "?amount=5&token=" + tokenID + "&memo=invoice+%231+%26+more",
+		},
+	}
+	for _, tc := range testcases {
+		uri, err := tc.req.URI()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tc.uri, uri)
+
+		req, err := ParsePaymentURI(uri)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tc.req, req)
+	}
+
+	// PRV token ID is omitted
+	uri, _ := (&PaymentRequest{PaymentAddress: paymentAddress, TokenID: common.PRVIDStr}).URI()
+	assert.Equal(t, "incognito:"+paymentAddress, uri)
+	req, err := ParsePaymentURI(uri + "?token=" + common.PRVIDStr + "&label=shop")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", req.TokenID)
+}
+
+func TestPaymentURIInvalid(t *testing.T) {
+	key, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	paymentAddress := key.Base58CheckSerialize(PaymentAddressType)
+
+	invalidURIs := []string{
+		"",
+		"bitcoin:" + paymentAddress,
+		"incognito:",
+		"incognito://" + paymentAddress,
+		"incognito:" + key.Base58CheckSerialize(ReadonlyKeyType),
+		"incognito:" + paymentAddress + "?amount=-1",
+		"incognito:" + paymentAddress + "?amount=1&amount=2",
+		"incognito:" + paymentAddress + "?token=abc",
+	}
+	for _, uri := range invalidURIs {
+		_, err := ParsePaymentURI(uri)
+		assert.NotEqual(t, nil, err, uri)
+	}
+
+	_, err := (&PaymentRequest{PaymentAddress: "invalid"}).URI()
+	assert.Equal(t, ErrCodeMessage[InvalidPaymentURIErr].code, err.(*WalletError).GetCode())
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"github.com/0xkraken/incognito-sdk-golang/common/base58"
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+)
+
+// Zero overwrites private key, receiving key and chain code of key with zeros
+// key can not be used to spend coins or derive child keys afterward
+func (key *KeyWallet) Zero() {
+	key.KeySet.Zero()
+	crypto.ZeroBytes(key.ChainCode)
+}
+
+// DeserializeSecureKey deserializes a key serialized by Base58CheckSerialize that is held by secureKey
+// the serialized key is not converted to a string, and intermediate buffers are zeroed
+// the caller should call Zero on the result after use
+func DeserializeSecureKey(secureKey *crypto.SecureKey) (*KeyWallet, error) {
+	var key *KeyWallet
+	err := secureKey.Use(func(serializedKey []byte) error {
+		data, _, err := base58.Base58Check{}.DecodeBytes(serializedKey)
+		if err != nil {
+			return err
+		}
+		defer crypto.ZeroBytes(data)
+
+		key, err = deserialize(data)
+		if err != nil {
+			return err
+		}
+		// chain code and child number are slices of data
+		key.ChainCode = append([]byte{}, key.ChainCode...)
+		key.ChildNumber = append([]byte{}, key.ChildNumber...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key.KeySet.PrivateKey) > 0 {
+		err = key.KeySet.InitFromPrivateKey(&key.KeySet.PrivateKey)
+		if err != nil {
+			key.Zero()
+			return nil, NewWalletError(InvalidSeserializedKey, err)
+		}
+	}
+	return key, nil
+}
+
+// SecurePrivateKey returns a copy of private key of key in a SecureKey
+func (key *KeyWallet) SecurePrivateKey() *crypto.SecureKey {
+	return crypto.NewSecureKeyFromCopy(key.KeySet.PrivateKey)
+}
@@ -0,0 +1,45 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/0xkraken/incognito-sdk-golang/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for zeroing keys and deserializing secure keys
+*/
+
+func TestDeserializeSecureKey(t *testing.T) {
+	key, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	privateKeyStr := key.Base58CheckSerialize(PriKeyType)
+
+	serializedKey := []byte(privateKeyStr)
+	secureKey := crypto.NewSecureKey(serializedKey)
+	decoded, err := DeserializeSecureKey(secureKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, key.KeySet, decoded.KeySet)
+	assert.Equal(t, key.ChainCode, decoded.ChainCode)
+	assert.Equal(t, privateKeyStr, decoded.Base58CheckSerialize(PriKeyType))
+
+	secureKey.Destroy()
+	assert.Equal(t, make([]byte, len(serializedKey)), serializedKey)
+	_, err = DeserializeSecureKey(secureKey)
+	assert.NotEqual(t, nil, err)
+
+	_, err = DeserializeSecureKey(crypto.NewSecureKey([]byte("invalid")))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestKeyWalletZero(t *testing.T) {
+	key, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	paymentAddress := key.Base58CheckSerialize(PaymentAddressType)
+
+	key.Zero()
+	assert.Equal(t, make([]byte, 32), []byte(key.KeySet.PrivateKey))
+	assert.Equal(t, make([]byte, 32), []byte(key.KeySet.ReadonlyKey.Rk))
+	assert.Equal(t, make([]byte, 32), key.ChainCode)
+	// public keys are not zeroed
+	assert.Equal(t, paymentAddress, key.Base58CheckSerialize(PaymentAddressType))
+}
@@ -0,0 +1,290 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0xkraken/incognito-sdk-golang/common"
+	"github.com/0xkraken/incognito-sdk-golang/incognitokey"
+)
+
+// AccountWallet is a named account of Wallet
+// it is derived from master key at Path, or imported from a private key (IsImported = true)
+// Index is the last child index of Path
+// a watch-only account only has payment address and readonly key, it can not spend coins
+type AccountWallet struct {
+	Name        string
+	Key         *KeyWallet
+	Path        string
+	Index       uint32
+	IsImported  bool
+	IsWatchOnly bool
+}
+
+// PrivateKeyStr returns serialized private key of account, it is empty for watch-only account
+func (account *AccountWallet) PrivateKeyStr() string {
+	if account.IsWatchOnly {
+		return ""
+	}
+	return account.Key.Base58CheckSerialize(PriKeyType)
+}
+
+// PaymentAddressStr returns serialized payment address of account
+func (account *AccountWallet) PaymentAddressStr() string {
+	return account.Key.Base58CheckSerialize(PaymentAddressType)
+}
+
+// ReadonlyKeyStr returns serialized readonly key of account
+func (account *AccountWallet) ReadonlyKeyStr() string {
+	return account.Key.Base58CheckSerialize(ReadonlyKeyType)
+}
+
+// Wallet holds a master key and named accounts
+// accounts are derived from master key with NewChildKey and account index
+type Wallet struct {
+	Name      string
+	Mnemonic  string // empty if wallet is not created from mnemonic
+	MasterKey *KeyWallet
+
+	accounts         []*AccountWallet
+	nextAccountIndex uint32
+}
+
+// NewWallet creates a new wallet with a random mnemonic from an entropy with entropyBits bits
+func NewWallet(name string, entropyBits int, mnemonicPassphrase string) (*Wallet, error) {
+	mnemonic, err := NewMnemonic(entropyBits)
+	if err != nil {
+		return nil, err
+	}
+	return NewWalletFromMnemonic(name, mnemonic, mnemonicPassphrase)
+}
+
+// NewWalletFromMnemonic restores wallet from a BIP39 mnemonic and its passphrase
+// accounts are not restored, call CreateNewAccount to derive them again in the same order
+func NewWalletFromMnemonic(name string, mnemonic string, mnemonicPassphrase string) (*Wallet, error) {
+	if name == "" {
+		return nil, NewWalletError(EmptyWalletNameErr, nil)
+	}
+	masterKey, err := NewMasterKeyFromMnemonic(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		Name:      name,
+		Mnemonic:  mnemonic,
+		MasterKey: masterKey,
+	}, nil
+}
+
+// NewWalletFromMasterKey creates wallet from an existing master key
+func NewWalletFromMasterKey(name string, masterKey *KeyWallet) (*Wallet, error) {
+	if name == "" {
+		return nil, NewWalletError(EmptyWalletNameErr, nil)
+	}
+	if masterKey == nil || len(masterKey.KeySet.PrivateKey) != common.PrivateKeySize {
+		return nil, NewWalletError(InvalidKeyTypeErr, errors.New("master key must contain private key"))
+	}
+	return &Wallet{
+		Name:      name,
+		MasterKey: masterKey,
+	}, nil
+}
+
+// CreateNewAccount derives a new account at the next account index with name accountName
+func (w *Wallet) CreateNewAccount(accountName string) (*AccountWallet, error) {
+	if w.findAccountByName(accountName) != nil {
+		return nil, NewWalletError(ExistedAccountNameErr, nil)
+	}
+
+	// skip indices that are already added by CreateAccountFromPath
+	var childKey *KeyWallet
+	var err error
+	for {
+		childKey, err = w.MasterKey.NewChildKey(w.nextAccountIndex)
+		if err != nil {
+			return nil, err
+		}
+		if w.findAccountByKey(childKey) == nil {
+			break
+		}
+		w.nextAccountIndex++
+	}
+
+	account := &AccountWallet{
+		Name:  accountName,
+		Key:   childKey,
+		Path:  FormatDerivationPath([]uint32{w.nextAccountIndex}),
+		Index: w.nextAccountIndex,
+	}
+	w.accounts = append(w.accounts, account)
+	w.nextAccountIndex++
+	return account, nil
+}
+
+// CreateAccountFromPath derives an account at path (eg: m/44'/587'/0'/0/5) with name accountName
+func (w *Wallet) CreateAccountFromPath(accountName string, path string) (*AccountWallet, error) {
+	if w.findAccountByName(accountName) != nil {
+		return nil, NewWalletError(ExistedAccountNameErr, nil)
+	}
+
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 {
+		return nil, NewWalletError(InvalidDerivationPathErr, errors.New("path of account must not be master key"))
+	}
+	childKey, err := w.MasterKey.DeriveFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if w.findAccountByKey(childKey) != nil {
+		return nil, NewWalletError(ExistedAccountErr, nil)
+	}
+
+	account := &AccountWallet{
+		Name:  accountName,
+		Key:   childKey,
+		Path:  FormatDerivationPath(indices),
+		Index: indices[len(indices)-1],
+	}
+	w.accounts = append(w.accounts, account)
+	return account, nil
+}
+
+// ImportAccount adds an account from a serialized private key with name accountName
+func (w *Wallet) ImportAccount(privateKeyStr string, accountName string) (*AccountWallet, error) {
+	if w.findAccountByName(accountName) != nil {
+		return nil, NewWalletError(ExistedAccountNameErr, nil)
+	}
+
+	key, err := newKeyWalletFromPrivateKeyStr(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if w.findAccountByKey(key) != nil {
+		return nil, NewWalletError(ExistedAccountErr, nil)
+	}
+
+	account := &AccountWallet{
+		Name:       accountName,
+		Key:        key,
+		IsImported: true,
+	}
+	w.accounts = append(w.accounts, account)
+	return account, nil
+}
+
+// ImportWatchOnlyAccount adds a watch-only account from serialized payment address and readonly key
+// the account can be used to view incoming coins without holding spending key
+func (w *Wallet) ImportWatchOnlyAccount(paymentAddressStr string, readonlyKeyStr string, accountName string) (*AccountWallet, error) {
+	if w.findAccountByName(accountName) != nil {
+		return nil, NewWalletError(ExistedAccountNameErr, nil)
+	}
+
+	key, err := NewWatchOnlyKeyWallet(paymentAddressStr, readonlyKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if w.findAccountByKey(key) != nil {
+		return nil, NewWalletError(ExistedAccountErr, nil)
+	}
+
+	account := &AccountWallet{
+		Name:        accountName,
+		Key:         key,
+		IsImported:  true,
+		IsWatchOnly: true,
+	}
+	w.accounts = append(w.accounts, account)
+	return account, nil
+}
+
+// ListAccounts returns all accounts of wallet in the order they were added
+func (w *Wallet) ListAccounts() []*AccountWallet {
+	accounts := make([]*AccountWallet, len(w.accounts))
+	copy(accounts, w.accounts)
+	return accounts
+}
+
+// GetAccount returns account with name accountName
+func (w *Wallet) GetAccount(accountName string) (*AccountWallet, error) {
+	account := w.findAccountByName(accountName)
+	if account == nil {
+		return nil, NewWalletError(NotFoundAccountErr, nil)
+	}
+	return account, nil
+}
+
+// RemoveAccount removes account with name accountName from wallet
+// the index of a removed derived account is not reused
+func (w *Wallet) RemoveAccount(accountName string) error {
+	for i, account := range w.accounts {
+		if account.Name == accountName {
+			w.accounts = append(w.accounts[:i], w.accounts[i+1:]...)
+			return nil
+		}
+	}
+	return NewWalletError(NotFoundAccountErr, nil)
+}
+
+func (w *Wallet) findAccountByName(accountName string) *AccountWallet {
+	for _, account := range w.accounts {
+		if account.Name == accountName {
+			return account
+		}
+	}
+	return nil
+}
+
+func (w *Wallet) findAccountByKey(key *KeyWallet) *AccountWallet {
+	for _, account := range w.accounts {
+		if bytes.Equal(account.Key.KeySet.PaymentAddress.Pk, key.KeySet.PaymentAddress.Pk) {
+			return account
+		}
+	}
+	return nil
+}
+
+// newKeyWalletFromPrivateKeyStr deserializes private key string
+// and regenerates payment address and readonly key
+func newKeyWalletFromPrivateKeyStr(privateKeyStr string) (*KeyWallet, error) {
+	key, err := Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(key.KeySet.PrivateKey) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	err = key.KeySet.InitFromPrivateKey(&key.KeySet.PrivateKey)
+	if err != nil {
+		return nil, NewWalletError(InvalidSeserializedKey, err)
+	}
+	return key, nil
+}
+
+// NewWatchOnlyKeyWallet creates a key wallet without private key from serialized payment address and readonly key
+// it returns error if they are not keys of the same account
+func NewWatchOnlyKeyWallet(paymentAddressStr string, readonlyKeyStr string) (*KeyWallet, error) {
+	paymentAddressKey, err := Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	readonlyKey, err := Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(paymentAddressKey.KeySet.PaymentAddress.Pk) == 0 || len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, errors.New("require payment address and readonly key"))
+	}
+	if !bytes.Equal(paymentAddressKey.KeySet.PaymentAddress.Pk, readonlyKey.KeySet.ReadonlyKey.Pk) {
+		return nil, NewWalletError(InvalidSeserializedKey, errors.New("payment address and readonly key are not of the same account"))
+	}
+
+	return &KeyWallet{
+		KeySet: incognitokey.KeySet{
+			PaymentAddress: paymentAddressKey.KeySet.PaymentAddress,
+			ReadonlyKey:    readonlyKey.KeySet.ReadonlyKey,
+		},
+	}, nil
+}
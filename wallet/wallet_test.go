@@ -0,0 +1,113 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for Wallet account functions
+*/
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestWalletCreateNewAccount(t *testing.T) {
+	w, err := NewWalletFromMnemonic("test", testMnemonic, "")
+	assert.Equal(t, nil, err)
+
+	account0, err := w.CreateNewAccount("account 0")
+	assert.Equal(t, nil, err)
+	account1, err := w.CreateNewAccount("account 1")
+	assert.Equal(t, nil, err)
+
+	// accounts are derived the same as NewChildKey of master key
+	childKey0, _ := w.MasterKey.NewChildKey(0)
+	childKey1, _ := w.MasterKey.NewChildKey(1)
+	assert.Equal(t, uint32(0), account0.Index)
+	assert.Equal(t, childKey0.KeySet.PrivateKey, account0.Key.KeySet.PrivateKey)
+	assert.Equal(t, uint32(1), account1.Index)
+	assert.Equal(t, childKey1.KeySet.PrivateKey, account1.Key.KeySet.PrivateKey)
+
+	_, err = w.CreateNewAccount("account 1")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountNameErr].code, err.(*WalletError).GetCode())
+
+	// index of removed account is not reused
+	assert.Equal(t, nil, w.RemoveAccount("account 1"))
+	account2, err := w.CreateNewAccount("account 2")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(2), account2.Index)
+
+	assert.Equal(t, 2, len(w.ListAccounts()))
+	err = w.RemoveAccount("account 1")
+	assert.Equal(t, ErrCodeMessage[NotFoundAccountErr].code, err.(*WalletError).GetCode())
+}
+
+func TestWalletImportAccount(t *testing.T) {
+	w, err := NewWalletFromMnemonic("test", testMnemonic, "")
+	assert.Equal(t, nil, err)
+	account0, _ := w.CreateNewAccount("account 0")
+
+	other, _ := NewMasterKeyFromMnemonic(testMnemonic, "other")
+	privateKeyStr := other.Base58CheckSerialize(PriKeyType)
+
+	imported, err := w.ImportAccount(privateKeyStr, "imported")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, imported.IsImported)
+	assert.Equal(t, other.KeySet.PaymentAddress, imported.Key.KeySet.PaymentAddress)
+	assert.Equal(t, other.Base58CheckSerialize(PaymentAddressType), imported.PaymentAddressStr())
+
+	_, err = w.ImportAccount(privateKeyStr, "imported 2")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountErr].code, err.(*WalletError).GetCode())
+	_, err = w.ImportAccount(account0.PrivateKeyStr(), "account 0")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountNameErr].code, err.(*WalletError).GetCode())
+	_, err = w.ImportAccount(account0.PaymentAddressStr(), "payment address")
+	assert.NotEqual(t, nil, err)
+
+	account, err := w.GetAccount("imported")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, imported, account)
+	_, err = w.GetAccount("unknown")
+	assert.Equal(t, ErrCodeMessage[NotFoundAccountErr].code, err.(*WalletError).GetCode())
+}
+
+func TestWalletNewWallet(t *testing.T) {
+	_, err := NewWallet("", 128, "")
+	assert.Equal(t, ErrCodeMessage[EmptyWalletNameErr].code, err.(*WalletError).GetCode())
+
+	w, err := NewWallet("test", 256, "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, IsMnemonicValid(w.Mnemonic))
+
+	restored, err := NewWalletFromMnemonic("restored", w.Mnemonic, "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, w.MasterKey.KeySet.PrivateKey, restored.MasterKey.KeySet.PrivateKey)
+}
+
+func TestWalletImportWatchOnlyAccount(t *testing.T) {
+	w, _ := NewWalletFromMnemonic("test", testMnemonic, "")
+	other, _ := NewMasterKeyFromMnemonic(testMnemonic, "other")
+	paymentAddressStr := other.Base58CheckSerialize(PaymentAddressType)
+	readonlyKeyStr := other.Base58CheckSerialize(ReadonlyKeyType)
+
+	account, err := w.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "watch")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, account.IsWatchOnly)
+	assert.Equal(t, 0, len(account.Key.KeySet.PrivateKey))
+	assert.Equal(t, "", account.PrivateKeyStr())
+	assert.Equal(t, paymentAddressStr, account.PaymentAddressStr())
+	assert.Equal(t, readonlyKeyStr, account.ReadonlyKeyStr())
+
+	_, err = w.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "watch 2")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountErr].code, err.(*WalletError).GetCode())
+	_, err = w.ImportAccount(other.Base58CheckSerialize(PriKeyType), "spending")
+	assert.Equal(t, ErrCodeMessage[ExistedAccountErr].code, err.(*WalletError).GetCode())
+
+	// keys of different accounts
+	master, _ := NewMasterKeyFromMnemonic(testMnemonic, "")
+	_, err = w.ImportWatchOnlyAccount(paymentAddressStr, master.Base58CheckSerialize(ReadonlyKeyType), "mismatch")
+	assert.NotEqual(t, nil, err)
+	// private key is not accepted as readonly key
+	_, err = w.ImportWatchOnlyAccount(paymentAddressStr, other.Base58CheckSerialize(PriKeyType), "private")
+	assert.NotEqual(t, nil, err)
+}